@@ -56,6 +56,27 @@ func (T *qClient) drawStringScaled(x, y int, s string, factor float32) {
 	}
 }
 
+func (T *qClient) drawAltStringScaled(x, y int, s string, factor float32) {
+	for i := 0; i < len(s); i++ {
+		T.Draw_CharScaled(x, y, int(s[i]^0x80), factor)
+		x += int(8 * factor)
+	}
+}
+
+func con_MessageMode_f(args []string, a interface{}) error {
+	T := a.(*qClient)
+	T.chat_team = false
+	T.cls.key_dest = key_message
+	return nil
+}
+
+func con_MessageMode2_f(args []string, a interface{}) error {
+	T := a.(*qClient)
+	T.chat_team = true
+	T.cls.key_dest = key_message
+	return nil
+}
+
 /*
  * If the line width has changed, reformat the buffer.
  */
@@ -136,13 +157,82 @@ func (T *qClient) conInit() {
 
 	//  Cmd_AddCommand("toggleconsole", Con_ToggleConsole_f);
 	//  Cmd_AddCommand("togglechat", Con_ToggleChat_f);
-	//  Cmd_AddCommand("messagemode", Con_MessageMode_f);
-	//  Cmd_AddCommand("messagemode2", Con_MessageMode2_f);
+	T.common.Cmd_AddCommand("messagemode", con_MessageMode_f, T)
+	T.common.Cmd_AddCommand("messagemode2", con_MessageMode2_f, T)
 	//  Cmd_AddCommand("clear", Con_Clear_f);
 	//  Cmd_AddCommand("condump", Con_Dump_f);
 	T.con.initialized = true
 }
 
+/*
+ * Draws the last few lines of output transparently
+ * over the game top
+ */
+func (T *qClient) conDrawNotify() {
+	scale := T.scrGetConsoleScale()
+	v := 0
+
+	// for (i = con.current - NUM_CON_TIMES + 1; i <= con.current; i++)
+	// {
+	// 	if (i < 0)
+	// 	{
+	// 		continue;
+	// 	}
+
+	// 	time = con.times[i % NUM_CON_TIMES];
+
+	// 	if (time == 0)
+	// 	{
+	// 		continue;
+	// 	}
+
+	// 	time = cls.realtime - time;
+
+	// 	if (time > con_notifytime->value * 1000)
+	// 	{
+	// 		continue;
+	// 	}
+
+	// 	text = con.text + (i % con.totallines) * con.linewidth;
+
+	// 	for (x = 0; x < con.linewidth; x++)
+	// 	{
+	// 		Draw_CharScaled(((x + 1) << 3) * scale, v * scale, text[x], scale);
+	// 	}
+
+	// 	v += 8;
+	// }
+
+	if T.cls.key_dest == key_message {
+		skip := 5
+		if T.chat_team {
+			T.drawStringScaled(int(8*scale), int(float32(v)*scale), "say_team:", scale)
+			skip = 11
+		} else {
+			T.drawStringScaled(int(8*scale), int(float32(v)*scale), "say:", scale)
+		}
+
+		s := T.chat_buffer
+		if len(s) > (T.viddef.width>>3)-(skip+1) {
+			s = s[len(s)-((T.viddef.width>>3)-(skip+1)):]
+		}
+
+		x := 0
+		for ; x < len(s); x++ {
+			T.Draw_CharScaled(int(float32((x+skip)<<3)*scale), int(float32(v)*scale), int(s[x]), scale)
+		}
+
+		T.Draw_CharScaled(int(float32((x+skip)<<3)*scale), int(float32(v)*scale),
+			10+((T.cls.realtime>>8)&1), scale)
+		v += 8
+	}
+
+	if v != 0 {
+		T.scrAddDirtyPoint(0, 0)
+		T.scrAddDirtyPoint(T.viddef.width-1, v)
+	}
+}
+
 /*
  * Draws the console with the solid background
  */
@@ -451,7 +451,7 @@ func (T *qClient) requestNextDownload() {
 	// 	dlquirks.filelist = true;
 	// #endif
 
-	// 	CL_RegisterSounds();
+	T.registerSounds()
 	T.prepRefresh()
 
 	T.cls.netchan.Message.WriteByte(shared.ClcStringcmd)
@@ -66,20 +66,20 @@ func (T *qClient) addMuzzleFlash(msg *shared.QReadbuf) error {
 	dl.minlight = 32
 	dl.die = float32(T.cl.time)
 
-	// if (silenced) {
-	// 	volume = 0.2f;
-	// } else {
-	// 	volume = 1;
-	// }
+	var volume float32
+	if silenced {
+		volume = 0.2
+	} else {
+		volume = 1
+	}
 
 	switch weapon {
 	case shared.MZ_BLASTER:
 		dl.color[0] = 1
 		dl.color[1] = 1
 		dl.color[2] = 0
-		// 		S_StartSound(NULL, i, CHAN_WEAPON,
-		// 			S_RegisterSound("weapons/blastf1a.wav"), volume, ATTN_NORM, 0);
-		// 		break;
+		T.sStartSound(nil, i, shared.CHAN_WEAPON,
+			T.sRegisterSound("weapons/blastf1a.wav"), volume, shared.ATTN_NORM, 0)
 		// 	case MZ_BLUEHYPERBLASTER:
 		// 		dl->color[0] = 0;
 		// 		dl->color[1] = 0;
@@ -703,9 +703,9 @@ func (T *qClient) addEntities() {
 		T.cl.lerpfrac = 1.0 - float32(T.cl.frame.servertime-T.cl.time)*0.01
 	}
 
-	// if (T.cl_timedemo.Bool()) {
-	// 	T.cl.lerpfrac = 1.0;
-	// }
+	if T.cl_timedemo.Bool() {
+		T.cl.lerpfrac = 1.0
+	}
 
 	T.calcViewValues()
 	T.addPacketEntities(&T.cl.frame)
@@ -461,12 +461,12 @@ func (T *qClient) sendCmd() error {
 	}
 
 	/* send a userinfo update if needed */
-	// if (userinfo_modified) {
-	// 	CL_FixUpGender();
-	// 	userinfo_modified = false;
-	// 	MSG_WriteByte(&cls.netchan.message, clc_userinfo);
-	// 	MSG_WriteString(&cls.netchan.message, Cvar_Userinfo());
-	// }
+	if T.common.Cvar_UserinfoModified() {
+		// CL_FixUpGender();
+		T.common.Cvar_ClearUserinfoModified()
+		T.cls.netchan.Message.WriteByte(shared.ClcUserinfo)
+		T.cls.netchan.Message.WriteString(T.common.Cvar_Userinfo())
+	}
 
 	buf := shared.QWritebufCreate(shared.MAX_MSGLEN)
 
@@ -487,14 +487,11 @@ func (T *qClient) sendCmd() error {
 	/* let the server know what the last frame we
 	   got was, so the next message can be delta
 	   compressed */
-	// if (cl_nodelta->value || !cl.frame.valid || cls.demowaiting)
-	// {
-	// 	MSG_WriteLong(&buf, -1); /* no compression */
-	// }
-	// else
-	// {
-	buf.WriteLong(T.cl.frame.serverframe)
-	// }
+	if !T.cl.frame.valid || T.cls.demowaiting {
+		buf.WriteLong(-1) /* no compression */
+	} else {
+		buf.WriteLong(T.cl.frame.serverframe)
+	}
 
 	/* send this and the previous cmds in the message, so
 	   if the last packet was dropped, it can be recovered */
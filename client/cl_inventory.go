@@ -0,0 +1,136 @@
+/*
+ * Copyright (C) 1997-2001 Id Software, Inc.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or (at
+ * your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ *
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 59 Temple Place - Suite 330, Boston, MA
+ * 02111-1307, USA.
+ *
+ * =======================================================================
+ *
+ * This file implements the inventory screen.
+ *
+ * =======================================================================
+ */
+package client
+
+import (
+	"fmt"
+	"goquake2/shared"
+	"strings"
+)
+
+const DISPLAY_ITEMS = 17
+
+func (T *qClient) parseInventory(msg *shared.QReadbuf) {
+	for i := 0; i < shared.MAX_ITEMS; i++ {
+		T.cl.inventory[i] = msg.ReadShort()
+	}
+}
+
+func (T *qClient) invDrawStringScaled(x, y int, s string, factor float32) {
+	for i := 0; i < len(s); i++ {
+		T.Draw_CharScaled(x, y, int(s[i]), factor)
+		x += int(8 * factor)
+	}
+}
+
+func setStringHighBit(s string) string {
+	b := []byte(s)
+	for i := range b {
+		b[i] |= 128
+	}
+
+	return string(b)
+}
+
+func (T *qClient) drawInventory() {
+	var index [shared.MAX_ITEMS]int
+
+	scale := T.scrGetHUDScale()
+
+	selected := int(T.cl.frame.playerstate.Stats[shared.STAT_SELECTED_ITEM])
+
+	num := 0
+	selected_num := 0
+
+	for i := 0; i < shared.MAX_ITEMS; i++ {
+		if i == selected {
+			selected_num = num
+		}
+
+		if T.cl.inventory[i] != 0 {
+			index[num] = i
+			num++
+		}
+	}
+
+	/* determine scroll point */
+	top := selected_num - DISPLAY_ITEMS/2
+
+	if num-top < DISPLAY_ITEMS {
+		top = num - DISPLAY_ITEMS
+	}
+
+	if top < 0 {
+		top = 0
+	}
+
+	x := int((float32(T.viddef.width) - scale*256) / 2)
+	y := int((float32(T.viddef.height) - scale*240) / 2)
+
+	/* repaint everything next frame */
+	T.scrDirtyScreen()
+
+	T.Draw_PicScaled(x, y+int(scale*8), "inventory", scale)
+
+	y += int(scale * 24)
+	x += int(scale * 24)
+
+	T.invDrawStringScaled(x, y, "hotkey ### item", scale)
+	T.invDrawStringScaled(x, y+int(scale*8), "------ --- ----", scale)
+
+	y += int(scale * 16)
+
+	for i := top; i < num && i < top+DISPLAY_ITEMS; i++ {
+		item := index[i]
+
+		/* search for a binding */
+		binding := "use " + T.cl.configstrings[shared.CS_ITEMS+item]
+		bind := ""
+
+		for j := range T.keybindings {
+			if len(T.keybindings[j]) > 0 && strings.EqualFold(T.keybindings[j], binding) {
+				bind = keyKeynumToString(j)
+				break
+			}
+		}
+
+		str := fmt.Sprintf("%6s %3d %s", bind, T.cl.inventory[item],
+			T.cl.configstrings[shared.CS_ITEMS+item])
+
+		if item != selected {
+			str = setStringHighBit(str)
+		} else {
+			/* draw a blinky cursor by the selected item */
+			if (int(float32(T.cls.realtime)*10) & 1) != 0 {
+				T.Draw_CharScaled(x-int(scale*8), y, 15, scale)
+			}
+		}
+
+		T.invDrawStringScaled(x, y, str, scale)
+
+		y += int(scale * 8)
+	}
+}
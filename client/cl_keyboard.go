@@ -30,9 +30,51 @@ package client
 
 import (
 	"fmt"
+	"goquake2/shared"
 	"strings"
 )
 
+func (T *qClient) keyMessage(key int) {
+	if (key == K_ENTER) || (key == K_KP_ENTER) {
+		if T.chat_team {
+			T.common.Cbuf_AddText("say_team \"")
+		} else {
+			T.common.Cbuf_AddText("say \"")
+		}
+
+		T.common.Cbuf_AddText(T.chat_buffer)
+		T.common.Cbuf_AddText("\"\n")
+
+		T.cls.key_dest = key_game
+		T.chat_buffer = ""
+		return
+	}
+
+	if key == K_ESCAPE {
+		T.cls.key_dest = key_game
+		T.chat_buffer = ""
+		return
+	}
+
+	if key == K_BACKSPACE {
+		if len(T.chat_buffer) > 0 {
+			T.chat_buffer = T.chat_buffer[:len(T.chat_buffer)-1]
+		}
+
+		return
+	}
+
+	if (key < 32) || (key > 127) {
+		return /* non printable charcter */
+	}
+
+	if len(T.chat_buffer) == MAXCMDLINE-1 {
+		return /* all full, this should never happen on modern systems */
+	}
+
+	T.chat_buffer += string(rune(key))
+}
+
 /*
  * Returns a key number to be used to index
  * keybindings[] by looking at the given string.
@@ -58,6 +100,29 @@ func keyStringToKeynum(str string) int {
 	return -1
 }
 
+/*
+ * Returns a string (either a single ascii char, a K_* name, or a 0x11 hex string) for the
+ * given keynum.
+ */
+func keyKeynumToString(keynum int) string {
+	if keynum == -1 {
+		return "<KEY NOT FOUND>"
+	}
+
+	if (keynum > 32) && (keynum < 127) && keynum != ';' && keynum != '"' {
+		/* printable ASCII */
+		return string(rune(keynum))
+	}
+
+	for _, kn := range keynames {
+		if keynum == kn.keynum {
+			return kn.name
+		}
+	}
+
+	return "<UNKNOWN KEYNUM>"
+}
+
 func (T *qClient) keySetBinding(keynum int, binding string) {
 	if keynum == -1 {
 		return
@@ -198,7 +263,7 @@ func (T *qClient) KeyInit() {
 /*
  * Called every frame for every detected keypress.
  * This is only for movement and special characters,
- * anything else is handled by Char_Event().
+ * anything else is handled by charEvent().
  */
 func (T *qClient) KeyEvent(key int, down, special bool) {
 	// char cmd[1024];
@@ -303,17 +368,17 @@ func (T *qClient) KeyEvent(key int, down, special bool) {
 				return
 			}
 
-			// 		/* Close the help computer */
-			// 		if (cl.frame.playerstate.stats[STAT_LAYOUTS] &&
-			// 			(cls.key_dest == key_game)) {
-			// 			Cbuf_AddText("cmd putaway\n");
-			// 			return;
-			// 		}
+			/* Close the help computer */
+			if T.cl.frame.playerstate.Stats[shared.STAT_LAYOUTS] != 0 &&
+				(T.cls.key_dest == key_game) {
+				T.common.Cbuf_AddText("cmd putaway\n")
+				return
+			}
 
 			switch T.cls.key_dest {
-			// 			/* Close chat window */
-			// 			case key_message:
-			// 				Key_Message(key);
+			/* Close chat window */
+			case key_message:
+				T.keyMessage(key)
 
 			/* Close menu or one layer up */
 			case key_menu:
@@ -399,17 +464,16 @@ func (T *qClient) KeyEvent(key int, down, special bool) {
 	   care for key down events (=> if(!down) returns above). */
 
 	/* Everything that's not a special char
-	   is processed by Char_Event(). */
+	   is processed by charEvent(). */
 	if !special {
 		return
 	}
 
 	/* Send key to the active input subsystem */
 	switch T.cls.key_dest {
-	// 	/* Chat */
-	// 	case key_message:
-	// 		Key_Message(key);
-	// 		break;
+	/* Chat */
+	case key_message:
+		T.keyMessage(key)
 
 	/* Menu */
 	case key_menu:
@@ -423,6 +487,25 @@ func (T *qClient) KeyEvent(key int, down, special bool) {
 	}
 }
 
+/*
+ * This is only for characters that are
+ * printed, everything else goes through
+ * KeyEvent().
+ */
+func (T *qClient) charEvent(key int) {
+	switch T.cls.key_dest {
+	/* Chat */
+	case key_message:
+		T.keyMessage(key)
+
+		// 	/* Console */
+		// 	case key_game:
+		// 	case key_console:
+		// 		Key_Console(key);
+		// 		break;
+	}
+}
+
 type keyname_t struct {
 	name   string
 	keynum int
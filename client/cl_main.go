@@ -27,12 +27,149 @@
 package client
 
 import (
+	"encoding/binary"
+	"fmt"
 	"goquake2/shared"
+	"os"
 	"strconv"
+	"strings"
 )
 
+/*
+ * Dumps the current net message, prefixed by the length
+ */
+func (T *qClient) writeDemoMessage(msg *shared.QReadbuf) {
+	/* the first eight bytes are just packet sequencing stuff */
+	T.writeDemoBlock(msg.Data()[8:])
+}
+
+func (T *qClient) writeDemoBlock(data []byte) {
+	var swlen [4]byte
+	binary.LittleEndian.PutUint32(swlen[:], uint32(len(data)))
+	T.cls.demofile.Write(swlen[:])
+	T.cls.demofile.Write(data)
+}
+
+/*
+ * stop recording a demo
+ */
+func cl_Stop_f(args []string, a interface{}) error {
+	T := a.(*qClient)
+
+	if !T.cls.demorecording {
+		T.common.Com_Printf("Not recording a demo.\n")
+		return nil
+	}
+
+	/* finish up */
+	var swlen [4]byte
+	binary.LittleEndian.PutUint32(swlen[:], 0xFFFFFFFF)
+	T.cls.demofile.Write(swlen[:])
+	T.cls.demofile.Close()
+	T.cls.demofile = nil
+	T.cls.demorecording = false
+	T.common.Com_Printf("Stopped demo.\n")
+	return nil
+}
+
+/*
+ * record <demoname>
+ *
+ * Begins recording a demo from the current position
+ */
+func cl_Record_f(args []string, a interface{}) error {
+	T := a.(*qClient)
+
+	if len(args) != 2 {
+		T.common.Com_Printf("record <demoname>\n")
+		return nil
+	}
+
+	if T.cls.demorecording {
+		T.common.Com_Printf("Already recording.\n")
+		return nil
+	}
+
+	if T.cls.state != ca_active {
+		T.common.Com_Printf("You must be in a level to record.\n")
+		return nil
+	}
+
+	/* open the demo file */
+	name := fmt.Sprintf("%s/demos/%s.dm2", T.common.FS_Gamedir(), args[1])
+
+	T.common.Com_Printf("recording to %s.\n", name)
+	T.common.FS_CreatePath(name)
+	f, err := os.Create(name)
+	if err != nil {
+		T.common.Com_Printf("ERROR: couldn't open.\n")
+		return nil
+	}
+
+	T.cls.demofile = f
+	T.cls.demorecording = true
+
+	/* don't start saving messages until a non-delta compressed message is received */
+	T.cls.demowaiting = true
+
+	/* write out messages to hold the startup information */
+	buf := shared.QWritebufCreate(shared.MAX_MSGLEN)
+
+	/* send the serverdata */
+	buf.WriteByte(shared.SvcServerdata)
+	buf.WriteLong(shared.PROTOCOL_VERSION)
+	buf.WriteLong(0x10000 + T.cl.servercount)
+	buf.WriteByte(1) /* demos are always attract loops */
+	buf.WriteString(T.cl.gamedir)
+	buf.WriteShort(T.cl.playernum)
+
+	buf.WriteString(T.cl.configstrings[shared.CS_NAME])
+
+	/* configstrings */
+	for i, cs := range T.cl.configstrings {
+		if len(cs) > 0 {
+			if buf.Cursize+len(cs)+32 > shared.MAX_MSGLEN {
+				/* write it out */
+				T.writeDemoBlock(buf.Data())
+				buf.Clear()
+			}
+
+			buf.WriteByte(shared.SvcConfigstring)
+			buf.WriteShort(i)
+			buf.WriteString(cs)
+		}
+	}
+
+	/* baselines */
+	nullstate := shared.Entity_state_t{}
+	for i := range T.cl_entities {
+		ent := &T.cl_entities[i].baseline
+		if ent.Modelindex == 0 {
+			continue
+		}
+
+		if buf.Cursize+64 > shared.MAX_MSGLEN {
+			/* write it out */
+			T.writeDemoBlock(buf.Data())
+			buf.Clear()
+		}
+
+		buf.WriteByte(shared.SvcSpawnbaseline)
+		buf.WriteDeltaEntity(&nullstate, ent, true, true)
+	}
+
+	buf.WriteByte(shared.SvcStufftext)
+	buf.WriteString("precache\n")
+
+	/* write it to the demo file */
+	T.writeDemoBlock(buf.Data())
+
+	/* the rest of the demo file will be individual frames */
+	return nil
+}
+
 func (T *qClient) clearState() {
-	// S_StopAllSounds();
+	T.sStopAllSounds()
 	T.clearEffects()
 	// T.clearTEnts()
 
@@ -61,7 +198,7 @@ func cl_Precache_f(args []string, a interface{}) error {
 		if _, err := T.common.CMLoadMap(T.cl.configstrings[shared.CS_MODELS+1], true, &map_checksum); err != nil {
 			return err
 		}
-		//  CL_RegisterSounds();
+		T.registerSounds()
 		return T.prepRefresh()
 	}
 
@@ -76,6 +213,61 @@ func cl_Precache_f(args []string, a interface{}) error {
 	return nil
 }
 
+/*
+ * Send the rest of the command line over as
+ * an unconnected command.
+ */
+func cl_Rcon_f(args []string, a interface{}) error {
+	T := a.(*qClient)
+
+	if len(T.rcon_client_password.String) == 0 {
+		T.common.Com_Printf("You must set 'rcon_password' before\n" +
+			"issuing an rcon command.\n")
+		return nil
+	}
+
+	T.common.NET_Config(true) /* allow remote */
+
+	var message strings.Builder
+	message.WriteString("rcon ")
+	message.WriteString(T.rcon_client_password.String)
+	message.WriteString(" ")
+
+	for i := 1; i < len(args); i++ {
+		if strings.ContainsAny(args[i], " ;") {
+			message.WriteString("\"" + args[i] + "\"")
+		} else {
+			message.WriteString(args[i])
+		}
+		message.WriteString(" ")
+	}
+
+	var to shared.Netadr_t
+	if T.cls.state >= ca_connected {
+		to = T.cls.netchan.Remote_address
+	} else {
+		if len(T.rcon_address.String) == 0 {
+			T.common.Com_Printf("You must either be connected,\n" +
+				"or set the 'rcon_address' cvar\n" +
+				"to issue rcon commands\n")
+			return nil
+		}
+
+		adr := shared.NET_StringToAdr(T.rcon_address.String)
+		if adr == nil {
+			T.common.Com_Printf("Bad rcon_address: %s\n", T.rcon_address.String)
+			return nil
+		}
+
+		to = *adr
+		if to.Port == 0 {
+			to.Port = shared.PORT_SERVER
+		}
+	}
+
+	return T.common.Netchan_OutOfBandPrint(shared.NS_CLIENT, to, "%s", message.String())
+}
+
 func (T *qClient) initLocal() {
 	T.cls.state = ca_disconnected
 	T.cls.realtime = T.common.Sys_Milliseconds()
@@ -109,6 +301,7 @@ func (T *qClient) initLocal() {
 	T.cl_showmiss = T.common.Cvar_Get("cl_showmiss", "0", 0)
 	T.cl_showclamp = T.common.Cvar_Get("showclamp", "0", 0)
 	T.cl_timeout = T.common.Cvar_Get("cl_timeout", "120", 0)
+	T.cl_timedemo = T.common.Cvar_Get("timedemo", "0", 0)
 	T.cl_paused = T.common.Cvar_Get("paused", "0", 0)
 	T.cl_loadpaused = T.common.Cvar_Get("cl_loadpaused", "1", shared.CVAR_ARCHIVE)
 
@@ -162,19 +355,19 @@ func (T *qClient) initLocal() {
 	// 	Cmd_AddCommand("skins", CL_Skins_f);
 
 	// 	Cmd_AddCommand("userinfo", CL_Userinfo_f);
-	// 	Cmd_AddCommand("snd_restart", CL_Snd_Restart_f);
+	T.common.Cmd_AddCommand("snd_restart", cl_Snd_Restart_f, T)
 
 	T.common.Cmd_AddCommand("changing", cl_Changing_f, T)
-	// 	Cmd_AddCommand("disconnect", CL_Disconnect_f);
-	// 	Cmd_AddCommand("record", CL_Record_f);
-	// 	Cmd_AddCommand("stop", CL_Stop_f);
+	T.common.Cmd_AddCommand("disconnect", cl_Disconnect_f, T)
+	T.common.Cmd_AddCommand("record", cl_Record_f, T)
+	T.common.Cmd_AddCommand("stop", cl_Stop_f, T)
 
 	// 	Cmd_AddCommand("quit", CL_Quit_f);
 
-	// 	Cmd_AddCommand("connect", CL_Connect_f);
+	T.common.Cmd_AddCommand("connect", cl_Connect_f, T)
 	T.common.Cmd_AddCommand("reconnect", cl_Reconnect_f, T)
 
-	// 	Cmd_AddCommand("rcon", CL_Rcon_f);
+	T.common.Cmd_AddCommand("rcon", cl_Rcon_f, T)
 
 	// 	Cmd_AddCommand("setenv", CL_Setenv_f);
 
@@ -270,10 +463,10 @@ func (T *qClient) Frame(packetdelta, renderdelta, timedelta int, packetframe, re
 		}
 	}
 
-	// 	if (cls.forcePacket || userinfo_modified) {
-	// 		packetframe = true;
-	// 		cls.forcePacket = false;
-	// 	}
+	if T.cls.forcePacket || T.common.Cvar_UserinfoModified() {
+		packetframe = true
+		T.cls.forcePacket = false
+	}
 
 	if packetframe {
 		if err := T.sendCmd(); err != nil {
@@ -313,8 +506,8 @@ func (T *qClient) Frame(packetdelta, renderdelta, timedelta int, packetframe, re
 		// 			time_after_ref = Sys_Milliseconds();
 		// 		}
 
-		// 		/* update audio */
-		// 		S_Update(cl.refdef.vieworg, cl.v_forward, cl.v_right, cl.v_up);
+		/* update audio */
+		T.sUpdate(T.cl.refdef.Vieworg[:], T.cl.v_forward[:], T.cl.v_right[:], T.cl.v_up[:])
 
 		/* advance local effects for next frame */
 		T.runDLights()
@@ -359,7 +552,7 @@ func (T *qClient) Init() error {
 	/* all archived variables will now be loaded */
 	T.conInit()
 
-	// 	S_Init();
+	T.sInit()
 
 	T.scrInit()
 
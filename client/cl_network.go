@@ -132,18 +132,15 @@ func (T *qClient) disconnect() {
 		return
 	}
 
-	//  if (cl_timedemo && cl_timedemo->value) {
-	// 	 int time;
+	if T.cl_timedemo != nil && T.cl_timedemo.Bool() {
+		time := T.common.Sys_Milliseconds() - T.cl.timedemo_start
 
-	// 	 time = Sys_Milliseconds() - cl.timedemo_start;
-
-	// 	 if (time > 0)
-	// 	 {
-	// 		 Com_Printf("%i frames, %3.1f seconds: %3.1f fps\n",
-	// 				 cl.timedemo_frames, time / 1000.0,
-	// 				 cl.timedemo_frames * 1000.0 / time);
-	// 	 }
-	//  }
+		if time > 0 {
+			T.common.Com_Printf("%v frames, %3.1f seconds: %3.1f fps\n",
+				T.cl.timedemo_frames, float64(time)/1000.0,
+				float64(T.cl.timedemo_frames)*1000.0/float64(time))
+		}
+	}
 
 	//  VectorClear(cl.refdef.blend);
 
@@ -157,10 +154,9 @@ func (T *qClient) disconnect() {
 
 	//  OGG_Stop();
 
-	//  if (cls.demorecording)
-	//  {
-	// 	 CL_Stop_f();
-	//  }
+	if T.cls.demorecording {
+		cl_Stop_f(nil, T)
+	}
 
 	/* send a disconnect message to the server */
 	final := shared.QWritebufCreate(32)
@@ -197,6 +193,40 @@ func (T *qClient) disconnect() {
 	//  T.common.Cvar_Set("game", userGivenGame);
 }
 
+func cl_Disconnect_f(args []string, a interface{}) error {
+	T := a.(*qClient)
+	T.disconnect()
+	if T.common.ServerState() != 0 {
+		T.common.Cbuf_AddText("killserver\n")
+	}
+	return nil
+}
+
+func cl_Connect_f(args []string, a interface{}) error {
+	T := a.(*qClient)
+
+	if len(args) != 2 {
+		T.common.Com_Printf("usage: connect <server>\n")
+		return nil
+	}
+
+	if T.common.ServerState() != 0 {
+		/* if running a local server, kill it and reissue
+		   note: this is connect with the save game system */
+		T.common.Cbuf_AddText(fmt.Sprintf("killserver\nconnect %s\n", args[1]))
+		return nil
+	}
+
+	T.common.NET_Config(true) /* allow remote */
+
+	T.disconnect()
+
+	T.cls.state = ca_connecting
+	T.cls.servername = args[1]
+	T.cls.connect_time = -99999 /* HACK: CL_CheckForResend() will fire immediately */
+	return nil
+}
+
 /*
  * Just sent as a hint to the client that they should
  * drop to full console
@@ -304,16 +334,17 @@ func (T *qClient) clPingServers() {
 
 		T.common.Com_Printf("pinging %s...\n", adrstring)
 
-		// 	if (!NET_StringToAdr(adrstring, &adr)) {
-		// 		Com_Printf("Bad address: %s\n", adrstring);
-		// 		continue;
-		// 	}
+		adr := shared.NET_StringToAdr(adrstring)
+		if adr == nil {
+			T.common.Com_Printf("Bad address: %s\n", adrstring)
+			continue
+		}
 
-		// 	if (!adr.port) {
-		// 		adr.port = BigShort(PORT_SERVER);
-		// 	}
+		if adr.Port == 0 {
+			adr.Port = shared.PORT_SERVER
+		}
 
-		// 	Netchan_OutOfBandPrint(NS_CLIENT, adr, va("info %i", PROTOCOL_VERSION));
+		T.common.Netchan_OutOfBandPrint(shared.NS_CLIENT, *adr, fmt.Sprintf("info %v", shared.PROTOCOL_VERSION))
 	}
 }
 
@@ -329,7 +360,7 @@ func (T *qClient) connectionlessPacket(msg *shared.QReadbuf, from *shared.Netadr
 
 	args := T.common.Cmd_TokenizeString(s, false)
 
-	T.common.Com_Printf("%s: %s\n", from, args[0])
+	T.common.Com_Printf("%s: %s\n", shared.NET_AdrToString(*from), args[0])
 
 	/* server connection */
 	if args[0] == "client_connect" {
@@ -426,20 +457,16 @@ func (T *qClient) connectionlessPacket(msg *shared.QReadbuf, from *shared.Netadr
 	// 		 return;
 	// 	 }
 
-	// 	 /* print command from somewhere */
-	// 	 if (!strcmp(c, "print"))
-	// 	 {
-	// 		 s = MSG_ReadString(&net_message);
-	// 		 Com_Printf("%s", s);
-	// 		 return;
-	// 	 }
+	/* print command from somewhere */
+	if args[0] == "print" {
+		T.common.Com_Printf("%s", msg.ReadString())
+		return nil
+	}
 
-	// 	 /* ping from somewhere */
-	// 	 if (!strcmp(c, "ping"))
-	// 	 {
-	// 		 Netchan_OutOfBandPrint(NS_CLIENT, net_from, "ack");
-	// 		 return;
-	// 	 }
+	/* ping from somewhere */
+	if args[0] == "ping" {
+		return T.common.Netchan_OutOfBandPrint(shared.NS_CLIENT, *from, "ack")
+	}
 
 	/* challenge from the server we are connecting to */
 	if args[0] == "challenge" {
@@ -449,12 +476,13 @@ func (T *qClient) connectionlessPacket(msg *shared.QReadbuf, from *shared.Netadr
 		return nil
 	}
 
-	// 	 /* echo request from server */
-	// 	 if (!strcmp(c, "echo"))
-	// 	 {
-	// 		 Netchan_OutOfBandPrint(NS_CLIENT, net_from, "%s", Cmd_Argv(1));
-	// 		 return;
-	// 	 }
+	/* echo request from server */
+	if args[0] == "echo" {
+		if len(args) > 1 {
+			return T.common.Netchan_OutOfBandPrint(shared.NS_CLIENT, *from, "%s", args[1])
+		}
+		return nil
+	}
 
 	T.common.Com_Printf("Unknown command.\n")
 	return nil
@@ -479,17 +507,16 @@ func (T *qClient) readPackets() error {
 
 		msg := shared.QReadbufCreate((data))
 		if msg.Size() < 8 {
-			T.common.Com_Printf("%v: Runt packet\n", from)
+			T.common.Com_Printf("%s: Runt packet\n", shared.NET_AdrToString(*from))
 			continue
 		}
 
-		// 	/* packet from server */
-		// 	if (!NET_CompareAdr(net_from, cls.netchan.remote_address))
-		// 	{
-		// 		Com_DPrintf("%s:sequenced packet without connection\n",
-		// 				NET_AdrToString(net_from));
-		// 		continue;
-		// 	}
+		/* packet from server */
+		if !shared.NET_CompareAdr(*from, T.cls.netchan.Remote_address) {
+			T.common.Com_DPrintf("%s:sequenced packet without connection\n",
+				shared.NET_AdrToString(*from))
+			continue
+		}
 
 		if !T.cls.netchan.Process(msg) {
 			continue /* wasn't accepted for some reason */
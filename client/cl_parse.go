@@ -522,7 +522,7 @@ func (T *qClient) parseFrame(msg *shared.QReadbuf) error {
 	if T.cl.frame.deltaframe <= 0 {
 		T.cl.frame.valid = true /* uncompressed frame */
 		old = nil
-		// 	cls.demowaiting = false; /* we can start recording now */
+		T.cls.demowaiting = false /* we can start recording now */
 	} else {
 		old = &T.cl.frames[T.cl.frame.deltaframe&shared.UPDATE_MASK]
 
@@ -620,6 +620,21 @@ func (T *qClient) parseFrame(msg *shared.QReadbuf) error {
 	return nil
 }
 
+func (T *qClient) registerSounds() {
+	T.sBeginRegistration()
+	// CL_RegisterTEntSounds();
+
+	for i := 1; i < shared.MAX_SOUNDS; i++ {
+		if len(T.cl.configstrings[shared.CS_SOUNDS+i]) == 0 {
+			break
+		}
+
+		T.cl.sound_precache[i] = T.sRegisterSound(T.cl.configstrings[shared.CS_SOUNDS+i])
+	}
+
+	T.sEndRegistration()
+}
+
 func (T *qClient) parseServerData(msg *shared.QReadbuf) error {
 
 	// /* Clear all key states */
@@ -857,11 +872,10 @@ func (T *qClient) parseConfigString(msg *shared.QReadbuf) error {
 			}
 		}
 	} else if (i >= shared.CS_SOUNDS) && (i < shared.CS_SOUNDS+shared.MAX_MODELS) {
-		// 		if (cl.refresh_prepped)
-		// 		{
-		// 			cl.sound_precache[i - CS_SOUNDS] =
-		// 				S_RegisterSound(cl.configstrings[i]);
-		// 		}
+		if T.cl.refresh_prepped {
+			T.cl.sound_precache[i-shared.CS_SOUNDS] =
+				T.sRegisterSound(T.cl.configstrings[i])
+		}
 	} else if (i >= shared.CS_IMAGES) && (i < shared.CS_IMAGES+shared.MAX_MODELS) {
 		// 		if (cl.refresh_prepped)
 		// 		{
@@ -877,78 +891,64 @@ func (T *qClient) parseConfigString(msg *shared.QReadbuf) error {
 func (T *qClient) parseStartSoundPacket(msg *shared.QReadbuf) error {
 
 	flags := msg.ReadByte()
-	_ = msg.ReadByte()
-	// sound_num := msg.ReadByte()
+	sound_num := msg.ReadByte()
 
-	if (flags & shared.SND_VOLUME) != 0 {
-		_ = float32(msg.ReadByte()) / 255.0
-		// 	volume = MSG_ReadByte(&net_message) / 255.0f;
+	if (sound_num < 0) || (sound_num >= shared.MAX_SOUNDS) {
+		return T.common.Com_Error(shared.ERR_DROP, "CL_ParseStartSoundPacket: sound_num = %v", sound_num)
 	}
 
-	// else
-	// {
-	// 	volume = DEFAULT_SOUND_PACKET_VOLUME;
-	// }
+	var volume float32
+	if (flags & shared.SND_VOLUME) != 0 {
+		volume = float32(msg.ReadByte()) / 255.0
+	} else {
+		volume = shared.DEFAULT_SOUND_PACKET_VOLUME
+	}
 
+	var attenuation float32
 	if (flags & shared.SND_ATTENUATION) != 0 {
-		_ = float32(msg.ReadByte()) / 64.0
-		// attenuation = MSG_ReadByte(&net_message) / 64.0f;
+		attenuation = float32(msg.ReadByte()) / 64.0
+	} else {
+		attenuation = shared.DEFAULT_SOUND_PACKET_ATTENUATION
 	}
 
-	// else
-	// {
-	// 	attenuation = DEFAULT_SOUND_PACKET_ATTENUATION;
-	// }
-
+	var ofs float32
 	if (flags & shared.SND_OFFSET) != 0 {
-		_ = float32(msg.ReadByte()) / 1000.0
-		// 	ofs = MSG_ReadByte(&net_message) / 1000.0f;
+		ofs = float32(msg.ReadByte()) / 1000.0
+	} else {
+		ofs = 0
 	}
 
-	// else
-	// {
-	// 	ofs = 0;
-	// }
-
+	var ent, channel int
 	if (flags & shared.SND_ENT) != 0 {
 		/* entity reletive */
-		_ = msg.ReadShort()
-		// 	channel = MSG_ReadShort(&net_message);
-		// 	ent = channel >> 3;
+		channel = msg.ReadShort()
+		ent = channel >> 3
 
-		// 	if (ent > MAX_EDICTS)
-		// 	{
-		// 		Com_Error(ERR_DROP, "CL_ParseStartSoundPacket: ent = %i", ent);
-		// 	}
+		if (ent < 0) || (ent >= shared.MAX_EDICTS) {
+			return T.common.Com_Error(shared.ERR_DROP, "CL_ParseStartSoundPacket: ent = %v", ent)
+		}
 
-		// 	channel &= 7;
+		channel &= 7
+	} else {
+		ent = 0
+		channel = 0
 	}
-	// else
-	// {
-	// 	ent = 0;
-	// 	channel = 0;
-	// }
 
+	var pos []float32
 	if (flags & shared.SND_POS) != 0 {
 		/* positioned in space */
-		_ = msg.ReadPos()
-		// 	MSG_ReadPos(&net_message, pos_v);
-
-		// 	pos = pos_v;
+		pos = msg.ReadPos()
+	} else {
+		/* use entity number */
+		pos = nil
 	}
-	// else
-	// {
-	// 	/* use entity number */
-	// 	pos = NULL;
-	// }
 
-	// if (!cl.sound_precache[sound_num])
-	// {
-	// 	return;
-	// }
+	if T.cl.sound_precache[sound_num] == nil {
+		return nil
+	}
 
-	// S_StartSound(pos, ent, channel, cl.sound_precache[sound_num],
-	// 		volume, attenuation, ofs);
+	T.sStartSound(pos, ent, channel, T.cl.sound_precache[sound_num],
+		volume, attenuation, ofs)
 	return nil
 }
 
@@ -1078,14 +1078,11 @@ func (T *qClient) parseServerMessage(msg *shared.QReadbuf) error {
 				return err
 			}
 
-			// 		case svc_inventory:
-			// 			CL_ParseInventory();
-			// 			break;
+		case shared.SvcInventory:
+			T.parseInventory(msg)
 
-			// 		case svc_layout:
-			// 			s = MSG_ReadString(&net_message);
-			// 			Q_strlcpy(cl.layout, s, sizeof(cl.layout));
-			// 			break;
+		case shared.SvcLayout:
+			T.cl.layout = msg.ReadString()
 
 		case shared.SvcPlayerinfo:
 		case shared.SvcPacketentities:
@@ -1098,11 +1095,10 @@ func (T *qClient) parseServerMessage(msg *shared.QReadbuf) error {
 
 	// CL_AddNetgraph();
 
-	// /* we don't know if it is ok to save a demo message
-	//    until after we have parsed the frame */
-	// if (cls.demorecording && !cls.demowaiting)
-	// {
-	// 	CL_WriteDemoMessage();
-	// }
+	/* we don't know if it is ok to save a demo message
+	   until after we have parsed the frame */
+	if T.cls.demorecording && !T.cls.demowaiting {
+		T.writeDemoMessage(msg)
+	}
 	return nil
 }
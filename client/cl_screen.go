@@ -101,9 +101,9 @@ func (T *qClient) scrDrawConsole() {
 	if T.scr_con_current > 0 {
 		T.conDrawConsole(T.scr_con_current)
 	} else {
-		// if (cls.key_dest == key_game) || (cls.key_dest == key_message) {
-		// 	Con_DrawNotify() /* only draw notify in game */
-		// }
+		if (T.cls.key_dest == key_game) || (T.cls.key_dest == key_message) {
+			T.conDrawNotify() /* only draw notify in game */
+		}
 	}
 }
 
@@ -398,51 +398,49 @@ func (T *qClient) scrExecuteLayoutString(s string) {
 			continue
 		}
 
-		// 	if (!strcmp(token, "client"))
-		// 	{
-		// 		/* draw a deathmatch client block */
-		// 		int score, ping, time;
-
-		// 		token = COM_Parse(&s);
-		// 		x = viddef.width / 2 - scale*160 + scale*(int)strtol(token, (char **)NULL, 10);
-		// 		token = COM_Parse(&s);
-		// 		y = viddef.height / 2 - scale*120 + scale*(int)strtol(token, (char **)NULL, 10);
-		// 		SCR_AddDirtyPoint(x, y);
-		// 		SCR_AddDirtyPoint(x + scale*159, y + scale*31);
+		if token == "client" {
+			/* draw a deathmatch client block */
+			token, index = shared.COM_Parse(s, index)
+			xi, _ := strconv.ParseInt(token, 10, 32)
+			x = T.viddef.width/2 - int(scale*160) + int(scale*float32(xi))
+			token, index = shared.COM_Parse(s, index)
+			yi, _ := strconv.ParseInt(token, 10, 32)
+			y = T.viddef.height/2 - int(scale*120) + int(scale*float32(yi))
+			T.scrAddDirtyPoint(x, y)
+			T.scrAddDirtyPoint(x+int(scale*159), y+int(scale*31))
 
-		// 		token = COM_Parse(&s);
-		// 		value = (int)strtol(token, (char **)NULL, 10);
+			token, index = shared.COM_Parse(s, index)
+			value, _ := strconv.ParseInt(token, 10, 32)
 
-		// 		if ((value >= MAX_CLIENTS) || (value < 0))
-		// 		{
-		// 			Com_Error(ERR_DROP, "client >= MAX_CLIENTS");
-		// 		}
+			if (value >= shared.MAX_CLIENTS) || (value < 0) {
+				T.common.Com_Error(shared.ERR_DROP, "client >= MAX_CLIENTS")
+				return
+			}
 
-		// 		ci = &cl.clientinfo[value];
+			ci := &T.cl.clientinfo[value]
 
-		// 		token = COM_Parse(&s);
-		// 		score = (int)strtol(token, (char **)NULL, 10);
+			token, index = shared.COM_Parse(s, index)
+			score, _ := strconv.ParseInt(token, 10, 32)
 
-		// 		token = COM_Parse(&s);
-		// 		ping = (int)strtol(token, (char **)NULL, 10);
+			token, index = shared.COM_Parse(s, index)
+			ping, _ := strconv.ParseInt(token, 10, 32)
 
-		// 		token = COM_Parse(&s);
-		// 		time = (int)strtol(token, (char **)NULL, 10);
+			token, index = shared.COM_Parse(s, index)
+			time, _ := strconv.ParseInt(token, 10, 32)
 
-		// 		DrawAltStringScaled(x + scale*32, y, ci->name, scale);
-		// 		DrawAltStringScaled(x + scale*32, y + scale*8, "Score: ", scale);
-		// 		DrawAltStringScaled(x + scale*(32 + 7 * 8), y + scale*8, va("%i", score), scale);
-		// 		DrawStringScaled(x + scale*32, y + scale*16, va("Ping:  %i", ping), scale);
-		// 		DrawStringScaled(x + scale*32, y + scale*24, va("Time:  %i", time), scale);
+			T.drawAltStringScaled(x+int(scale*32), y, ci.name, scale)
+			T.drawAltStringScaled(x+int(scale*32), y+int(scale*8), "Score: ", scale)
+			T.drawAltStringScaled(x+int(scale*(32+7*8)), y+int(scale*8), fmt.Sprintf("%d", score), scale)
+			T.drawStringScaled(x+int(scale*32), y+int(scale*16), fmt.Sprintf("Ping:  %d", ping), scale)
+			T.drawStringScaled(x+int(scale*32), y+int(scale*24), fmt.Sprintf("Time:  %d", time), scale)
 
-		// 		if (!ci->icon)
-		// 		{
-		// 			ci = &cl.baseclientinfo;
-		// 		}
+			if ci.icon == nil {
+				ci = &T.cl.baseclientinfo
+			}
 
-		// 		Draw_PicScaled(x, y, ci->iconname, scale);
-		// 		continue;
-		// 	}
+			T.Draw_PicScaled(x, y, ci.iconname, scale)
+			continue
+		}
 
 		// 	if (!strcmp(token, "ctf"))
 		// 	{
@@ -493,15 +491,14 @@ func (T *qClient) scrExecuteLayoutString(s string) {
 		// 		continue;
 		// 	}
 
-		// 	if (!strcmp(token, "picn"))
-		// 	{
-		// 		/* draw a pic from a name */
-		// 		token = COM_Parse(&s);
-		// 		SCR_AddDirtyPoint(x, y);
-		// 		SCR_AddDirtyPoint(x + scale*23, y + scale*23);
-		// 		Draw_PicScaled(x, y, (char *)token, scale);
-		// 		continue;
-		// 	}
+		if token == "picn" {
+			/* draw a pic from a name */
+			token, index = shared.COM_Parse(s, index)
+			T.scrAddDirtyPoint(x, y)
+			T.scrAddDirtyPoint(x+int(scale*23), y+int(scale*23))
+			T.Draw_PicScaled(x, y, token, scale)
+			continue
+		}
 
 		if token == "num" {
 			/* draw a number */
@@ -607,12 +604,11 @@ func (T *qClient) scrExecuteLayoutString(s string) {
 		// 		continue;
 		// 	}
 
-		// 	if (!strcmp(token, "string"))
-		// 	{
-		// 		token = COM_Parse(&s);
-		// 		DrawStringScaled(x, y, token, scale);
-		// 		continue;
-		// 	}
+		if token == "string" {
+			token, index = shared.COM_Parse(s, index)
+			T.drawStringScaled(x, y, token, scale)
+			continue
+		}
 
 		// 	if (!strcmp(token, "cstring2"))
 		// 	{
@@ -621,12 +617,11 @@ func (T *qClient) scrExecuteLayoutString(s string) {
 		// 		continue;
 		// 	}
 
-		// 	if (!strcmp(token, "string2"))
-		// 	{
-		// 		token = COM_Parse(&s);
-		// 		DrawAltStringScaled(x, y, token, scale);
-		// 		continue;
-		// 	}
+		if token == "string2" {
+			token, index = shared.COM_Parse(s, index)
+			T.drawAltStringScaled(x, y, token, scale)
+			continue
+		}
 
 		if token == "if" {
 			token, index = shared.COM_Parse(s, index)
@@ -793,7 +788,7 @@ func (T *qClient) scrUpdateScreen() error {
 		}
 
 		if (T.cl.frame.playerstate.Stats[shared.STAT_LAYOUTS] & 2) != 0 {
-			// 			 CL_DrawInventory();
+			T.drawInventory()
 		}
 
 		// 		 SCR_DrawNet();
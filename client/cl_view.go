@@ -231,13 +231,13 @@ func (T *qClient) renderView(stereo_separation float32) error {
 		return nil // still loading
 	}
 
-	// if (cl_timedemo->value) {
-	// 	if (!cl.timedemo_start) {
-	// 		cl.timedemo_start = Sys_Milliseconds();
-	// 	}
+	if T.cl_timedemo.Bool() {
+		if T.cl.timedemo_start == 0 {
+			T.cl.timedemo_start = T.common.Sys_Milliseconds()
+		}
 
-	// 	cl.timedemo_frames++;
-	// }
+		T.cl.timedemo_frames++
+	}
 
 	/* an invalid frame will just use the exact previous refdef
 	   we can't use the old frame if the video mode has changed, though... */
@@ -27,6 +27,7 @@ package client
 
 import (
 	"goquake2/shared"
+	"os"
 
 	"github.com/veandco/go-sdl2/sdl"
 )
@@ -163,7 +164,7 @@ type client_state_t struct {
 
 	model_clip [shared.MAX_MODELS]*shared.Cmodel_t
 
-	//    struct sfx_s	*sound_precache[MAX_SOUNDS];
+	sound_precache [shared.MAX_SOUNDS]*sfx_t
 
 	image_precache [shared.MAX_IMAGES]interface{}
 
@@ -230,10 +231,10 @@ type client_static_t struct {
 	// 	size_t		downloadposition;
 	// 	int			downloadpercent;
 
-	// 	/* demo recording info must be here, so it isn't cleared on level change */
-	// 	qboolean	demorecording;
-	// 	qboolean	demowaiting; /* don't record until a non-delta message is received */
-	// 	FILE		*demofile;
+	/* demo recording info must be here, so it isn't cleared on level change */
+	demorecording bool
+	demowaiting   bool /* don't record until a non-delta message is received */
+	demofile      *os.File
 
 	// #ifdef USE_CURL
 	// 	/* http downloading */
@@ -308,6 +309,7 @@ type qClient struct {
 	cl_noskins       *shared.CvarT
 	cl_footsteps     *shared.CvarT
 	cl_timeout       *shared.CvarT
+	cl_timedemo      *shared.CvarT
 	cl_predict       *shared.CvarT
 	cl_showfps       *shared.CvarT
 	cl_gun           *shared.CvarT
@@ -394,6 +396,16 @@ type qClient struct {
 	// console
 	con console_t
 
+	// sound
+	snd         sound_t
+	s_initsound *shared.CvarT
+	s_volume    *shared.CvarT
+	s_khz       *shared.CvarT
+	s_mixahead  *shared.CvarT
+	s_show      *shared.CvarT
+	s_backend   *shared.CvarT
+	s_wavfile   *shared.CvarT
+
 	r_entities    []shared.Entity_t
 	r_particles   []shared.Particle_t
 	r_lightstyles [shared.MAX_LIGHTSTYLES]shared.Lightstyle_t
@@ -461,6 +473,9 @@ type qClient struct {
 	key_repeats [K_LAST]int  /* if > 1, it is autorepeating */
 	keydown     [K_LAST]bool
 
+	chat_team   bool
+	chat_buffer string
+
 	menu MenuStr
 
 	precache_check         int
@@ -120,7 +120,7 @@ func (T *qClient) mForceMenuOff() {
 }
 
 func (T *qClient) mPopMenu() {
-	// S_StartLocalSound(menu_out_sound);
+	T.sStartLocalSound(menu_out_sound)
 
 	if len(T.menu.m_layers) < 1 {
 		log.Fatal("M_PopMenu: depth < 1")
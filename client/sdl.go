@@ -323,18 +323,11 @@ func (T *QInput) Update() {
 			// 			 }
 			// 			 break;
 
-			// 		 case SDL_TEXTINPUT:
-			// 		 {
-			// 			 int c = event.text.text[0];
-			// 			 // also make sure we don't get the char that corresponds to the
-			// 			 // "console key" (like "^" or "`") as text input
-			// 			 if ((c >= ' ') && (c <= '~') && c != consoleKeyCode)
-			// 			 {
-			// 				 Char_Event(c);
-			// 			 }
-			// 		 }
-
-			// 			 break;
+		case sdl.TEXTINPUT:
+			c := int(event.(*sdl.TextInputEvent).Text[0])
+			if (c >= ' ') && (c <= '~') {
+				T.client.charEvent(c)
+			}
 
 		case sdl.KEYDOWN,
 			sdl.KEYUP:
@@ -687,7 +680,7 @@ func (T *QInput) Init(client *qClient) {
 	//  Cmd_AddCommand("+joyaltselector", IN_JoyAltSelectorDown);
 	//  Cmd_AddCommand("-joyaltselector", IN_JoyAltSelectorUp);
 
-	//  SDL_StartTextInput();
+	sdl.StartTextInput()
 
 	//  /* Joystick init */
 	//  if (!SDL_WasInit(SDL_INIT_GAMECONTROLLER | SDL_INIT_HAPTIC))
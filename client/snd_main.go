@@ -0,0 +1,862 @@
+/*
+ * Copyright (C) 1997-2001 Id Software, Inc.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or (at
+ * your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ *
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 59 Temple Place - Suite 330, Boston, MA
+ * 02111-1307, USA.
+ *
+ * =======================================================================
+ *
+ * The upper layer of the Quake II sound system. It keeps track of the
+ * known sounds, queues and spatializes them and hands them over to the
+ * mixer in snd_mix.go and the platform backends in snd_sdl.go and
+ * snd_null.go.
+ *
+ * =======================================================================
+ */
+package client
+
+import (
+	"goquake2/shared"
+	"strings"
+)
+
+const (
+	MAX_CHANNELS        = 32
+	MAX_PLAYSOUNDS      = 128
+	PAINTBUFFER_SIZE    = 2048
+	SOUND_FULLVOLUME    = 80
+	SOUND_LOOPATTENUATE = 0.003
+)
+
+type sfxcache_t struct {
+	length    int
+	loopstart int
+	speed     int
+	data      []int16 /* always 16 bit mono at dma.speed */
+}
+
+type sfx_t struct {
+	name                  string
+	registration_sequence int
+	cache                 *sfxcache_t
+	truename              string
+}
+
+/*
+ * A playsound_t will be generated by each call to S_StartSound,
+ * when the mixer reaches playsound->begin, the playsound will
+ * be assigned to a channel
+ */
+type playsound_t struct {
+	sfx          *sfx_t
+	volume       float32
+	attenuation  float32
+	entnum       int
+	entchannel   int
+	fixed_origin bool /* use origin field instead of entnum's origin */
+	origin       [3]float32
+	begin        int /* begin on this sample */
+}
+
+type channel_t struct {
+	sfx          *sfx_t /* sfx number */
+	leftvol      int    /* 0-255 volume */
+	rightvol     int    /* 0-255 volume */
+	end          int    /* end time in global paintsamples */
+	pos          int    /* sample position in sfx */
+	entnum       int    /* to allow overriding a specific sound */
+	entchannel   int
+	origin       [3]float32 /* only use if fixed_origin is set */
+	dist_mult    float32    /* distance multiplier (attenuation/clipK) */
+	master_vol   int        /* 0-255 master volume */
+	fixed_origin bool       /* use origin instead of fetching entnum's origin */
+	autosound    bool       /* from an entity->sound, cleared each frame */
+}
+
+type portable_samplepair_t struct {
+	left  int
+	right int
+}
+
+type dma_t struct {
+	channels         int
+	samples          int /* mono samples in buffer */
+	submission_chunk int /* don't mix less than this # */
+	speed            int
+}
+
+/*
+ * The platform dependent part of the sound system.
+ * Backends receive 16 bit interleaved stereo.
+ */
+type sndBackend interface {
+	Init(dma *dma_t) bool
+	Shutdown()
+	/* number of sample pairs the device has consumed */
+	SoundTime() int
+	Submit(samples []int16)
+}
+
+type sound_t struct {
+	started     bool
+	registering bool
+
+	backend sndBackend
+	dma     dma_t
+
+	registration_sequence int
+	known_sfx             map[string]*sfx_t
+
+	channels [MAX_CHANNELS]channel_t
+	pending  []*playsound_t
+
+	paintedtime int
+	soundtime   int
+	paintbuffer [PAINTBUFFER_SIZE]portable_samplepair_t
+	transfer    []int16
+
+	listener_origin  [3]float32
+	listener_forward [3]float32
+	listener_right   [3]float32
+	listener_up      [3]float32
+}
+
+func (T *qClient) sInit() {
+	T.common.Com_Printf("\n------- sound initialization -------\n")
+
+	T.s_initsound = T.common.Cvar_Get("s_initsound", "1", 0)
+	T.s_volume = T.common.Cvar_Get("s_volume", "0.7", shared.CVAR_ARCHIVE)
+	T.s_khz = T.common.Cvar_Get("s_khz", "44", shared.CVAR_ARCHIVE)
+	T.s_mixahead = T.common.Cvar_Get("s_mixahead", "0.14", shared.CVAR_ARCHIVE)
+	T.s_show = T.common.Cvar_Get("s_show", "0", 0)
+	T.s_backend = T.common.Cvar_Get("s_backend", "sdl", shared.CVAR_ARCHIVE)
+	T.s_wavfile = T.common.Cvar_Get("s_wavfile", "", 0)
+
+	if !T.s_initsound.Bool() {
+		T.common.Com_Printf("Not initializing.\n")
+		T.common.Com_Printf("------------------------------------\n")
+		return
+	}
+
+	T.common.Cmd_AddCommand("stopsound", s_StopAllSounds_f, T)
+	T.common.Cmd_AddCommand("soundlist", s_SoundList_f, T)
+	T.common.Cmd_AddCommand("soundinfo", s_SoundInfo_f, T)
+
+	T.sStartBackend()
+
+	T.common.Com_Printf("------------------------------------\n")
+}
+
+func (T *qClient) sStartBackend() {
+	T.snd.dma = dma_t{}
+	switch T.s_khz.Int() {
+	case 11:
+		T.snd.dma.speed = 11025
+	case 22:
+		T.snd.dma.speed = 22050
+	case 48:
+		T.snd.dma.speed = 48000
+	default:
+		T.snd.dma.speed = 44100
+	}
+	T.snd.dma.channels = 2
+
+	if T.s_backend.String == "null" {
+		T.snd.backend = &sndNull{common: T.common, filename: T.s_wavfile.String}
+	} else {
+		T.snd.backend = &sndSDL{common: T.common}
+	}
+
+	if !T.snd.backend.Init(&T.snd.dma) {
+		T.common.Com_Printf("Sound backend %s failed, falling back to null.\n", T.s_backend.String)
+		T.snd.backend = &sndNull{common: T.common, filename: T.s_wavfile.String}
+		if !T.snd.backend.Init(&T.snd.dma) {
+			T.snd.backend = nil
+			return
+		}
+	}
+
+	if T.snd.known_sfx == nil {
+		T.snd.known_sfx = make(map[string]*sfx_t)
+	}
+
+	T.snd.transfer = make([]int16, PAINTBUFFER_SIZE*2)
+	T.snd.started = true
+	T.snd.paintedtime = 0
+	T.snd.soundtime = 0
+
+	T.common.Com_Printf("Sound sampling rate: %v\n", T.snd.dma.speed)
+
+	T.sStopAllSounds()
+}
+
+/*
+ * Shutdown sound engine
+ */
+func (T *qClient) sShutdown() {
+	if !T.snd.started {
+		return
+	}
+
+	T.sStopAllSounds()
+
+	T.snd.backend.Shutdown()
+	T.snd.backend = nil
+	T.snd.started = false
+
+	/* free all sounds */
+	T.snd.known_sfx = nil
+}
+
+/*
+ * Restart the sound subsystem so it can pick up
+ * new parameters and flush all sounds
+ */
+func cl_Snd_Restart_f(args []string, a interface{}) error {
+	T := a.(*qClient)
+
+	T.sShutdown()
+	if T.s_initsound.Bool() {
+		T.sStartBackend()
+	}
+	T.registerSounds()
+	return nil
+}
+
+/*
+ * Returns the sfx_t for a sound name,
+ * creating it if requested.
+ */
+func (T *qClient) sFindName(name string, create bool) *sfx_t {
+	if len(name) == 0 {
+		T.common.Com_Printf("S_FindName: empty name\n")
+		return nil
+	}
+
+	if len(name) >= shared.MAX_QPATH {
+		T.common.Com_Printf("Sound name too long: %s\n", name)
+		return nil
+	}
+
+	/* see if already loaded */
+	if sfx, ok := T.snd.known_sfx[name]; ok {
+		return sfx
+	}
+
+	if !create {
+		return nil
+	}
+
+	sfx := &sfx_t{name: name, registration_sequence: T.snd.registration_sequence}
+	T.snd.known_sfx[name] = sfx
+	return sfx
+}
+
+/*
+ * Creates an alias name for a file
+ */
+func (T *qClient) sAliasName(aliasname, truename string) *sfx_t {
+	sfx := &sfx_t{
+		name:                  aliasname,
+		truename:              truename,
+		registration_sequence: T.snd.registration_sequence,
+	}
+	T.snd.known_sfx[aliasname] = sfx
+	return sfx
+}
+
+/*
+ * Called before registering
+ * of sound starts
+ */
+func (T *qClient) sBeginRegistration() {
+	T.snd.registration_sequence++
+	T.snd.registering = true
+}
+
+/*
+ * Registers a sound
+ */
+func (T *qClient) sRegisterSound(name string) *sfx_t {
+	if !T.snd.started {
+		return nil
+	}
+
+	sfx := T.sFindName(name, true)
+	if sfx == nil {
+		return nil
+	}
+	sfx.registration_sequence = T.snd.registration_sequence
+
+	if !T.snd.registering {
+		T.sLoadSound(sfx)
+	}
+
+	return sfx
+}
+
+func (T *qClient) sRegisterSexedSound(ent *shared.Entity_state_t, base string) *sfx_t {
+	/* determine what model the client is using */
+	model := ""
+	n := shared.CS_PLAYERSKINS + ent.Number - 1
+	if n >= shared.CS_PLAYERSKINS && n < shared.CS_PLAYERSKINS+shared.MAX_CLIENTS {
+		cs := T.cl.configstrings[n]
+		if p := strings.IndexByte(cs, '\\'); p >= 0 {
+			model = cs[p+1:]
+			if p = strings.IndexByte(model, '/'); p >= 0 {
+				model = model[:p]
+			}
+		}
+	}
+
+	/* if we can't figure it out, they're male */
+	if len(model) == 0 {
+		model = "male"
+	}
+
+	/* see if we already know of the model specific sound */
+	sexedFilename := "#players/" + model + "/" + base[1:]
+	sfx := T.sFindName(sexedFilename, false)
+	if sfx == nil {
+		/* no, so see if it exists */
+		f, _ := T.common.FS_FOpenFile(sexedFilename[1:], false)
+		if f != nil {
+			/* yes, close the file and register it */
+			f.Close()
+			sfx = T.sRegisterSound(sexedFilename)
+		} else {
+			/* no, revert to the male sound in the pak0.pak */
+			sfx = T.sAliasName(sexedFilename, "player/male/"+base[1:])
+		}
+	}
+
+	return sfx
+}
+
+/*
+ * Called after registering of
+ * sound has ended
+ */
+func (T *qClient) sEndRegistration() {
+	if !T.snd.started {
+		return
+	}
+
+	/* free any sounds not from this registration sequence */
+	for name, sfx := range T.snd.known_sfx {
+		if sfx.registration_sequence != T.snd.registration_sequence {
+			/* don't need this sound */
+			delete(T.snd.known_sfx, name)
+		}
+	}
+
+	/* load everything in */
+	for _, sfx := range T.snd.known_sfx {
+		T.sLoadSound(sfx)
+	}
+
+	T.snd.registering = false
+}
+
+/*
+ * Picks a channel based on priorities,
+ * empty slots, number of channels
+ */
+func (T *qClient) sPickChannel(entnum, entchannel int) *channel_t {
+	if entchannel < 0 {
+		T.common.Com_Error(shared.ERR_DROP, "S_PickChannel: entchannel<0")
+		return nil
+	}
+
+	/* Check for replacement sound, or find the best one to replace */
+	first_to_die := -1
+	life_left := 0x7fffffff
+
+	for ch_idx := range T.snd.channels {
+		ch := &T.snd.channels[ch_idx]
+
+		/* channel 0 never overrides unless out of channels */
+		if (entchannel != 0) && (ch.entnum == entnum) && (ch.entchannel == entchannel) {
+			/* always override sound from same entity */
+			first_to_die = ch_idx
+			break
+		}
+
+		/* don't let monster sounds override player sounds */
+		if (ch.entnum == T.cl.playernum+1) && (entnum != T.cl.playernum+1) && ch.sfx != nil {
+			continue
+		}
+
+		if ch.end-T.snd.paintedtime < life_left {
+			life_left = ch.end - T.snd.paintedtime
+			first_to_die = ch_idx
+		}
+	}
+
+	if first_to_die == -1 {
+		return nil
+	}
+
+	ch := &T.snd.channels[first_to_die]
+	*ch = channel_t{}
+	return ch
+}
+
+/*
+ * Used for spatializing channels and autosounds
+ */
+func (T *qClient) sSpatializeOrigin(origin []float32, master_vol, dist_mult float32) (int, int) {
+	if T.cls.state != ca_active {
+		return 255, 255
+	}
+
+	/* calculate stereo seperation and distance attenuation */
+	source_vec := make([]float32, 3)
+	shared.VectorSubtract(origin, T.snd.listener_origin[:], source_vec)
+
+	dist := shared.VectorNormalize(source_vec)
+	dist -= SOUND_FULLVOLUME
+
+	if dist < 0 {
+		dist = 0 /* close enough to be at full volume */
+	}
+
+	dist *= dist_mult /* different attenuation levels */
+
+	dot := shared.DotProduct(T.snd.listener_right[:], source_vec)
+
+	var lscale, rscale float32
+	if (T.snd.dma.channels == 1) || dist_mult == 0 {
+		/* no attenuation = no spatialization */
+		rscale = 1.0
+		lscale = 1.0
+	} else {
+		rscale = 0.5 * (1.0 + dot)
+		lscale = 0.5 * (1.0 - dot)
+	}
+
+	/* add in distance effect */
+	right_vol := int(master_vol * (1.0 - dist) * rscale)
+	if right_vol < 0 {
+		right_vol = 0
+	}
+
+	left_vol := int(master_vol * (1.0 - dist) * lscale)
+	if left_vol < 0 {
+		left_vol = 0
+	}
+
+	return left_vol, right_vol
+}
+
+/*
+ * Spatializes a channel
+ */
+func (T *qClient) sSpatialize(ch *channel_t) {
+	/* anything coming from the view entity
+	   will always be full volume */
+	if ch.entnum == T.cl.playernum+1 {
+		ch.leftvol = ch.master_vol
+		ch.rightvol = ch.master_vol
+		return
+	}
+
+	var origin []float32
+	if ch.fixed_origin {
+		origin = ch.origin[:]
+	} else {
+		origin = T.getEntitySoundOrigin(ch.entnum)
+	}
+
+	ch.leftvol, ch.rightvol = T.sSpatializeOrigin(origin, float32(ch.master_vol), ch.dist_mult)
+}
+
+/*
+ * Returns the origin sounds of the given
+ * entity are played from
+ */
+func (T *qClient) getEntitySoundOrigin(ent int) []float32 {
+	if (ent < 0) || (ent >= shared.MAX_EDICTS) {
+		T.common.Com_Error(shared.ERR_DROP, "CL_GetEntitySoundOrigin: bad ent")
+		return make([]float32, 3)
+	}
+
+	old := &T.cl_entities[ent]
+	if old.lerp_origin != nil {
+		return old.lerp_origin
+	}
+	return old.current.Origin[:]
+}
+
+/*
+ * Take the next playsound and begin it on the channel.
+ * This is never called directly by S_Play*, but only
+ * by the update loop.
+ */
+func (T *qClient) sIssuePlaysound(ps *playsound_t) {
+	if T.s_show.Bool() {
+		T.common.Com_Printf("Issue %v\n", ps.begin)
+	}
+
+	/* pick a channel to play on */
+	ch := T.sPickChannel(ps.entnum, ps.entchannel)
+	if ch == nil {
+		return
+	}
+
+	sc := T.sLoadSound(ps.sfx)
+	if sc == nil {
+		return
+	}
+
+	/* spatialize */
+	if ps.attenuation == shared.ATTN_STATIC {
+		ch.dist_mult = ps.attenuation * 0.001
+	} else {
+		ch.dist_mult = ps.attenuation * 0.0005
+	}
+
+	ch.master_vol = int(ps.volume)
+	ch.entnum = ps.entnum
+	ch.entchannel = ps.entchannel
+	ch.sfx = ps.sfx
+	ch.origin = ps.origin
+	ch.fixed_origin = ps.fixed_origin
+
+	T.sSpatialize(ch)
+
+	ch.pos = 0
+	ch.end = T.snd.paintedtime + sc.length
+}
+
+/*
+ * Validates the parms and ques the sound up.
+ * If origin is nil, the sound will be dynamically
+ * sourced from the entity. Entchannel 0 will never
+ * override a playing sound.
+ */
+func (T *qClient) sStartSound(origin []float32, entnum, entchannel int, sfx *sfx_t,
+	fvol, attenuation, timeofs float32) {
+
+	if !T.snd.started {
+		return
+	}
+
+	if sfx == nil {
+		return
+	}
+
+	if sfx.name[0] == '*' {
+		sfx = T.sRegisterSexedSound(&T.cl_entities[entnum].current, sfx.name)
+		if sfx == nil {
+			return
+		}
+	}
+
+	/* make sure the sound is loaded */
+	if T.sLoadSound(sfx) == nil {
+		return /* couldn't load the sound's data */
+	}
+
+	if len(T.snd.pending) >= MAX_PLAYSOUNDS {
+		return
+	}
+
+	/* make the playsound_t */
+	ps := &playsound_t{
+		sfx:         sfx,
+		volume:      fvol * 255,
+		attenuation: attenuation,
+		entnum:      entnum,
+		entchannel:  entchannel,
+	}
+
+	if origin != nil {
+		copy(ps.origin[:], origin)
+		ps.fixed_origin = true
+	}
+
+	ps.begin = T.snd.paintedtime + int(timeofs*float32(T.snd.dma.speed))
+
+	/* sort into the pending sound list */
+	i := len(T.snd.pending)
+	for i > 0 && T.snd.pending[i-1].begin > ps.begin {
+		i--
+	}
+	T.snd.pending = append(T.snd.pending, nil)
+	copy(T.snd.pending[i+1:], T.snd.pending[i:])
+	T.snd.pending[i] = ps
+}
+
+/*
+ * Plays a sound when we're not
+ * in a level. Used by the menu
+ * system.
+ */
+func (T *qClient) sStartLocalSound(sound string) {
+	if !T.snd.started {
+		return
+	}
+
+	sfx := T.sRegisterSound(sound)
+	if sfx == nil {
+		T.common.Com_Printf("S_StartLocalSound: can't cache %s\n", sound)
+		return
+	}
+
+	T.sStartSound(nil, T.cl.playernum+1, 0, sfx, 1, 1, 0)
+}
+
+/*
+ * Clears the playback buffer so
+ * that all playback stops.
+ */
+func (T *qClient) sStopAllSounds() {
+	if !T.snd.started {
+		return
+	}
+
+	/* clear all the playsounds */
+	T.snd.pending = nil
+
+	/* clear all the channels */
+	for i := range T.snd.channels {
+		T.snd.channels[i] = channel_t{}
+	}
+}
+
+func s_StopAllSounds_f(args []string, a interface{}) error {
+	a.(*qClient).sStopAllSounds()
+	return nil
+}
+
+/*
+ * Entities with a "sound" field will generate looped sounds
+ * that are automatically started, stopped, and merged together
+ * as the entities are sent to the client
+ */
+func (T *qClient) sAddLoopSounds() {
+	if T.cl_paused.Bool() || (T.cls.state != ca_active) || !T.cl.sound_prepped {
+		return
+	}
+
+	sounds := make([]int, T.cl.frame.num_entities)
+	for i := range sounds {
+		num := (T.cl.frame.parse_entities + i) & (MAX_PARSE_ENTITIES - 1)
+		sounds[i] = T.cl_parse_entities[num].Sound
+	}
+
+	for i := range sounds {
+		if sounds[i] == 0 {
+			continue
+		}
+
+		sfx := T.cl.sound_precache[sounds[i]]
+		if sfx == nil {
+			continue /* bad sound effect */
+		}
+
+		sc := sfx.cache
+		if sc == nil {
+			continue
+		}
+
+		num := (T.cl.frame.parse_entities + i) & (MAX_PARSE_ENTITIES - 1)
+		ent := &T.cl_parse_entities[num]
+
+		/* find the total contribution of all sounds of this type */
+		left_total, right_total := T.sSpatializeOrigin(ent.Origin[:], 255.0, SOUND_LOOPATTENUATE)
+
+		for j := i + 1; j < len(sounds); j++ {
+			if sounds[j] != sounds[i] {
+				continue
+			}
+
+			sounds[j] = 0 /* don't check this again later */
+
+			num = (T.cl.frame.parse_entities + j) & (MAX_PARSE_ENTITIES - 1)
+			ent = &T.cl_parse_entities[num]
+
+			left, right := T.sSpatializeOrigin(ent.Origin[:], 255.0, SOUND_LOOPATTENUATE)
+			left_total += left
+			right_total += right
+		}
+
+		if (left_total == 0) && (right_total == 0) {
+			continue /* not audible */
+		}
+
+		/* allocate a channel */
+		ch := T.sPickChannel(0, 0)
+		if ch == nil {
+			return
+		}
+
+		if left_total > 255 {
+			left_total = 255
+		}
+
+		if right_total > 255 {
+			right_total = 255
+		}
+
+		ch.leftvol = left_total
+		ch.rightvol = right_total
+		ch.autosound = true /* remove next frame */
+		ch.sfx = sfx
+
+		/* a zero length sample would make the modulo below fail */
+		if sc.length == 0 {
+			ch.pos = 0
+			ch.end = 0
+		} else {
+			ch.pos = T.snd.paintedtime % sc.length
+			ch.end = T.snd.paintedtime + sc.length - ch.pos
+		}
+	}
+}
+
+/*
+ * Called once each time through the main loop
+ */
+func (T *qClient) sUpdate(origin, forward, right, up []float32) {
+	if !T.snd.started {
+		return
+	}
+
+	/* if the loading plaque is up, clear everything
+	   out to make sure we aren't looping a dirty
+	   dma buffer while loading */
+	if T.cls.disable_screen != 0 {
+		return
+	}
+
+	copy(T.snd.listener_origin[:], origin)
+	copy(T.snd.listener_forward[:], forward)
+	copy(T.snd.listener_right[:], right)
+	copy(T.snd.listener_up[:], up)
+
+	/* update spatialization for dynamic sounds */
+	for i := range T.snd.channels {
+		ch := &T.snd.channels[i]
+		if ch.sfx == nil {
+			continue
+		}
+
+		if ch.autosound {
+			/* autosounds are regenerated fresh each frame */
+			*ch = channel_t{}
+			continue
+		}
+
+		/* respatialize channel */
+		T.sSpatialize(ch)
+
+		if ch.leftvol == 0 && ch.rightvol == 0 {
+			*ch = channel_t{}
+			continue
+		}
+	}
+
+	/* add loopsounds */
+	T.sAddLoopSounds()
+
+	/* debugging output */
+	if T.s_show.Bool() {
+		total := 0
+		for i := range T.snd.channels {
+			ch := &T.snd.channels[i]
+			if ch.sfx != nil && (ch.leftvol != 0 || ch.rightvol != 0) {
+				T.common.Com_Printf("%3v %3v %s\n", ch.leftvol, ch.rightvol, ch.sfx.name)
+				total++
+			}
+		}
+
+		T.common.Com_Printf("----(%v)---- painted: %v\n", total, T.snd.paintedtime)
+	}
+
+	/* mix some sound */
+	T.snd.soundtime = T.snd.backend.SoundTime()
+
+	/* check to make sure that we haven't overshot */
+	if T.snd.paintedtime < T.snd.soundtime {
+		T.common.Com_DPrintf("S_Update_ : overflow\n")
+		T.snd.paintedtime = T.snd.soundtime
+	}
+
+	/* mix ahead of current position */
+	endtime := T.snd.soundtime + int(T.s_mixahead.Float()*float32(T.snd.dma.speed))
+
+	/* mix to an even submission block size */
+	endtime = (endtime + T.snd.dma.submission_chunk - 1) & ^(T.snd.dma.submission_chunk - 1)
+	samps := T.snd.dma.samples >> (T.snd.dma.channels - 1)
+
+	if endtime-T.snd.soundtime > samps {
+		endtime = T.snd.soundtime + samps
+	}
+
+	T.sPaintChannels(endtime)
+}
+
+func s_SoundList_f(args []string, a interface{}) error {
+	T := a.(*qClient)
+
+	total := 0
+	for _, sfx := range T.snd.known_sfx {
+		if sfx.registration_sequence == 0 {
+			continue
+		}
+
+		sc := sfx.cache
+		if sc != nil {
+			size := sc.length * 2
+			total += size
+
+			if sc.loopstart >= 0 {
+				T.common.Com_Printf("L")
+			} else {
+				T.common.Com_Printf(" ")
+			}
+
+			T.common.Com_Printf("(16b) %6v : %s\n", size, sfx.name)
+		} else {
+			if sfx.name[0] == '*' {
+				T.common.Com_Printf("  placeholder : %s\n", sfx.name)
+			} else {
+				T.common.Com_Printf("  not loaded  : %s\n", sfx.name)
+			}
+		}
+	}
+
+	T.common.Com_Printf("Total resident: %v\n", total)
+	return nil
+}
+
+func s_SoundInfo_f(args []string, a interface{}) error {
+	T := a.(*qClient)
+
+	if !T.snd.started {
+		T.common.Com_Printf("sound system not started\n")
+		return nil
+	}
+
+	T.common.Com_Printf("%5d stereo\n", T.snd.dma.channels-1)
+	T.common.Com_Printf("%5d samples\n", T.snd.dma.samples)
+	T.common.Com_Printf("%5d samplebits\n", 16)
+	T.common.Com_Printf("%5d submission_chunk\n", T.snd.dma.submission_chunk)
+	T.common.Com_Printf("%5d speed\n", T.snd.dma.speed)
+	return nil
+}
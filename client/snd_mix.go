@@ -0,0 +1,161 @@
+/*
+ * Copyright (C) 1997-2001 Id Software, Inc.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or (at
+ * your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ *
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 59 Temple Place - Suite 330, Boston, MA
+ * 02111-1307, USA.
+ *
+ * =======================================================================
+ *
+ * This file implements the software mixer. All active channels are
+ * painted into a 32 bit paint buffer, which is then clamped and
+ * handed to the backend as 16 bit interleaved stereo.
+ *
+ * =======================================================================
+ */
+package client
+
+func (T *qClient) sTransferPaintBuffer(endtime int) {
+	count := endtime - T.snd.paintedtime
+	out := T.snd.transfer[:count*2]
+
+	for i := 0; i < count; i++ {
+		l := T.snd.paintbuffer[i].left >> 8
+		r := T.snd.paintbuffer[i].right >> 8
+
+		if l > 0x7fff {
+			l = 0x7fff
+		} else if l < -32768 {
+			l = -32768
+		}
+
+		if r > 0x7fff {
+			r = 0x7fff
+		} else if r < -32768 {
+			r = -32768
+		}
+
+		out[i*2] = int16(l)
+		out[i*2+1] = int16(r)
+	}
+
+	T.snd.backend.Submit(out)
+}
+
+func (T *qClient) sPaintChannelFrom16(ch *channel_t, sc *sfxcache_t, count, offset int) {
+	snd_vol := int(T.s_volume.Float() * 256)
+	leftvol := ch.leftvol * snd_vol
+	rightvol := ch.rightvol * snd_vol
+
+	sfx := sc.data[ch.pos:]
+	samp := T.snd.paintbuffer[offset : offset+count]
+
+	for i := range samp {
+		data := int(sfx[i])
+		samp[i].left += (data * leftvol) >> 8
+		samp[i].right += (data * rightvol) >> 8
+	}
+
+	ch.pos += count
+}
+
+func (T *qClient) sPaintChannels(endtime int) {
+	for T.snd.paintedtime < endtime {
+		/* if paintbuffer is smaller than DMA buffer */
+		end := endtime
+		if endtime-T.snd.paintedtime > PAINTBUFFER_SIZE {
+			end = T.snd.paintedtime + PAINTBUFFER_SIZE
+		}
+
+		/* start any playsounds */
+		for len(T.snd.pending) > 0 {
+			ps := T.snd.pending[0]
+
+			if ps.begin <= T.snd.paintedtime {
+				T.snd.pending = T.snd.pending[1:]
+				T.sIssuePlaysound(ps)
+				continue
+			}
+
+			if ps.begin < end {
+				end = ps.begin /* stop here */
+			}
+
+			break
+		}
+
+		/* clear the paint buffer */
+		for i := 0; i < end-T.snd.paintedtime; i++ {
+			T.snd.paintbuffer[i] = portable_samplepair_t{}
+		}
+
+		/* paint in the channels. */
+		for i := range T.snd.channels {
+			ch := &T.snd.channels[i]
+			ltime := T.snd.paintedtime
+
+			for ltime < end {
+				if ch.sfx == nil || (ch.leftvol == 0 && ch.rightvol == 0) {
+					break
+				}
+
+				/* max painting is to the end of the buffer */
+				count := end - ltime
+
+				/* might be stopped by running out of data */
+				if ch.end-ltime < count {
+					count = ch.end - ltime
+				}
+
+				sc := T.sLoadSound(ch.sfx)
+				if sc == nil {
+					break
+				}
+
+				if count > 0 && ch.pos+count <= len(sc.data) {
+					T.sPaintChannelFrom16(ch, sc, count, ltime-T.snd.paintedtime)
+					ltime += count
+				} else if count > 0 {
+					/* ran past the sample, stop the channel */
+					ch.sfx = nil
+					break
+				}
+
+				/* if at end of loop, restart */
+				if ltime >= ch.end {
+					if ch.autosound {
+						/* autolooping sounds always go back to start */
+						ch.pos = 0
+						ch.end = ltime + sc.length
+					} else if sc.loopstart >= 0 {
+						ch.pos = sc.loopstart
+						ch.end = ltime + sc.length - ch.pos
+					} else {
+						/* channel just stopped */
+						ch.sfx = nil
+					}
+
+					if sc.length == 0 {
+						ch.sfx = nil
+					}
+				}
+			}
+		}
+
+		/* transfer out according to DMA format */
+		T.sTransferPaintBuffer(end)
+		T.snd.paintedtime = end
+	}
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright (C) 1997-2001 Id Software, Inc.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or (at
+ * your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ *
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 59 Temple Place - Suite 330, Boston, MA
+ * 02111-1307, USA.
+ *
+ * =======================================================================
+ *
+ * Null sound backend. The device takes the mixed samples as soon as
+ * they are submitted and throws them away, or writes them into a WAV
+ * file if s_wavfile is set. Since no clock is involved the output is
+ * the same on every run, which allows checking the mixer without any
+ * audio hardware.
+ *
+ * =======================================================================
+ */
+package client
+
+import (
+	"encoding/binary"
+	"goquake2/shared"
+	"os"
+)
+
+const WAV_HEADER_SIZE = 44
+
+type sndNull struct {
+	common   shared.QCommon
+	filename string
+
+	speed    int
+	channels int
+
+	/* sample pairs handed to the device so far. The
+	   null device consumes samples as fast as they are
+	   submitted, so its output depends only on what
+	   was mixed and not on the wall clock */
+	submitted int
+
+	file    *os.File
+	written int /* bytes of sample data in the file */
+	bytes   []byte
+}
+
+func (s *sndNull) Init(dma *dma_t) bool {
+	s.speed = dma.speed
+	s.channels = dma.channels
+	s.submitted = 0
+
+	dma.samples = dma.speed * dma.channels
+	dma.submission_chunk = 1
+
+	if len(s.filename) > 0 {
+		f, err := os.Create(s.filename)
+		if err != nil {
+			s.common.Com_Printf("Couldn't open %s for writing\n", s.filename)
+		} else {
+			s.file = f
+			s.written = 0
+			s.writeHeader()
+			s.common.Com_Printf("Writing sound output to %s\n", s.filename)
+		}
+	}
+
+	return true
+}
+
+/*
+ * Writes the RIFF header. It is rewritten after every
+ * submission, so the file is valid even if the
+ * client never shuts down cleanly.
+ */
+func (s *sndNull) writeHeader() {
+	var h [WAV_HEADER_SIZE]byte
+
+	copy(h[0:], "RIFF")
+	binary.LittleEndian.PutUint32(h[4:], uint32(WAV_HEADER_SIZE-8+s.written))
+	copy(h[8:], "WAVE")
+
+	copy(h[12:], "fmt ")
+	binary.LittleEndian.PutUint32(h[16:], 16)
+	binary.LittleEndian.PutUint16(h[20:], 1) /* PCM */
+	binary.LittleEndian.PutUint16(h[22:], uint16(s.channels))
+	binary.LittleEndian.PutUint32(h[24:], uint32(s.speed))
+	binary.LittleEndian.PutUint32(h[28:], uint32(s.speed*s.channels*2))
+	binary.LittleEndian.PutUint16(h[32:], uint16(s.channels*2))
+	binary.LittleEndian.PutUint16(h[34:], 16)
+
+	copy(h[36:], "data")
+	binary.LittleEndian.PutUint32(h[40:], uint32(s.written))
+
+	s.file.WriteAt(h[:], 0)
+}
+
+func (s *sndNull) Shutdown() {
+	if s.file != nil {
+		s.writeHeader()
+		s.file.Close()
+		s.file = nil
+	}
+}
+
+func (s *sndNull) SoundTime() int {
+	return s.submitted
+}
+
+func (s *sndNull) Submit(samples []int16) {
+	s.submitted += len(samples) / s.channels
+
+	if s.file == nil {
+		return
+	}
+
+	if cap(s.bytes) < len(samples)*2 {
+		s.bytes = make([]byte, len(samples)*2)
+	}
+	b := s.bytes[:len(samples)*2]
+
+	for i, v := range samples {
+		binary.LittleEndian.PutUint16(b[i*2:], uint16(v))
+	}
+
+	if _, err := s.file.WriteAt(b, int64(WAV_HEADER_SIZE+s.written)); err != nil {
+		return
+	}
+	s.written += len(b)
+	s.writeHeader()
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright (C) 2010, 2013 Yamagi Burmeister
+ * Copyright (C) 2005 Ryan C. Gordon
+ * Copyright (C) 1997-2001 Id Software, Inc.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or (at
+ * your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ *
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 59 Temple Place - Suite 330, Boston, MA
+ * 02111-1307, USA.
+ *
+ * =======================================================================
+ *
+ * SDL sound backend. Instead of a callback filling a ring buffer the
+ * mixed samples are pushed into the SDL audio queue, the amount still
+ * queued tells how far the device has played.
+ *
+ * =======================================================================
+ */
+package client
+
+import (
+	"encoding/binary"
+	"goquake2/shared"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+type sndSDL struct {
+	common    shared.QCommon
+	dev       sdl.AudioDeviceID
+	submitted int /* sample pairs queued since init */
+	bytes     []byte
+}
+
+func (s *sndSDL) Init(dma *dma_t) bool {
+	if sdl.WasInit(sdl.INIT_AUDIO) == 0 {
+		if err := sdl.InitSubSystem(sdl.INIT_AUDIO); err != nil {
+			s.common.Com_Printf("Couldn't init SDL audio: %v.\n", err)
+			return false
+		}
+	}
+
+	s.common.Com_Printf("SDL audio driver: %s\n", sdl.GetCurrentAudioDriver())
+
+	desired := sdl.AudioSpec{
+		Freq:     int32(dma.speed),
+		Format:   sdl.AUDIO_S16LSB,
+		Channels: uint8(dma.channels),
+	}
+
+	/* Make sure we mix at least
+	   one frame worth of data */
+	if desired.Freq <= 11025 {
+		desired.Samples = 256
+	} else if desired.Freq <= 22050 {
+		desired.Samples = 512
+	} else {
+		desired.Samples = 1024
+	}
+
+	var obtained sdl.AudioSpec
+	dev, err := sdl.OpenAudioDevice("", false, &desired, &obtained, 0)
+	if err != nil {
+		s.common.Com_Printf("SDL_OpenAudio() failed: %v\n", err)
+		sdl.QuitSubSystem(sdl.INIT_AUDIO)
+		return false
+	}
+
+	s.dev = dev
+	s.submitted = 0
+
+	/* the queue can hold the whole
+	   mixahead, one second is plenty */
+	dma.samples = dma.speed * dma.channels
+	dma.submission_chunk = 1
+
+	sdl.PauseAudioDevice(s.dev, false)
+	return true
+}
+
+func (s *sndSDL) Shutdown() {
+	s.common.Com_Printf("Closing SDL audio device...\n")
+	sdl.PauseAudioDevice(s.dev, true)
+	sdl.CloseAudioDevice(s.dev)
+	sdl.QuitSubSystem(sdl.INIT_AUDIO)
+	s.dev = 0
+	s.common.Com_Printf("SDL audio device shut down.\n")
+}
+
+func (s *sndSDL) SoundTime() int {
+	queued := int(sdl.GetQueuedAudioSize(s.dev)) / 4
+	return s.submitted - queued
+}
+
+func (s *sndSDL) Submit(samples []int16) {
+	if cap(s.bytes) < len(samples)*2 {
+		s.bytes = make([]byte, len(samples)*2)
+	}
+	b := s.bytes[:len(samples)*2]
+
+	for i, v := range samples {
+		binary.LittleEndian.PutUint16(b[i*2:], uint16(v))
+	}
+
+	if err := sdl.QueueAudio(s.dev, b); err != nil {
+		return
+	}
+	s.submitted += len(samples) / 2
+}
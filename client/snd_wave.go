@@ -0,0 +1,300 @@
+/*
+ * Copyright (C) 1997-2001 Id Software, Inc.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or (at
+ * your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ *
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 59 Temple Place - Suite 330, Boston, MA
+ * 02111-1307, USA.
+ *
+ * =======================================================================
+ *
+ * This file implements a subset of the WAVE audio file format.
+ *
+ * =======================================================================
+ */
+package client
+
+import "encoding/binary"
+
+type wavinfo_t struct {
+	rate      int
+	width     int
+	channels  int
+	loopstart int
+	samples   int
+	dataofs   int /* chunk starts this many bytes from file start */
+}
+
+/* walks the chunks of a RIFF file */
+type wavparser_t struct {
+	wav        []byte
+	iff_data   int
+	last_chunk int
+	data_p     int /* -1 if the chunk wasn't found */
+}
+
+func (p *wavparser_t) getLittleShort() int {
+	if p.data_p+2 > len(p.wav) {
+		p.data_p = len(p.wav)
+		return 0
+	}
+	val := int(int16(binary.LittleEndian.Uint16(p.wav[p.data_p:])))
+	p.data_p += 2
+	return val
+}
+
+func (p *wavparser_t) getLittleLong() int {
+	if p.data_p+4 > len(p.wav) {
+		p.data_p = len(p.wav)
+		return 0
+	}
+	val := int(int32(binary.LittleEndian.Uint32(p.wav[p.data_p:])))
+	p.data_p += 4
+	return val
+}
+
+func (p *wavparser_t) findNextChunk(name string) {
+	for {
+		p.data_p = p.last_chunk
+
+		p.data_p += 4
+		if p.data_p >= len(p.wav) {
+			/* didn't find the chunk */
+			p.data_p = -1
+			return
+		}
+
+		iff_chunk_len := p.getLittleLong()
+		if iff_chunk_len < 0 {
+			p.data_p = -1
+			return
+		}
+
+		p.data_p -= 8
+		p.last_chunk = p.data_p + 8 + ((iff_chunk_len + 1) & ^1)
+
+		if p.data_p+4 > len(p.wav) {
+			p.data_p = -1
+			return
+		}
+
+		if string(p.wav[p.data_p:p.data_p+4]) == name {
+			return
+		}
+	}
+}
+
+func (p *wavparser_t) findChunk(name string) {
+	p.last_chunk = p.iff_data
+	p.findNextChunk(name)
+}
+
+func (T *qClient) getWavinfo(name string, wav []byte) wavinfo_t {
+	info := wavinfo_t{}
+
+	if len(wav) == 0 {
+		return info
+	}
+
+	p := &wavparser_t{wav: wav}
+
+	/* find "RIFF" chunk */
+	p.findChunk("RIFF")
+	if !(p.data_p >= 0 && p.data_p+12 <= len(wav) && string(wav[p.data_p+8:p.data_p+12]) == "WAVE") {
+		T.common.Com_Printf("Missing RIFF/WAVE chunks\n")
+		return info
+	}
+
+	/* get "fmt " chunk */
+	p.iff_data = p.data_p + 12
+
+	p.findChunk("fmt ")
+	if p.data_p < 0 {
+		T.common.Com_Printf("Missing fmt chunk\n")
+		return info
+	}
+
+	p.data_p += 8
+	format := p.getLittleShort()
+	if format != 1 {
+		T.common.Com_Printf("Microsoft PCM format only\n")
+		return info
+	}
+
+	info.channels = p.getLittleShort()
+	info.rate = p.getLittleLong()
+	p.data_p += 4 + 2
+	info.width = p.getLittleShort() / 8
+
+	/* get cue chunk */
+	p.findChunk("cue ")
+	if p.data_p >= 0 {
+		p.data_p += 32
+		info.loopstart = p.getLittleLong()
+
+		/* if the next chunk is a LIST chunk,
+		   look for a cue length marker */
+		p.findNextChunk("LIST")
+		if p.data_p >= 0 {
+			if (p.data_p+32 <= len(wav)) && string(wav[p.data_p+28:p.data_p+32]) == "mark" {
+				/* this is not a proper parse,
+				   but it works with cooledit... */
+				p.data_p += 24
+				i := p.getLittleLong() /* samples in loop */
+				info.samples = info.loopstart + i
+			}
+		}
+	} else {
+		info.loopstart = -1
+	}
+
+	/* find data chunk */
+	p.findChunk("data")
+	if p.data_p < 0 {
+		T.common.Com_Printf("Missing data chunk\n")
+		return info
+	}
+
+	if info.width != 1 && info.width != 2 {
+		T.common.Com_Printf("%s has unsupported sample width %v\n", name, info.width)
+		info.channels = 0
+		return info
+	}
+
+	p.data_p += 4
+	samples := p.getLittleLong() / info.width
+	if p.data_p+samples*info.width > len(wav) {
+		samples = (len(wav) - p.data_p) / info.width
+	}
+
+	if info.samples != 0 {
+		if samples < info.samples {
+			T.common.Com_Printf("Sound %s has a bad loop length\n", name)
+			info.channels = 0
+			return info
+		}
+	} else {
+		info.samples = samples
+	}
+
+	info.dataofs = p.data_p
+
+	return info
+}
+
+/*
+ * Reads a whole file through the
+ * filesystem handle interface
+ */
+func (T *qClient) sReadFile(name string) []byte {
+	f, _ := T.common.FS_FOpenFile(name, false)
+	if f == nil {
+		return nil
+	}
+	defer f.Close()
+
+	var data []byte
+	for {
+		chunk := f.Read(16384)
+		if len(chunk) == 0 {
+			break
+		}
+		data = append(data, chunk...)
+	}
+
+	return data
+}
+
+/*
+ * Converts the loaded sample to the
+ * rate and width of the dma buffer
+ */
+func (T *qClient) sResampleSfx(sfx *sfx_t, info *wavinfo_t, data []byte) *sfxcache_t {
+	stepscale := float32(info.rate) / float32(T.snd.dma.speed) /* this is usually 0.5, 1, or 2 */
+
+	sc := &sfxcache_t{}
+	sc.length = int(float32(info.samples) / stepscale)
+	sc.loopstart = info.loopstart
+	if sc.loopstart != -1 {
+		sc.loopstart = int(float32(sc.loopstart) / stepscale)
+	}
+	sc.speed = T.snd.dma.speed
+	sc.data = make([]int16, sc.length)
+
+	/* resample / decimate to the current source rate */
+	samplefrac := 0
+	fracstep := int(stepscale * 256)
+
+	for i := 0; i < sc.length; i++ {
+		srcsample := samplefrac >> 8
+		samplefrac += fracstep
+
+		if srcsample >= info.samples {
+			srcsample = info.samples - 1
+		}
+
+		if info.width == 2 {
+			sc.data[i] = int16(binary.LittleEndian.Uint16(data[srcsample*2:]))
+		} else {
+			sc.data[i] = int16((int(data[srcsample]) - 128) << 8)
+		}
+	}
+
+	sfx.cache = sc
+	return sc
+}
+
+func (T *qClient) sLoadSound(s *sfx_t) *sfxcache_t {
+	if s.name[0] == '*' {
+		return nil
+	}
+
+	/* see if still in memory */
+	if s.cache != nil {
+		return s.cache
+	}
+
+	/* load it in */
+	name := s.name
+	if len(s.truename) > 0 {
+		name = s.truename
+	}
+
+	var namebuffer string
+	if name[0] == '#' {
+		namebuffer = name[1:]
+	} else {
+		namebuffer = "sound/" + name
+	}
+
+	data := T.sReadFile(namebuffer)
+	if data == nil {
+		T.common.Com_DPrintf("Couldn't load %s\n", namebuffer)
+		return nil
+	}
+
+	info := T.getWavinfo(s.name, data)
+	if info.channels != 1 {
+		if info.channels != 0 {
+			T.common.Com_Printf("%s is a stereo sample\n", s.name)
+		}
+		return nil
+	}
+
+	if info.rate <= 0 || info.samples <= 0 {
+		return nil
+	}
+
+	return T.sResampleSfx(s, &info, data[info.dataofs:])
+}
@@ -29,6 +29,7 @@ import (
 	"fmt"
 	"goquake2/shared"
 	"log"
+	"strings"
 )
 
 /*
@@ -131,7 +132,53 @@ func (T *qCommon) Com_VPrintf(print_level int, format string, a ...interface{})
 	// 		 }
 	// 	 }
 	//  }
-	fmt.Printf(format, a...)
+	msg := fmt.Sprintf(format, a...)
+
+	if T.rd_target != 0 {
+		for len(msg)+T.rd_buffer.Len() > T.rd_buffersize-1 {
+			/* send what fits and keep going, long
+			   output goes out in several chunks */
+			n := T.rd_buffersize - 1 - T.rd_buffer.Len()
+			T.rd_buffer.WriteString(msg[:n])
+			msg = msg[n:]
+			T.rd_flush(T.rd_target, T.rd_buffer.String())
+			T.rd_buffer.Reset()
+		}
+
+		T.rd_buffer.WriteString(msg)
+		return
+	}
+
+	fmt.Print(msg)
+}
+
+/*
+ * Redirects all Com_Printf output into a buffer. The
+ * buffer is handed to flush whenever it is full and
+ * when Com_EndRedirect is called.
+ */
+func (T *qCommon) Com_BeginRedirect(target, buffersize int, flush func(target int, buffer string)) {
+	if target == 0 || buffersize <= 1 || flush == nil {
+		return
+	}
+
+	T.rd_target = target
+	T.rd_buffersize = buffersize
+	T.rd_flush = flush
+	T.rd_buffer.Reset()
+}
+
+func (T *qCommon) Com_EndRedirect() {
+	if T.rd_target == 0 {
+		return
+	}
+
+	T.rd_flush(T.rd_target, T.rd_buffer.String())
+
+	T.rd_target = 0
+	T.rd_buffersize = 0
+	T.rd_flush = nil
+	T.rd_buffer.Reset()
 }
 
 /*
@@ -149,6 +196,28 @@ func (T *qCommon) Com_DPrintf(format string, a ...interface{}) {
 	T.Com_VPrintf(shared.PRINT_DEVELOPER, format, a...)
 }
 
+/*
+ * Prints an info string as a table of
+ * keys and values.
+ */
+func (T *qCommon) Info_Print(s string) {
+	split := strings.Split(strings.TrimPrefix(s, "\\"), "\\")
+	if len(s) == 0 {
+		return
+	}
+
+	for i := 0; i < len(split); i += 2 {
+		T.Com_Printf("%-20s", split[i])
+
+		if i+1 >= len(split) {
+			T.Com_Printf("MISSING VALUE\n")
+			return
+		}
+
+		T.Com_Printf("%s\n", split[i+1])
+	}
+}
+
 type AbortFrame struct{}
 
 func (m *AbortFrame) Error() string {
@@ -232,7 +232,7 @@ func (T *qCommon) Cmd_ExecuteString(text string) error {
 	/* send it as a server command if we are connected */
 	// Cmd_ForwardToServer()
 
-	fmt.Printf("Unknown command \"%v\"\n", args[0])
+	T.Com_Printf("Unknown command \"%v\"\n", args[0])
 	return nil
 }
 
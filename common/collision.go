@@ -162,6 +162,16 @@ func (T *qCommon) floodAreaConnections() {
 	}
 }
 
+func (T *qCommon) CMSetAreaPortalState(portalnum int, open bool) error {
+	if (portalnum < 0) || (portalnum > T.collision.numareaportals) {
+		return T.Com_Error(shared.ERR_DROP, "areaportal > numareaportals")
+	}
+
+	T.collision.portalopen[portalnum] = open
+	T.floodAreaConnections()
+	return nil
+}
+
 func (T *qCommon) CMAreasConnected(area1, area2 int) bool {
 	if T.collision.map_noareas.Bool() {
 		return true
@@ -211,6 +221,34 @@ func (T *qCommon) CMWriteAreaBits(buffer []byte, area int) int {
 	return bytes
 }
 
+/*
+ * Returns the portal state, one byte per
+ * portal, for writing into a savegame
+ */
+func (T *qCommon) CMWritePortalState() []byte {
+	data := make([]byte, len(T.collision.portalopen))
+
+	for i, open := range T.collision.portalopen {
+		if open {
+			data[i] = 1
+		}
+	}
+
+	return data
+}
+
+/*
+ * Reads the portal state from a savegame
+ * and recalculates the area connections
+ */
+func (T *qCommon) CMReadPortalState(data []byte) {
+	for i := range T.collision.portalopen {
+		T.collision.portalopen[i] = i < len(data) && data[i] != 0
+	}
+
+	T.floodAreaConnections()
+}
+
 /*
  * Returns true if any leaf under headnode has a cluster that
  * is potentially visible
@@ -29,7 +29,7 @@ package common
 
 import (
 	"goquake2/shared"
-	"net"
+	"strings"
 	"time"
 )
 
@@ -95,8 +95,12 @@ type qCommon struct {
 	UserinfoModified bool
 
 	// from clientserver
-	recursive bool
-	msg       string
+	recursive     bool
+	msg           string
+	rd_target     int
+	rd_buffer     strings.Builder
+	rd_buffersize int
+	rd_flush      func(target int, buffer string)
 
 	// from network
 	loopback [](chan []byte)
@@ -113,7 +117,11 @@ type qCommon struct {
 	pm_waterfriction   float32
 	pm_waterspeed      float32
 
-	ip_sockets [2]*net.UDPConn
+	ip_sockets          [2]*netReceiver
+	ip6_sockets         [2]*netReceiver
+	multicast_interface string
+
+	netsim [2]netSim
 }
 
 func CreateQCommon(client shared.QClient, server shared.QServer) shared.QCommon {
@@ -333,6 +333,26 @@ func (T *qCommon) Cvar_Serverinfo() string {
 	return T.cvarBitInfo(shared.CVAR_SERVERINFO)
 }
 
+/*
+ * returns the names and values
+ * of all the CVAR_LATCH cvars
+ */
+func (T *qCommon) Cvar_LatchedVars() map[string]string {
+	vars := make(map[string]string)
+
+	for key, val := range T.cvarVars {
+		if (val.Flags & shared.CVAR_LATCH) != 0 {
+			vars[key] = val.String
+		}
+	}
+
+	return vars
+}
+
+func (T *qCommon) Cvar_UserinfoModified() bool {
+	return T.UserinfoModified
+}
+
 func (T *qCommon) Cvar_ClearUserinfoModified() {
 	T.UserinfoModified = false
 }
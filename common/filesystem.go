@@ -30,6 +30,7 @@ import (
 	"fmt"
 	"goquake2/shared"
 	"os"
+	"path/filepath"
 	"strings"
 )
 
@@ -216,6 +217,28 @@ func (T *qCommon) FS_FOpenFile(name string, gamedir_only bool) (shared.QFileHand
 	return nil, nil
 }
 
+/*
+ * Returns the directory that
+ * all writes go into.
+ */
+func (T *qCommon) FS_Gamedir() string {
+	return T.fs_gamedir
+}
+
+/*
+ * Creates any directories needed to store the given filename.
+ */
+func (T *qCommon) FS_CreatePath(path string) {
+	if strings.Contains(path, "..") {
+		T.Com_Printf("WARNING: refusing to create relative path '%s'.\n", path)
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		T.Com_Printf("FS_CreatePath: %v\n", err)
+	}
+}
+
 /*
  * Filename are reletive to the quake search path. A null buffer will just
  * return the file length without loading.
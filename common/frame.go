@@ -200,6 +200,7 @@ func (T *qCommon) Init() error {
 	// 	Sys_Init();
 	// 	NET_Init();
 	T.netchanInit()
+	T.netsimInit()
 	if err := T.server.Init(T); err != nil {
 		return err
 	}
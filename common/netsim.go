@@ -0,0 +1,175 @@
+/*
+ * Copyright (C) 1997-2001 Id Software, Inc.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or (at
+ * your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ *
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 59 Temple Place - Suite 330, Boston, MA
+ * 02111-1307, USA.
+ *
+ * =======================================================================
+ *
+ * Network condition simulator. Sits below NET_SendPacket and delays,
+ * drops, duplicates and reorders outgoing packets of each socket, so
+ * the netchan, prediction and delta compression can be tested against
+ * bad networks. Works for loopback too, so a single process is enough.
+ *
+ * =======================================================================
+ */
+package common
+
+import (
+	"goquake2/shared"
+	"math/rand"
+	"sort"
+)
+
+type netSimPacket struct {
+	due  int /* Sys_Milliseconds() when the packet goes out */
+	seq  int /* keeps the send order for packets due at the same time */
+	to   shared.Netadr_t
+	data []byte
+}
+
+type netSim struct {
+	latency *shared.CvarT /* msec added to every packet */
+	jitter  *shared.CvarT /* random +/- msec on top of the latency */
+	loss    *shared.CvarT /* percent of packets dropped */
+	dup     *shared.CvarT /* percent of packets sent twice */
+	reorder *shared.CvarT /* percent of packets held back behind later ones */
+
+	queue []netSimPacket
+	seq   int
+}
+
+func (T *qCommon) netsimInit() {
+	for i, prefix := range []string{"cl", "sv"} {
+		sim := &T.netsim[i]
+		sim.latency = T.Cvar_Get("netsim_"+prefix+"_latency", "0", 0)
+		sim.jitter = T.Cvar_Get("netsim_"+prefix+"_jitter", "0", 0)
+		sim.loss = T.Cvar_Get("netsim_"+prefix+"_loss", "0", 0)
+		sim.dup = T.Cvar_Get("netsim_"+prefix+"_dup", "0", 0)
+		sim.reorder = T.Cvar_Get("netsim_"+prefix+"_reorder", "0", 0)
+	}
+}
+
+func (sim *netSim) active() bool {
+	if sim.latency == nil {
+		return false
+	}
+
+	return sim.latency.Float() > 0 || sim.jitter.Float() > 0 ||
+		sim.loss.Float() > 0 || sim.dup.Float() > 0 ||
+		sim.reorder.Float() > 0
+}
+
+func (sim *netSim) chance(cvar *shared.CvarT) bool {
+	return rand.Float32()*100 < cvar.Float()
+}
+
+func (sim *netSim) delay() int {
+	d := sim.latency.Float()
+	if j := sim.jitter.Float(); j > 0 {
+		d += shared.Crandk() * j
+	}
+
+	if sim.chance(sim.reorder) {
+		/* hold it back long enough for the
+		   following packets to overtake it */
+		extra := sim.latency.Float() + sim.jitter.Float()
+		if extra < 50 {
+			extra = 50
+		}
+		d += extra
+	}
+
+	if d < 0 {
+		return 0
+	}
+	return int(d)
+}
+
+func (sim *netSim) enqueue(now int, data []byte, to shared.Netadr_t) {
+	/* the caller may reuse its buffer */
+	buf := make([]byte, len(data))
+	copy(buf, data)
+
+	sim.queue = append(sim.queue, netSimPacket{
+		due:  now + sim.delay(),
+		seq:  sim.seq,
+		to:   to,
+		data: buf,
+	})
+	sim.seq++
+}
+
+/*
+ * Puts an outgoing packet through the simulator
+ * of the socket instead of sending it directly.
+ */
+func (T *qCommon) netsimSend(sock shared.Netsrc_t, data []byte, to shared.Netadr_t) error {
+	sim := &T.netsim[sock]
+
+	if sim.chance(sim.loss) {
+		if T.showdrop != nil && T.showdrop.Bool() {
+			T.Com_Printf("netsim: dropped %v bytes to %s\n", len(data), shared.NET_AdrToString(to))
+		}
+		return nil
+	}
+
+	now := T.Sys_Milliseconds()
+	sim.enqueue(now, data, to)
+
+	if sim.chance(sim.dup) {
+		sim.enqueue(now, data, to)
+	}
+
+	return T.netsimFlush()
+}
+
+/*
+ * Sends all delayed packets that are due.
+ * Called every time the network is polled.
+ */
+func (T *qCommon) netsimFlush() error {
+	now := T.Sys_Milliseconds()
+
+	for i := range T.netsim {
+		sim := &T.netsim[i]
+		if len(sim.queue) == 0 {
+			continue
+		}
+
+		sort.Slice(sim.queue, func(a, b int) bool {
+			if sim.queue[a].due != sim.queue[b].due {
+				return sim.queue[a].due < sim.queue[b].due
+			}
+			return sim.queue[a].seq < sim.queue[b].seq
+		})
+
+		sent := 0
+		for _, p := range sim.queue {
+			if p.due > now {
+				break
+			}
+			if err := T.netSendPacket(shared.Netsrc_t(i), p.data, p.to); err != nil {
+				return err
+			}
+			sent++
+		}
+
+		sim.queue = sim.queue[sent:]
+	}
+
+	return nil
+}
@@ -26,14 +26,152 @@
 package common
 
 import (
-	"fmt"
+	"errors"
 	"goquake2/shared"
 	"net"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
+const netQueueLength = 256 /* packets buffered per socket */
+
+/*
+ * Limits for the pause after a failed read, so a
+ * socket that keeps erroring doesn't spin the receiver.
+ */
+const netMinBackoff = time.Millisecond
+const netMaxBackoff = 100 * time.Millisecond
+
+type netPacket struct {
+	from shared.Netadr_t
+	buf  *[shared.MAX_MSGLEN]byte
+	size int
+}
+
+/*
+ * Reads datagrams from one UDP socket in its own
+ * goroutine and hands them to the main loop through
+ * a bounded queue. If the main loop falls behind
+ * the packets are dropped and counted.
+ */
+type netReceiver struct {
+	conn    *net.UDPConn
+	packets chan netPacket
+	done    chan struct{}
+	dropped uint64
+	failed  uint64 /* read errors */
+
+	/* buffer of the packet last returned by NET_GetPacket,
+	   it stays valid until the next call */
+	current *[shared.MAX_MSGLEN]byte
+}
+
+var netBuffers = sync.Pool{
+	New: func() interface{} { return new([shared.MAX_MSGLEN]byte) },
+}
+
+func netStartReceiver(conn *net.UDPConn) *netReceiver {
+	rcv := &netReceiver{
+		conn:    conn,
+		packets: make(chan netPacket, netQueueLength),
+		done:    make(chan struct{}),
+	}
+	go rcv.run()
+	return rcv
+}
+
+func (rcv *netReceiver) run() {
+	defer close(rcv.done)
+
+	var backoff time.Duration
+
+	for {
+		buf := netBuffers.Get().(*[shared.MAX_MSGLEN]byte)
+		r, addr, err := rcv.conn.ReadFromUDP(buf[:])
+		if err != nil {
+			netBuffers.Put(buf)
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+
+			/* back off while the socket keeps failing */
+			atomic.AddUint64(&rcv.failed, 1)
+			if backoff == 0 {
+				backoff = netMinBackoff
+			} else if backoff < netMaxBackoff {
+				backoff *= 2
+			}
+			time.Sleep(backoff)
+			continue
+		}
+
+		backoff = 0
+
+		a := shared.NET_UDPAddrToAdr(addr)
+		if a == nil || r == len(buf) {
+			/* unknown address family or oversize packet */
+			netBuffers.Put(buf)
+			atomic.AddUint64(&rcv.dropped, 1)
+			continue
+		}
+
+		select {
+		case rcv.packets <- netPacket{from: *a, buf: buf, size: r}:
+		default:
+			netBuffers.Put(buf)
+			atomic.AddUint64(&rcv.dropped, 1)
+		}
+	}
+}
+
+/*
+ * Returns the next queued packet without blocking.
+ */
+func (rcv *netReceiver) get() (*shared.Netadr_t, []byte) {
+	if rcv.current != nil {
+		netBuffers.Put(rcv.current)
+		rcv.current = nil
+	}
+
+	select {
+	case p := <-rcv.packets:
+		rcv.current = p.buf
+		return &p.from, p.buf[:p.size]
+	default:
+		return nil, nil
+	}
+}
+
+/*
+ * Closes the socket, waits for the goroutine
+ * to finish and returns the number of packets
+ * that were dropped and of failed reads.
+ */
+func (rcv *netReceiver) close() (uint64, uint64) {
+	rcv.conn.Close()
+	<-rcv.done
+
+	if rcv.current != nil {
+		netBuffers.Put(rcv.current)
+		rcv.current = nil
+	}
+
+	for {
+		select {
+		case p := <-rcv.packets:
+			netBuffers.Put(p.buf)
+		default:
+			return atomic.LoadUint64(&rcv.dropped), atomic.LoadUint64(&rcv.failed)
+		}
+	}
+}
+
 func (T *qCommon) NET_GetPacket(sock shared.Netsrc_t) (*shared.Netadr_t, []byte) {
+	/* let the simulator release the delayed packets */
+	T.netsimFlush()
+
 	index := (int(sock) + 1) & 1
 	select {
 	case c := <-T.loopback[index]:
@@ -41,23 +179,12 @@ func (T *qCommon) NET_GetPacket(sock shared.Netsrc_t) (*shared.Netadr_t, []byte)
 		a.Type = shared.NA_LOOPBACK
 		return a, c
 	default:
-		var msg [shared.MAX_MSGLEN]byte
-		if T.ip_sockets[sock] != nil {
-			T.ip_sockets[sock].SetReadDeadline(time.Now().Add(time.Microsecond))
-			r, addr, err := T.ip_sockets[sock].ReadFromUDP(msg[:])
-			if err != nil {
-				// T.Com_Printf("ERROR receiving %s\n", err.Error())
+		for _, rcv := range []*netReceiver{T.ip_sockets[sock], T.ip6_sockets[sock]} {
+			if rcv == nil {
+				continue
 			}
-			if r > 0 {
-				println("Received", r, addr)
-				a := &shared.Netadr_t{}
-				a.Type = shared.NA_IP
-				a.Port = uint16(addr.Port)
-				a.Ip[0] = addr.IP[0]
-				a.Ip[1] = addr.IP[1]
-				a.Ip[2] = addr.IP[2]
-				a.Ip[3] = addr.IP[3]
-				return a, msg[:r]
+			if a, data := rcv.get(); a != nil {
+				return a, data
 			}
 		}
 		return nil, nil
@@ -65,217 +192,155 @@ func (T *qCommon) NET_GetPacket(sock shared.Netsrc_t) (*shared.Netadr_t, []byte)
 }
 
 func (T *qCommon) NET_SendPacket(sock shared.Netsrc_t, data []byte, to shared.Netadr_t) error {
-	// 	int ret;
-	// 	struct sockaddr_storage addr;
-	// 	int net_socket;
-	// 	int addr_size = sizeof(struct sockaddr_in);
+	if T.netsim[sock].active() {
+		return T.netsimSend(sock, data, to)
+	}
+
+	return T.netSendPacket(sock, data, to)
+}
+
+func (T *qCommon) netSendPacket(sock shared.Netsrc_t, data []byte, to shared.Netadr_t) error {
+
+	var net_socket *netReceiver
 
 	switch to.Type {
 	case shared.NA_LOOPBACK:
 		index := int(sock)
-		T.loopback[index] <- data
-		return nil
-
-	case shared.NA_BROADCAST:
-		net_socket := T.ip_sockets[sock]
-		if net_socket == nil {
-			println("NO SOCKET")
-			return nil
-		}
-		_, err := net_socket.Write(data)
-		if err != nil {
-			T.Com_Printf("NET_SendPacket ERROR: %s to %v\n", err.Error(), to)
-			return err
+		select {
+		case T.loopback[index] <- data:
+		default:
+			/* the other side is not reading, drop
+			   it like a full socket buffer would */
 		}
 		return nil
 
-	case shared.NA_IP:
-		// net_socket := T.ip_sockets[sock]
-		// if net_socket == nil {
-		// 	println("NO SOCKET")
-		// 	return nil
-		// }
-		// addr := &net.UDPAddr{
-		// 	IP:   net.IPv4(to.Ip[0], to.Ip[1], to.Ip[2], to.Ip[3]),
-		// 	Port: int(to.Port),
-		// }
-		return nil
+	case shared.NA_BROADCAST,
+		shared.NA_IP:
+		net_socket = T.ip_sockets[sock]
 
 	case shared.NA_IP6,
 		shared.NA_MULTICAST6:
-		// 			net_socket = ip6_sockets[sock];
-		// 			addr_size = sizeof(struct sockaddr_in6);
-		// 			if (!net_socket) {
-		return nil
-	// 			}
-
-	// 		case NA_IPX:
-	// 		case NA_BROADCAST_IPX:
-	// 			net_socket = ipx_sockets[sock];
-	// 			if (!net_socket) {
-	// 				return;
-	// 			}
-	// 			break;
+		net_socket = T.ip6_sockets[sock]
 
 	default:
 		return T.Com_Error(shared.ERR_FATAL, "NET_SendPacket: bad address type")
 	}
 
-	// 	NetadrToSockadr(&to, &addr);
-
-	// 	/* Re-check the address family. If to.type is NA_IP6 but
-	// 	   contains an IPv4 mapped address, NetadrToSockadr will
-	// 	   return an AF_INET struct.  If so, switch back to AF_INET
-	// 	   socket.*/
-	// 	if ((to.type == NA_IP6) && (addr.ss_family == AF_INET))
-	// 	{
-	// 		net_socket = ip_sockets[sock];
-	// 		addr_size = sizeof(struct sockaddr_in);
-
-	// 		if (!net_socket)
-	// 		{
-	// 			return;
-	// 		}
-	// 	}
-
-	// 	if (addr.ss_family == AF_INET6)
-	// 	{
-	// 		struct sockaddr_in6 *s6 = (struct sockaddr_in6 *)&addr;
-
-	// 		/* If multicast socket, must specify scope.
-	// 		   So multicast_interface must be specified */
-	// 		if (IN6_IS_ADDR_MULTICAST(&s6->sin6_addr))
-	// 		{
-	// 			struct addrinfo hints;
-	// 			struct addrinfo *res;
-	// 			char tmp[128];
-
-	// 			if (multicast_interface != NULL)
-	// 			{
-	// 				int error;
-	// 				char mcast_addr[128], mcast_port[10];
-
-	// 				/* Do a getnameinfo/getaddrinfo cycle
-	// 				   to calculate the scope_id of the
-	// 				   multicast address. getaddrinfo is
-	// 				   passed a multicast address of the
-	// 				   form ff0x::xxx%multicast_interface */
-	// #ifdef SIN6_LEN
-	// 				error = getnameinfo((struct sockaddr *)s6, s6->sin6_len, tmp,
-	// 						sizeof(tmp), NULL, 0, NI_NUMERICHOST);
-	// #else
-	// 				error = getnameinfo((struct sockaddr *)s6,
-	// 							sizeof(struct sockaddr_in6),
-	// 							tmp, sizeof(tmp), NULL, 0, NI_NUMERICHOST);
-	// #endif
-
-	// 				if (error)
-	// 				{
-	// 					Com_Printf("NET_SendPacket: getnameinfo: %s\n",
-	// 							gai_strerror(error));
-	// 					return;
-	// 				}
-
-	// 				Com_sprintf(mcast_addr, sizeof(mcast_addr), "%s%%%s", tmp,
-	// 						multicast_interface);
-	// 				Com_sprintf(mcast_port, sizeof(mcast_port), "%d",
-	// 						ntohs(s6->sin6_port));
-	// 				memset(&hints, 0, sizeof(hints));
-	// 				hints.ai_family = AF_INET6;
-	// 				hints.ai_socktype = SOCK_DGRAM;
-	// 				hints.ai_flags = AI_NUMERICHOST;
-
-	// 				error = getaddrinfo(mcast_addr, mcast_port, &hints, &res);
-
-	// 				if (error)
-	// 				{
-	// 					Com_Printf("NET_SendPacket: getaddrinfo: %s\n",
-	// 							gai_strerror(error));
-	// 					return;
-	// 				}
-
-	// 				/* sockaddr_in6 should now have a valid scope_id. */
-	// 				memcpy(s6, res->ai_addr, res->ai_addrlen);
-	// 				freeaddrinfo(res);
-	// 			}
-	// 			else
-	// 			{
-	// 				Com_Printf("NET_SendPacket: IPv6 multicast destination but +set multicast not specified: %s\n",
-	// 						inet_ntop(AF_INET6, &s6->sin6_addr, tmp, sizeof(tmp)));
-	// 				return;
-	// 			}
-	// 		}
-	// 	}
-
-	// println("WriteToUDP")
-	// r, err := net_socket.WriteToUDP(data, addr)
-	// if err != nil {
-	// 	T.Com_Printf("NET_SendPacket ERROR: %s to %v\n", err.Error(), to)
-	// 	return err
-	// }
-	// T.Com_Printf("NET_SendPacket %v\n", r)
-
-	// 	ret = sendto(net_socket,
-	// 			data,
-	// 			length,
-	// 			0,
-	// 			(struct sockaddr *)&addr,
-	// 			addr_size);
-
-	// 	if (ret == -1)
-	// 	{
-	// 		Com_Printf("NET_SendPacket ERROR: %s to %s\n", NET_ErrorString(),
-	// 				NET_AdrToString(to));
-	// 	}
+	addr := shared.NET_AdrToUDPAddr(to)
+
+	/* Re-check the address family. If to.type is NA_IP6 but
+	   contains an IPv4 mapped address, NET_AdrToUDPAddr will
+	   return an IPv4 address. If so, switch back to the IPv4
+	   socket. */
+	if to.Type == shared.NA_IP6 && addr.IP.To4() != nil {
+		net_socket = T.ip_sockets[sock]
+	}
+
+	if net_socket == nil {
+		return nil
+	}
+
+	/* If multicast socket, must specify scope.
+	   So multicast_interface must be specified */
+	if addr.IP.IsMulticast() && addr.IP.To4() == nil && len(T.multicast_interface) > 0 {
+		addr.Zone = T.multicast_interface
+	}
+
+	if _, err := net_socket.conn.WriteToUDP(data, addr); err != nil {
+		T.Com_Printf("NET_SendPacket ERROR: %s to %s\n", err.Error(),
+			shared.NET_AdrToString(to))
+	}
 	return nil
 }
 
+/*
+ * Opens an UDP socket bound to the given interface
+ * and port and starts its receiver. PORT_ANY lets
+ * the system pick the port.
+ * The network is either "udp4" or "udp6", IPv6
+ * sockets are always IPv6 only, IPv4 traffic goes
+ * through their IPv4 counterpart.
+ */
+func (T *qCommon) netSocket(ip string, port int, network string, typ shared.Netsrc_t) *netReceiver {
+	addr := &net.UDPAddr{}
+
+	if len(ip) > 0 && ip != "localhost" {
+		a, err := net.ResolveUDPAddr(network, net.JoinHostPort(ip, "0"))
+		if err != nil {
+			T.Com_Printf("NET_Socket: %s\n", err.Error())
+			return nil
+		}
+		addr.IP = a.IP
+		addr.Zone = a.Zone
+	}
+
+	if port != shared.PORT_ANY {
+		addr.Port = port
+	}
+
+	if network == "udp6" && typ == shared.NS_SERVER && addr.IP == nil {
+		/* the server listens on the quake2 multicast group
+		   too, so IPv6 clients can find it on the LAN */
+		var ifi *net.Interface
+		if len(T.multicast_interface) > 0 {
+			i, err := net.InterfaceByName(T.multicast_interface)
+			if err != nil {
+				T.Com_Printf("NET_Socket: multicast interface %s: %s\n",
+					T.multicast_interface, err.Error())
+			} else {
+				ifi = i
+			}
+		}
+
+		gaddr := &net.UDPAddr{IP: net.ParseIP(shared.QUAKE2MCAST), Port: addr.Port}
+		sock, err := net.ListenMulticastUDP(network, ifi, gaddr)
+		if err == nil {
+			return netStartReceiver(sock)
+		}
+		T.Com_Printf("NET_Socket: IPV6_JOIN_GROUP: %s\n", err.Error())
+	}
+
+	/* the Go runtime enables SO_BROADCAST on datagram sockets */
+	sock, err := net.ListenUDP(network, addr)
+	if err != nil {
+		T.Com_Printf("NET_Socket: bind: %s\n", err.Error())
+		return nil
+	}
+
+	return netStartReceiver(sock)
+}
+
 func (T *qCommon) netOpenIP() {
 
-	println("netOpenIP")
 	port := T.Cvar_Get("port", strconv.Itoa(shared.PORT_SERVER), shared.CVAR_NOSET)
 	ip := T.Cvar_Get("ip", "localhost", shared.CVAR_NOSET)
-	host := ip.String
-	if len(host) == 0 || host == "localhost" {
-		host = "0.0.0.0"
+	multicast := T.Cvar_Get("multicast", "NULL", shared.CVAR_NOSET)
+
+	if multicast.String != "NULL" {
+		T.multicast_interface = multicast.String
+	} else {
+		T.multicast_interface = ""
 	}
 
-	// if (!ip6_sockets[NS_SERVER])
-	// {
-	// 	ip6_sockets[NS_SERVER] = NET_Socket(ip->string, port->value,
-	// 			NS_SERVER, AF_INET6);
-	// }
+	if T.ip6_sockets[shared.NS_SERVER] == nil {
+		T.ip6_sockets[shared.NS_SERVER] = T.netSocket(ip.String, port.Int(), "udp6", shared.NS_SERVER)
+	}
 
-	// if (!ip6_sockets[NS_CLIENT])
-	// {
-	// 	ip6_sockets[NS_CLIENT] = NET_Socket(ip->string, PORT_ANY,
-	// 			NS_CLIENT, AF_INET6);
-	// }
+	if T.ip6_sockets[shared.NS_CLIENT] == nil {
+		T.ip6_sockets[shared.NS_CLIENT] = T.netSocket(ip.String, shared.PORT_ANY, "udp6", shared.NS_CLIENT)
+	}
 
 	if T.ip_sockets[shared.NS_SERVER] == nil {
-		addr := net.UDPAddr{
-			Port: port.Int(),
-			IP:   net.ParseIP(host),
-		}
-		sock, err := net.ListenUDP("udp4", &addr)
-		if err != nil {
-			fmt.Printf("Failed to open SERVER socket %s\n", err.Error())
-		} else {
-			T.ip_sockets[shared.NS_SERVER] = sock
-		}
+		T.ip_sockets[shared.NS_SERVER] = T.netSocket(ip.String, port.Int(), "udp4", shared.NS_SERVER)
+	}
+
+	if T.ip_sockets[shared.NS_SERVER] == nil && T.ip6_sockets[shared.NS_SERVER] == nil &&
+		T.dedicated != nil && T.dedicated.Bool() {
+		T.Com_Error(shared.ERR_FATAL, "Couldn't allocate dedicated server IP port")
 	}
 
 	if T.ip_sockets[shared.NS_CLIENT] == nil {
-		addr := net.UDPAddr{
-			Port: port.Int(),
-			IP:   net.IPv4(255, 255, 255, 255),
-		}
-		sock, err := net.DialUDP("udp4", nil, &addr)
-		if err != nil {
-			fmt.Printf("Failed to open CLIENT socket %s\n", err.Error())
-		} else {
-			T.ip_sockets[shared.NS_CLIENT] = sock
-		}
+		T.ip_sockets[shared.NS_CLIENT] = T.netSocket(ip.String, shared.PORT_ANY, "udp4", shared.NS_CLIENT)
 	}
 }
 
@@ -283,25 +348,31 @@ func (T *qCommon) netOpenIP() {
  * A single player game will only use the loopback code
  */
 func (T *qCommon) NET_Config(multiplayer bool) {
-	println("NET_Config", multiplayer)
 	if !multiplayer {
 
 		/* shut down any existing sockets */
 		for i := 0; i < 2; i++ {
 			if T.ip_sockets[i] != nil {
-				T.ip_sockets[i].Close()
+				dropped, failed := T.ip_sockets[i].close()
+				if dropped > 0 {
+					T.Com_DPrintf("NET_Config: %v IPv4 packets dropped\n", dropped)
+				}
+				if failed > 0 {
+					T.Com_DPrintf("NET_Config: %v IPv4 reads failed\n", failed)
+				}
 				T.ip_sockets[i] = nil
 			}
 
-			// 	if (ip6_sockets[i]) {
-			// 		close(ip6_sockets[i]);
-			// 		ip6_sockets[i] = 0;
-			// 	}
-
-			// 	if (ipx_sockets[i]) {
-			// 		close(ipx_sockets[i]);
-			// 		ipx_sockets[i] = 0;
-			// 	}
+			if T.ip6_sockets[i] != nil {
+				dropped, failed := T.ip6_sockets[i].close()
+				if dropped > 0 {
+					T.Com_DPrintf("NET_Config: %v IPv6 packets dropped\n", dropped)
+				}
+				if failed > 0 {
+					T.Com_DPrintf("NET_Config: %v IPv6 reads failed\n", failed)
+				}
+				T.ip6_sockets[i] = nil
+			}
 		}
 	} else {
 		/* open sockets */
@@ -480,10 +480,10 @@ func (G *qGame) findTarget(self *edict_t) bool {
 
 	G.foundTarget(self)
 
-	//  if (!(self->monsterinfo.aiflags & AI_SOUND_TARGET) &&
-	// 	 (self->monsterinfo.sight)) {
-	// 	 self->monsterinfo.sight(self, self->enemy);
-	//  }
+	if (self.monsterinfo.aiflags&AI_SOUND_TARGET) == 0 &&
+		self.monsterinfo.sight != nil {
+		self.monsterinfo.sight(self, self.enemy, G)
+	}
 
 	return true
 }
@@ -0,0 +1,217 @@
+/*
+ * Copyright (C) 1997-2001 Id Software, Inc.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or (at
+ * your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ *
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 59 Temple Place - Suite 330, Boston, MA
+ * 02111-1307, USA.
+ *
+ * =======================================================================
+ *
+ * The chase camera. Only used by client when connected to a server.
+ *
+ * =======================================================================
+ */
+package game
+
+import "goquake2/shared"
+
+func (G *qGame) updateChaseCam(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	/* is our chase target gone? */
+	if !ent.client.chase_target.inuse ||
+		ent.client.chase_target.client.resp.spectator {
+		old := ent.client.chase_target
+		G.chaseNext(ent)
+
+		if ent.client.chase_target == old {
+			ent.client.chase_target = nil
+			ent.client.ps.Pmove.Pm_flags &^= shared.PMF_NO_PREDICTION
+			return
+		}
+	}
+
+	targ := ent.client.chase_target
+
+	ownerv := make([]float32, 3)
+	copy(ownerv, targ.s.Origin[:])
+	ownerv[2] += float32(targ.viewheight)
+
+	angles := make([]float32, 3)
+	copy(angles, targ.client.v_angle[:])
+
+	if angles[shared.PITCH] > 56 {
+		angles[shared.PITCH] = 56
+	}
+
+	forward := make([]float32, 3)
+	right := make([]float32, 3)
+	shared.AngleVectors(angles, forward, right, nil)
+	shared.VectorNormalize(forward)
+
+	o := make([]float32, 3)
+	shared.VectorMA(ownerv, -30, forward, o)
+
+	if o[2] < targ.s.Origin[2]+20 {
+		o[2] = targ.s.Origin[2] + 20
+	}
+
+	/* jump animation lifts */
+	if targ.groundentity == nil {
+		o[2] += 16
+	}
+
+	zero := []float32{0, 0, 0}
+	trace := G.gi.Trace(ownerv, zero, zero, o, targ, shared.MASK_SOLID)
+
+	goal := make([]float32, 3)
+	copy(goal, trace.Endpos[:])
+
+	shared.VectorMA(goal, 2, forward, goal)
+
+	/* pad for floors and ceilings */
+	copy(o, goal)
+	o[2] += 6
+	trace = G.gi.Trace(goal, zero, zero, o, targ, shared.MASK_SOLID)
+
+	if trace.Fraction < 1 {
+		copy(goal, trace.Endpos[:])
+		goal[2] -= 6
+	}
+
+	copy(o, goal)
+	o[2] -= 6
+	trace = G.gi.Trace(goal, zero, zero, o, targ, shared.MASK_SOLID)
+
+	if trace.Fraction < 1 {
+		copy(goal, trace.Endpos[:])
+		goal[2] += 6
+	}
+
+	if targ.deadflag != 0 {
+		ent.client.ps.Pmove.Pm_type = shared.PM_DEAD
+	} else {
+		ent.client.ps.Pmove.Pm_type = shared.PM_FREEZE
+	}
+
+	copy(ent.s.Origin[:], goal)
+
+	for i := 0; i < 3; i++ {
+		ent.client.ps.Pmove.Delta_angles[i] = shared.ANGLE2SHORT(
+			targ.client.v_angle[i] - ent.client.resp.cmd_angles[i])
+	}
+
+	if targ.deadflag != 0 {
+		ent.client.ps.Viewangles[shared.ROLL] = 40
+		ent.client.ps.Viewangles[shared.PITCH] = -15
+		ent.client.ps.Viewangles[shared.YAW] = targ.client.killer_yaw
+	} else {
+		copy(ent.client.ps.Viewangles[:], targ.client.v_angle[:])
+		copy(ent.client.v_angle[:], targ.client.v_angle[:])
+	}
+
+	ent.viewheight = 0
+	ent.client.ps.Pmove.Pm_flags |= shared.PMF_NO_PREDICTION
+	G.gi.Linkentity(ent)
+}
+
+func (G *qGame) chaseNext(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	if ent.client.chase_target == nil {
+		return
+	}
+
+	i := ent.client.chase_target.index
+	var e *edict_t
+
+	for {
+		i++
+
+		if i > G.maxclients.Int() {
+			i = 1
+		}
+
+		e = &G.g_edicts[i]
+
+		if e.inuse && !e.client.resp.spectator {
+			break
+		}
+
+		if e == ent.client.chase_target {
+			break
+		}
+	}
+
+	ent.client.chase_target = e
+	ent.client.update_chase = true
+}
+
+func (G *qGame) chasePrev(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	if ent.client.chase_target == nil {
+		return
+	}
+
+	i := ent.client.chase_target.index
+	var e *edict_t
+
+	for {
+		i--
+
+		if i < 1 {
+			i = G.maxclients.Int()
+		}
+
+		e = &G.g_edicts[i]
+
+		if e.inuse && !e.client.resp.spectator {
+			break
+		}
+
+		if e == ent.client.chase_target {
+			break
+		}
+	}
+
+	ent.client.chase_target = e
+	ent.client.update_chase = true
+}
+
+func (G *qGame) getChaseTarget(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	for i := 1; i <= G.maxclients.Int(); i++ {
+		other := &G.g_edicts[i]
+
+		if other.inuse && !other.client.resp.spectator {
+			ent.client.chase_target = other
+			ent.client.update_chase = true
+			G.updateChaseCam(ent)
+			return
+		}
+	}
+
+	G.gi.Centerprintf(ent, "No other players to chase.")
+}
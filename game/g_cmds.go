@@ -25,51 +25,771 @@
  */
 package game
 
-import "goquake2/shared"
+import (
+	"fmt"
+	"goquake2/shared"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func (G *qGame) clientTeam(ent *edict_t) string {
+	if ent == nil || ent.client == nil {
+		return ""
+	}
+
+	value := shared.Info_ValueForKey(ent.client.pers.userinfo, "skin")
+
+	p := strings.IndexByte(value, '/')
+	if p < 0 {
+		return value
+	}
+
+	if (G.dmflags.Int() & shared.DF_MODELTEAMS) != 0 {
+		return value[:p]
+	}
+
+	return value[p+1:]
+}
+
+func (G *qGame) onSameTeam(ent1, ent2 *edict_t) bool {
+	if ent1 == nil || ent2 == nil {
+		return false
+	}
+
+	if (G.dmflags.Int() & (shared.DF_MODELTEAMS | shared.DF_SKINTEAMS)) == 0 {
+		return false
+	}
+
+	return G.clientTeam(ent1) == G.clientTeam(ent2)
+}
+
+func (G *qGame) selectNextItem(ent *edict_t, itflags int) {
+	if ent == nil {
+		return
+	}
+
+	cl := ent.client
+
+	if cl.chase_target != nil {
+		G.chaseNext(ent)
+		return
+	}
+
+	/* scan  for the next valid one */
+	for i := 1; i <= shared.MAX_ITEMS; i++ {
+		index := (cl.pers.selected_item + i) % shared.MAX_ITEMS
+
+		if cl.pers.inventory[index] == 0 {
+			continue
+		}
+
+		it := getItemByIndex(index)
+
+		if it == nil || it.use == nil {
+			continue
+		}
+
+		if (it.flags & itflags) == 0 {
+			continue
+		}
+
+		cl.pers.selected_item = index
+		return
+	}
+
+	cl.pers.selected_item = -1
+}
+
+func (G *qGame) selectPrevItem(ent *edict_t, itflags int) {
+	if ent == nil {
+		return
+	}
+
+	cl := ent.client
+
+	if cl.chase_target != nil {
+		G.chasePrev(ent)
+		return
+	}
+
+	/* nothing selected, start from the top */
+	selected := cl.pers.selected_item
+	if selected < 0 {
+		selected = 0
+	}
+
+	/* scan  for the next valid one */
+	for i := 1; i <= shared.MAX_ITEMS; i++ {
+		index := (selected + shared.MAX_ITEMS - i) % shared.MAX_ITEMS
+
+		if cl.pers.inventory[index] == 0 {
+			continue
+		}
+
+		it := getItemByIndex(index)
+
+		if it == nil || it.use == nil {
+			continue
+		}
+
+		if (it.flags & itflags) == 0 {
+			continue
+		}
+
+		cl.pers.selected_item = index
+		return
+	}
+
+	cl.pers.selected_item = -1
+}
+
+func (G *qGame) validateSelectedItem(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	cl := ent.client
+
+	if cl.pers.selected_item >= 0 &&
+		cl.pers.inventory[cl.pers.selected_item] != 0 {
+		return /* valid */
+	}
+
+	G.selectNextItem(ent, -1)
+}
+
+/* ================================================================= */
+
+/*
+ * Give items to a client
+ */
+func (G *qGame) cmd_Give_f(ent *edict_t, args []string) {
+	if ent == nil {
+		return
+	}
+
+	if (G.deathmatch.Bool() || G.coop.Bool()) && !G.sv_cheats.Bool() {
+		G.gi.Cprintf(ent, shared.PRINT_HIGH,
+			"You must run the server with '+set cheats 1' to enable this command.\n")
+		return
+	}
+
+	name := strings.Join(args[1:], " ")
+	arg1 := ""
+	if len(args) > 1 {
+		arg1 = args[1]
+	}
+
+	give_all := strings.EqualFold(name, "all")
+
+	if give_all || strings.EqualFold(arg1, "health") {
+		if len(args) == 3 {
+			v, _ := strconv.Atoi(args[2])
+			ent.Health = v
+		} else {
+			ent.Health = ent.max_health
+		}
+
+		if !give_all {
+			return
+		}
+	}
+
+	if give_all || strings.EqualFold(name, "weapons") {
+		for i := range gameitemlist {
+			it := &gameitemlist[i]
+
+			if it.pickup == nil {
+				continue
+			}
+
+			if (it.flags & IT_WEAPON) == 0 {
+				continue
+			}
+
+			ent.client.pers.inventory[i] += 1
+		}
+
+		if !give_all {
+			return
+		}
+	}
+
+	if give_all || strings.EqualFold(name, "ammo") {
+		for i := range gameitemlist {
+			it := &gameitemlist[i]
+
+			if it.pickup == nil {
+				continue
+			}
+
+			if (it.flags & IT_AMMO) == 0 {
+				continue
+			}
+
+			G.addAmmo(ent, it, 1000)
+		}
+
+		if !give_all {
+			return
+		}
+	}
+
+	if give_all || strings.EqualFold(name, "armor") {
+		it := G.findItem("Jacket Armor")
+		ent.client.pers.inventory[itemIndex(it)] = 0
+
+		it = G.findItem("Combat Armor")
+		ent.client.pers.inventory[itemIndex(it)] = 0
+
+		it = G.findItem("Body Armor")
+		info := it.info.(*gitem_armor_t)
+		ent.client.pers.inventory[itemIndex(it)] = info.max_count
+
+		if !give_all {
+			return
+		}
+	}
+
+	if give_all || strings.EqualFold(name, "Power Shield") {
+		it := G.findItem("Power Shield")
+		it_ent, _ := G.gSpawn()
+		it_ent.Classname = it.classname
+		G.spawnItem(it_ent, it)
+		touch_Item(it_ent, ent, nil, nil, G)
+
+		if it_ent.inuse {
+			G.gFreeEdict(it_ent)
+		}
+
+		if !give_all {
+			return
+		}
+	}
+
+	if give_all {
+		for i := range gameitemlist {
+			it := &gameitemlist[i]
+
+			if it.pickup == nil {
+				continue
+			}
+
+			if (it.flags & (IT_ARMOR | IT_WEAPON | IT_AMMO)) != 0 {
+				continue
+			}
+
+			ent.client.pers.inventory[i] = 1
+		}
+
+		return
+	}
+
+	it := G.findItem(name)
+
+	if it == nil {
+		name = arg1
+		it = G.findItem(name)
+
+		if it == nil {
+			G.gi.Cprintf(ent, shared.PRINT_HIGH, "unknown item\n")
+			return
+		}
+	}
+
+	if it.pickup == nil {
+		G.gi.Cprintf(ent, shared.PRINT_HIGH, "non-pickup item\n")
+		return
+	}
+
+	index := itemIndex(it)
+
+	if (it.flags & IT_AMMO) != 0 {
+		if len(args) == 3 {
+			v, _ := strconv.Atoi(args[2])
+			ent.client.pers.inventory[index] = v
+		} else {
+			ent.client.pers.inventory[index] += it.quantity
+		}
+	} else {
+		it_ent, _ := G.gSpawn()
+		it_ent.Classname = it.classname
+		G.spawnItem(it_ent, it)
+		touch_Item(it_ent, ent, nil, nil, G)
+
+		if it_ent.inuse {
+			G.gFreeEdict(it_ent)
+		}
+	}
+}
+
+/*
+ * Sets client to godmode
+ *
+ * argv(0) god
+ */
+func (G *qGame) cmd_God_f(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	if (G.deathmatch.Bool() || G.coop.Bool()) && !G.sv_cheats.Bool() {
+		G.gi.Cprintf(ent, shared.PRINT_HIGH,
+			"You must run the server with '+set cheats 1' to enable this command.\n")
+		return
+	}
+
+	ent.flags ^= FL_GODMODE
+
+	if (ent.flags & FL_GODMODE) == 0 {
+		G.gi.Cprintf(ent, shared.PRINT_HIGH, "godmode OFF\n")
+	} else {
+		G.gi.Cprintf(ent, shared.PRINT_HIGH, "godmode ON\n")
+	}
+}
+
+/*
+ * Sets client to notarget
+ *
+ * argv(0) notarget
+ */
+func (G *qGame) cmd_Notarget_f(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	if (G.deathmatch.Bool() || G.coop.Bool()) && !G.sv_cheats.Bool() {
+		G.gi.Cprintf(ent, shared.PRINT_HIGH,
+			"You must run the server with '+set cheats 1' to enable this command.\n")
+		return
+	}
+
+	ent.flags ^= FL_NOTARGET
+
+	if (ent.flags & FL_NOTARGET) == 0 {
+		G.gi.Cprintf(ent, shared.PRINT_HIGH, "notarget OFF\n")
+	} else {
+		G.gi.Cprintf(ent, shared.PRINT_HIGH, "notarget ON\n")
+	}
+}
+
+/*
+ * argv(0) noclip
+ */
+func (G *qGame) cmd_Noclip_f(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	if (G.deathmatch.Bool() || G.coop.Bool()) && !G.sv_cheats.Bool() {
+		G.gi.Cprintf(ent, shared.PRINT_HIGH,
+			"You must run the server with '+set cheats 1' to enable this command.\n")
+		return
+	}
+
+	if ent.movetype == MOVETYPE_NOCLIP {
+		ent.movetype = MOVETYPE_WALK
+		G.gi.Cprintf(ent, shared.PRINT_HIGH, "noclip OFF\n")
+	} else {
+		ent.movetype = MOVETYPE_NOCLIP
+		G.gi.Cprintf(ent, shared.PRINT_HIGH, "noclip ON\n")
+	}
+}
 
 /*
  * Use an inventory item
  */
 func (G *qGame) cmd_Use_f(ent *edict_t, args []string) {
-	//  int index;
-	//  gitem_t *it;
-	//  char *s;
-
 	if ent == nil {
 		return
 	}
 
-	s := ""
-	for i := 1; i < len(args); i++ {
-		s += args[i]
-		if i < len(args)-1 {
-			s += " "
-		}
+	s := strings.Join(args[1:], " ")
+	it := G.findItem(s)
+
+	if it == nil {
+		G.gi.Cprintf(ent, shared.PRINT_HIGH, "unknown item: %s\n", s)
+		return
+	}
+
+	if it.use == nil {
+		G.gi.Cprintf(ent, shared.PRINT_HIGH, "Item is not usable.\n")
+		return
 	}
+
+	index := itemIndex(it)
+
+	if ent.client.pers.inventory[index] == 0 {
+		G.gi.Cprintf(ent, shared.PRINT_HIGH, "Out of item: %s\n", s)
+		return
+	}
+
+	it.use(ent, it, G)
+}
+
+/*
+ * Drop an inventory item
+ */
+func (G *qGame) cmd_Drop_f(ent *edict_t, args []string) {
+	if ent == nil {
+		return
+	}
+
+	s := strings.Join(args[1:], " ")
 	it := G.findItem(s)
-	println("USE:", len(args), args[1])
 
 	if it == nil {
-		// G.gi.Cprintf(ent, PRINT_HIGH, "unknown item: %s\n", s)
+		G.gi.Cprintf(ent, shared.PRINT_HIGH, "unknown item: %s\n", s)
+		return
+	}
+
+	if it.drop == nil {
+		G.gi.Cprintf(ent, shared.PRINT_HIGH, "Item is not dropable.\n")
+		return
+	}
+
+	index := itemIndex(it)
+
+	if ent.client.pers.inventory[index] == 0 {
+		G.gi.Cprintf(ent, shared.PRINT_HIGH, "Out of item: %s\n", s)
+		return
+	}
+
+	it.drop(ent, it, G)
+}
+
+func (G *qGame) cmd_Inven_f(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	cl := ent.client
+
+	cl.showscores = false
+	cl.showhelp = false
+
+	if cl.showinventory {
+		cl.showinventory = false
 		return
 	}
 
+	cl.showinventory = true
+
+	G.inventoryMessage(ent)
+	G.gi.Unicast(ent, true)
+}
+
+func (G *qGame) cmd_InvUse_f(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	G.validateSelectedItem(ent)
+
+	if ent.client.pers.selected_item == -1 {
+		G.gi.Cprintf(ent, shared.PRINT_HIGH, "No item to use.\n")
+		return
+	}
+
+	it := getItemByIndex(ent.client.pers.selected_item)
+
 	if it.use == nil {
-		// 	 G.gi.Cprintf(ent, PRINT_HIGH, "Item is not usable.\n");
+		G.gi.Cprintf(ent, shared.PRINT_HIGH, "Item is not usable.\n")
+		return
+	}
+
+	it.use(ent, it, G)
+}
+
+func (G *qGame) cmd_WeapPrev_f(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	cl := ent.client
+
+	if cl.pers.weapon == nil {
+		return
+	}
+
+	selected_weapon := itemIndex(cl.pers.weapon)
+
+	/* scan  for the next valid one */
+	for i := 1; i <= shared.MAX_ITEMS; i++ {
+		index := (selected_weapon + i) % shared.MAX_ITEMS
+
+		if cl.pers.inventory[index] == 0 {
+			continue
+		}
+
+		it := getItemByIndex(index)
+
+		if it == nil || it.use == nil {
+			continue
+		}
+
+		if (it.flags & IT_WEAPON) == 0 {
+			continue
+		}
+
+		it.use(ent, it, G)
+
+		/* prevent scrolling through ALL weapons */
+		if cl.newweapon == it {
+			return /* successful */
+		}
+	}
+}
+
+func (G *qGame) cmd_WeapNext_f(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	cl := ent.client
+
+	if cl.pers.weapon == nil {
+		return
+	}
+
+	selected_weapon := itemIndex(cl.pers.weapon)
+
+	/* scan  for the next valid one */
+	for i := 1; i <= shared.MAX_ITEMS; i++ {
+		index := (selected_weapon + shared.MAX_ITEMS - i) % shared.MAX_ITEMS
+
+		if cl.pers.inventory[index] == 0 {
+			continue
+		}
+
+		it := getItemByIndex(index)
+
+		if it == nil || it.use == nil {
+			continue
+		}
+
+		if (it.flags & IT_WEAPON) == 0 {
+			continue
+		}
+
+		it.use(ent, it, G)
+
+		/* prevent scrolling through ALL weapons */
+		if cl.newweapon == it {
+			return /* successful */
+		}
+	}
+}
+
+func (G *qGame) cmd_WeapLast_f(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	cl := ent.client
+
+	if cl.pers.weapon == nil || cl.pers.lastweapon == nil {
+		return
+	}
+
+	index := itemIndex(cl.pers.lastweapon)
+
+	if cl.pers.inventory[index] == 0 {
 		return
 	}
 
-	//  index = ITEM_INDEX(it);
+	it := getItemByIndex(index)
 
-	//  if (!ent->client->pers.inventory[index])
-	//  {
-	// 	 gi.cprintf(ent, PRINT_HIGH, "Out of item: %s\n", s);
-	// 	 return;
-	//  }
+	if it == nil || it.use == nil {
+		return
+	}
+
+	if (it.flags & IT_WEAPON) == 0 {
+		return
+	}
 
 	it.use(ent, it, G)
 }
 
+func (G *qGame) cmd_InvDrop_f(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	G.validateSelectedItem(ent)
+
+	if ent.client.pers.selected_item == -1 {
+		G.gi.Cprintf(ent, shared.PRINT_HIGH, "No item to drop.\n")
+		return
+	}
+
+	it := getItemByIndex(ent.client.pers.selected_item)
+
+	if it.drop == nil {
+		G.gi.Cprintf(ent, shared.PRINT_HIGH, "Item is not dropable.\n")
+		return
+	}
+
+	it.drop(ent, it, G)
+}
+
+func (G *qGame) cmd_Kill_f(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	if ((G.level.time - ent.client.respawn_time) < 5) ||
+		ent.client.resp.spectator {
+		return
+	}
+
+	ent.flags &^= FL_GODMODE
+	ent.Health = 0
+	G.meansOfDeath = MOD_SUICIDE
+	player_die(ent, ent, ent, 100000, []float32{0, 0, 0}, G)
+}
+
+func (G *qGame) cmd_PutAway_f(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	ent.client.showscores = false
+	ent.client.showhelp = false
+	ent.client.showinventory = false
+}
+
+func (G *qGame) cmd_Players_f(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	var index []int
+	for i := 0; i < G.maxclients.Int(); i++ {
+		if G.game.clients[i].pers.connected {
+			index = append(index, i)
+		}
+	}
+
+	/* sort by frags */
+	sort.SliceStable(index, func(a, b int) bool {
+		return G.game.clients[index[a]].ps.Stats[shared.STAT_FRAGS] <
+			G.game.clients[index[b]].ps.Stats[shared.STAT_FRAGS]
+	})
+
+	/* print information */
+	var large strings.Builder
+	for _, i := range index {
+		small := fmt.Sprintf("%3v %v\n",
+			G.game.clients[i].ps.Stats[shared.STAT_FRAGS],
+			G.game.clients[i].pers.netname)
+
+		if large.Len()+len(small) > 1280-100 {
+			/* can't print all of them in one packet */
+			large.WriteString("...\n")
+			break
+		}
+
+		large.WriteString(small)
+	}
+
+	G.gi.Cprintf(ent, shared.PRINT_HIGH, "%s\n%v players\n", large.String(), len(index))
+}
+
+func (G *qGame) cmd_Say_f(ent *edict_t, team, arg0 bool, args []string) {
+	if ent == nil {
+		return
+	}
+
+	if len(args) < 2 && !arg0 {
+		return
+	}
+
+	if (G.dmflags.Int() & (shared.DF_MODELTEAMS | shared.DF_SKINTEAMS)) == 0 {
+		team = false
+	}
+
+	var text string
+	if team {
+		text = fmt.Sprintf("(%s): ", ent.client.pers.netname)
+	} else {
+		text = fmt.Sprintf("%s: ", ent.client.pers.netname)
+	}
+
+	var p string
+	if arg0 {
+		p = strings.Join(args, " ")
+	} else {
+		p = strings.Join(args[1:], " ")
+
+		if len(p) > 1 && p[0] == '"' && p[len(p)-1] == '"' {
+			p = p[1 : len(p)-1]
+		}
+	}
+
+	text += p
+
+	/* don't let text be too long for malicious reasons */
+	if len(text) > 150 {
+		text = text[:150]
+	}
+
+	text += "\n"
+
+	if G.flood_msgs.Bool() {
+		cl := ent.client
+
+		if G.level.time < cl.flood_locktill {
+			G.gi.Cprintf(ent, shared.PRINT_HIGH, "You can't talk for %d more seconds\n",
+				int(cl.flood_locktill-G.level.time))
+			return
+		}
+
+		i := cl.flood_whenhead - G.flood_msgs.Int() + 1
+
+		if i < 0 {
+			i = len(cl.flood_when) + i
+		}
+
+		if i >= 0 && i < len(cl.flood_when) && cl.flood_when[i] != 0 &&
+			(G.level.time-cl.flood_when[i] < G.flood_persecond.Float()) {
+			cl.flood_locktill = G.level.time + G.flood_waitdelay.Float()
+			G.gi.Cprintf(ent, shared.PRINT_CHAT,
+				"Flooding protection activated for %d seconds.\n",
+				G.flood_waitdelay.Int())
+			return
+		}
+
+		cl.flood_whenhead = (cl.flood_whenhead + 1) % len(cl.flood_when)
+		cl.flood_when[cl.flood_whenhead] = G.level.time
+	}
+
+	if G.dedicated.Bool() {
+		G.gi.Cprintf(nil, shared.PRINT_CHAT, "%s", text)
+	}
+
+	for j := 1; j <= G.game.maxclients; j++ {
+		other := &G.g_edicts[j]
+
+		if !other.inuse {
+			continue
+		}
+
+		if other.client == nil {
+			continue
+		}
+
+		if team {
+			if !G.onSameTeam(ent, other) {
+				continue
+			}
+		}
+
+		G.gi.Cprintf(other, shared.PRINT_CHAT, "%s", text)
+	}
+}
+
 func (G *qGame) ClientCommand(sent shared.Edict_s, args []string) {
 	if sent == nil {
 		return
@@ -80,119 +800,77 @@ func (G *qGame) ClientCommand(sent shared.Edict_s, args []string) {
 		return /* not fully in game yet */
 	}
 
-	// if (Q_stricmp(cmd, "players") == 0)
-	// {
-	// 	Cmd_Players_f(ent);
-	// 	return;
-	// }
-
-	// if (Q_stricmp(cmd, "say") == 0)
-	// {
-	// 	Cmd_Say_f(ent, false, false);
-	// 	return;
-	// }
-
-	// if (Q_stricmp(cmd, "say_team") == 0)
-	// {
-	// 	Cmd_Say_f(ent, true, false);
-	// 	return;
-	// }
-
-	// if (Q_stricmp(cmd, "score") == 0)
-	// {
-	// 	Cmd_Score_f(ent);
-	// 	return;
-	// }
-
-	// if (Q_stricmp(cmd, "help") == 0)
-	// {
-	// 	Cmd_Help_f(ent);
-	// 	return;
-	// }
-
-	// if (level.intermissiontime)
-	// {
-	// 	return;
-	// }
-
-	if args[0] == "use" {
+	cmd := strings.ToLower(args[0])
+
+	if cmd == "players" {
+		G.cmd_Players_f(ent)
+		return
+	}
+
+	if cmd == "say" {
+		G.cmd_Say_f(ent, false, false, args)
+		return
+	}
+
+	if cmd == "say_team" {
+		G.cmd_Say_f(ent, true, false, args)
+		return
+	}
+
+	if cmd == "score" {
+		G.cmd_Score_f(ent)
+		return
+	}
+
+	if cmd == "help" {
+		G.cmd_Help_f(ent)
+		return
+	}
+
+	if G.level.intermissiontime != 0 {
+		return
+	}
+
+	if cmd == "use" {
 		G.cmd_Use_f(ent, args)
-		// }
-		// else if (Q_stricmp(cmd, "drop") == 0)
-		// {
-		// 	Cmd_Drop_f(ent);
-		// }
-		// else if (Q_stricmp(cmd, "give") == 0)
-		// {
-		// 	Cmd_Give_f(ent);
-		// }
-		// else if (Q_stricmp(cmd, "god") == 0)
-		// {
-		// 	Cmd_God_f(ent);
-		// }
-		// else if (Q_stricmp(cmd, "notarget") == 0)
-		// {
-		// 	Cmd_Notarget_f(ent);
-		// }
-		// else if (Q_stricmp(cmd, "noclip") == 0)
-		// {
-		// 	Cmd_Noclip_f(ent);
-		// }
-		// else if (Q_stricmp(cmd, "inven") == 0)
-		// {
-		// 	Cmd_Inven_f(ent);
-		// }
-		// else if (Q_stricmp(cmd, "invnext") == 0)
-		// {
-		// 	SelectNextItem(ent, -1);
-		// }
-		// else if (Q_stricmp(cmd, "invprev") == 0)
-		// {
-		// 	SelectPrevItem(ent, -1);
-		// }
-		// else if (Q_stricmp(cmd, "invnextw") == 0)
-		// {
-		// 	SelectNextItem(ent, IT_WEAPON);
-		// }
-		// else if (Q_stricmp(cmd, "invprevw") == 0)
-		// {
-		// 	SelectPrevItem(ent, IT_WEAPON);
-		// }
-		// else if (Q_stricmp(cmd, "invnextp") == 0)
-		// {
-		// 	SelectNextItem(ent, IT_POWERUP);
-		// }
-		// else if (Q_stricmp(cmd, "invprevp") == 0)
-		// {
-		// 	SelectPrevItem(ent, IT_POWERUP);
-		// }
-		// else if (Q_stricmp(cmd, "invuse") == 0)
-		// {
-		// 	Cmd_InvUse_f(ent);
-		// }
-		// else if (Q_stricmp(cmd, "invdrop") == 0)
-		// {
-		// 	Cmd_InvDrop_f(ent);
-		// }
-		// else if (Q_stricmp(cmd, "weapprev") == 0)
-		// {
-		// 	Cmd_WeapPrev_f(ent);
-		// }
-		// else if (Q_stricmp(cmd, "weapnext") == 0)
-		// {
-		// 	Cmd_WeapNext_f(ent);
-		// }
-		// else if (Q_stricmp(cmd, "weaplast") == 0)
-		// {
-		// 	Cmd_WeapLast_f(ent);
-		// }
-		// else if (Q_stricmp(cmd, "kill") == 0)
-		// {
-		// 	Cmd_Kill_f(ent);
-		// }
-		// else if (Q_stricmp(cmd, "putaway") == 0)
-		// {
-		// 	Cmd_PutAway_f(ent);
+	} else if cmd == "drop" {
+		G.cmd_Drop_f(ent, args)
+	} else if cmd == "give" {
+		G.cmd_Give_f(ent, args)
+	} else if cmd == "god" {
+		G.cmd_God_f(ent)
+	} else if cmd == "notarget" {
+		G.cmd_Notarget_f(ent)
+	} else if cmd == "noclip" {
+		G.cmd_Noclip_f(ent)
+	} else if cmd == "inven" {
+		G.cmd_Inven_f(ent)
+	} else if cmd == "invnext" {
+		G.selectNextItem(ent, -1)
+	} else if cmd == "invprev" {
+		G.selectPrevItem(ent, -1)
+	} else if cmd == "invnextw" {
+		G.selectNextItem(ent, IT_WEAPON)
+	} else if cmd == "invprevw" {
+		G.selectPrevItem(ent, IT_WEAPON)
+	} else if cmd == "invnextp" {
+		G.selectNextItem(ent, IT_POWERUP)
+	} else if cmd == "invprevp" {
+		G.selectPrevItem(ent, IT_POWERUP)
+	} else if cmd == "invuse" {
+		G.cmd_InvUse_f(ent)
+	} else if cmd == "invdrop" {
+		G.cmd_InvDrop_f(ent)
+	} else if cmd == "weapprev" {
+		G.cmd_WeapPrev_f(ent)
+	} else if cmd == "weapnext" {
+		G.cmd_WeapNext_f(ent)
+	} else if cmd == "weaplast" {
+		G.cmd_WeapLast_f(ent)
+	} else if cmd == "kill" {
+		G.cmd_Kill_f(ent)
+	} else if cmd == "putaway" {
+		G.cmd_PutAway_f(ent)
 		// }
 		// else if (Q_stricmp(cmd, "wave") == 0)
 		// {
@@ -214,8 +892,7 @@ func (G *qGame) ClientCommand(sent shared.Edict_s, args []string) {
 		// {
 		// 	Cmd_CycleWeap_f(ent);
 		// }
-		// else /* anything that doesn't match a command will be a chat */
-		// {
-		// 	Cmd_Say_f(ent, false, true);
+	} else { /* anything that doesn't match a command will be a chat */
+		G.cmd_Say_f(ent, false, true, args)
 	}
 }
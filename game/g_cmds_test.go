@@ -0,0 +1,32 @@
+package game
+
+import "testing"
+
+func TestSelectPrevItemAfterEmptySelectNext(t *testing.T) {
+	G := &qGame{}
+	ent := &edict_t{client: &gclient_t{}}
+
+	/* no powerups in the inventory, invnextp clears the selection */
+	G.selectNextItem(ent, IT_POWERUP)
+	if ent.client.pers.selected_item != -1 {
+		t.Fatalf("selected_item = %v, want -1", ent.client.pers.selected_item)
+	}
+
+	/* invprevp must not index the inventory with -1 */
+	G.selectPrevItem(ent, IT_POWERUP)
+	if ent.client.pers.selected_item != -1 {
+		t.Fatalf("selected_item = %v, want -1", ent.client.pers.selected_item)
+	}
+
+	/* with a powerup present both directions find it */
+	quad := G.findItem("Quad Damage")
+	if quad == nil {
+		t.Fatal("Quad Damage not in item list")
+	}
+	ent.client.pers.inventory[itemIndex(quad)] = 1
+
+	G.selectPrevItem(ent, IT_POWERUP)
+	if ent.client.pers.selected_item != itemIndex(quad) {
+		t.Fatalf("selected_item = %v, want %v", ent.client.pers.selected_item, itemIndex(quad))
+	}
+}
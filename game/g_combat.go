@@ -25,7 +25,62 @@
  */
 package game
 
-import "goquake2/shared"
+import (
+	"goquake2/shared"
+	"math"
+)
+
+/*
+ * Returns true if the inflictor can
+ * directly damage the target. Used for
+ * explosions and melee attacks.
+ */
+func (G *qGame) canDamage(targ, inflictor *edict_t) bool {
+	if targ == nil || inflictor == nil {
+		return false
+	}
+
+	dest := make([]float32, 3)
+
+	/* bmodels need special checking because their origin is 0,0,0 */
+	if targ.movetype == MOVETYPE_PUSH {
+		shared.VectorAdd(targ.absmin[:], targ.absmax[:], dest)
+		shared.VectorScale(dest, 0.5, dest)
+		trace := G.gi.Trace(inflictor.s.Origin[:], []float32{0, 0, 0}, []float32{0, 0, 0},
+			dest, inflictor, shared.MASK_SOLID)
+
+		if trace.Fraction == 1.0 {
+			return true
+		}
+
+		if e, ok := trace.Ent.(*edict_t); ok && e == targ {
+			return true
+		}
+
+		return false
+	}
+
+	trace := G.gi.Trace(inflictor.s.Origin[:], []float32{0, 0, 0}, []float32{0, 0, 0},
+		targ.s.Origin[:], inflictor, shared.MASK_SOLID)
+
+	if trace.Fraction == 1.0 {
+		return true
+	}
+
+	for _, ofs := range [][2]float32{{15, 15}, {15, -15}, {-15, 15}, {-15, -15}} {
+		copy(dest, targ.s.Origin[:])
+		dest[0] += ofs[0]
+		dest[1] += ofs[1]
+		trace = G.gi.Trace(inflictor.s.Origin[:], []float32{0, 0, 0}, []float32{0, 0, 0},
+			dest, inflictor, shared.MASK_SOLID)
+
+		if trace.Fraction == 1.0 {
+			return true
+		}
+	}
+
+	return false
+}
 
 func (G *qGame) killed(targ, inflictor, attacker *edict_t, damage int, point []float32) {
 	if targ == nil || inflictor == nil || attacker == nil {
@@ -70,11 +125,149 @@ func (G *qGame) killed(targ, inflictor, attacker *edict_t, damage int, point []f
 }
 
 func (G *qGame) spawnDamage(dtype int, origin, normal []float32) {
-	// gi.WriteByte(svc_temp_entity);
-	// gi.WriteByte(type);
-	// gi.WritePosition(origin);
-	// gi.WriteDir(normal);
-	// gi.multicast(origin, MULTICAST_PVS);
+	G.gi.WriteUByte(shared.SvcTempEntity)
+	G.gi.WriteUByte(dtype)
+	G.gi.WritePosition(origin)
+	G.gi.WriteDir(normal)
+	G.gi.Multicast(origin, shared.MULTICAST_PVS)
+}
+
+func (G *qGame) checkPowerArmor(ent *edict_t, point, normal []float32,
+	damage, dflags int) int {
+
+	if ent == nil {
+		return 0
+	}
+
+	if damage == 0 {
+		return 0
+	}
+
+	client := ent.client
+
+	if (dflags & DAMAGE_NO_ARMOR) != 0 {
+		return 0
+	}
+
+	var power_armor_type, index, power int
+	if client != nil {
+		power_armor_type = G.powerArmorType(ent)
+
+		if power_armor_type != POWER_ARMOR_NONE {
+			index = G.findItemIndex("Cells")
+			power = client.pers.inventory[index]
+		}
+	} else if (ent.svflags & shared.SVF_MONSTER) != 0 {
+		power_armor_type = ent.monsterinfo.power_armor_type
+		power = ent.monsterinfo.power_armor_power
+	} else {
+		return 0
+	}
+
+	if power_armor_type == POWER_ARMOR_NONE {
+		return 0
+	}
+
+	if power == 0 {
+		return 0
+	}
+
+	var damagePerCell, pa_te_type int
+	if power_armor_type == POWER_ARMOR_SCREEN {
+		forward := make([]float32, 3)
+		vec := make([]float32, 3)
+
+		/* only works if damage point is in front */
+		shared.AngleVectors(ent.s.Angles[:], forward, nil, nil)
+		shared.VectorSubtract(point, ent.s.Origin[:], vec)
+		shared.VectorNormalize(vec)
+		dot := shared.DotProduct(vec, forward)
+
+		if dot <= 0.3 {
+			return 0
+		}
+
+		damagePerCell = 1
+		pa_te_type = shared.TE_SCREEN_SPARKS
+		damage = damage / 3
+	} else {
+		damagePerCell = 2
+		pa_te_type = shared.TE_SHIELD_SPARKS
+		damage = (2 * damage) / 3
+	}
+
+	save := power * damagePerCell
+
+	if save == 0 {
+		return 0
+	}
+
+	if save > damage {
+		save = damage
+	}
+
+	G.spawnDamage(pa_te_type, point, normal)
+	ent.powerarmor_time = G.level.time + 0.2
+
+	power_used := save / damagePerCell
+
+	if client != nil {
+		client.pers.inventory[index] -= power_used
+	} else {
+		ent.monsterinfo.power_armor_power -= power_used
+	}
+
+	return save
+}
+
+func (G *qGame) checkArmor(ent *edict_t, point, normal []float32, damage,
+	te_sparks, dflags int) int {
+
+	if ent == nil {
+		return 0
+	}
+
+	if damage == 0 {
+		return 0
+	}
+
+	client := ent.client
+
+	if client == nil {
+		return 0
+	}
+
+	if (dflags & DAMAGE_NO_ARMOR) != 0 {
+		return 0
+	}
+
+	index := G.armorIndex(ent)
+
+	if index == 0 {
+		return 0
+	}
+
+	armor, _ := getItemByIndex(index).info.(*gitem_armor_t)
+
+	var save int
+	if (dflags & DAMAGE_ENERGY) != 0 {
+		save = int(math.Ceil(float64(armor.energy_protection * float32(damage))))
+	} else {
+		save = int(math.Ceil(float64(armor.normal_protection * float32(damage))))
+	}
+
+	if save >= client.pers.inventory[index] {
+		save = client.pers.inventory[index]
+	}
+
+	if save == 0 {
+		return 0
+	}
+
+	client.pers.inventory[index] -= save
+	G.spawnDamage(te_sparks, point, normal)
+
+	return save
 }
 
 func (G *qGame) mReactToDamage(targ, attacker *edict_t) {
@@ -186,22 +379,17 @@ func (G *qGame) tDamage(targ, inflictor, attacker *edict_t,
 	/* friendly fire avoidance if enabled you
 	   can't hurt teammates (but you can hurt
 	   yourself) knockback still occurs */
-	// if ((targ != attacker) && ((deathmatch->value &&
-	// 	  ((int)(dmflags->value) & (DF_MODELTEAMS | DF_SKINTEAMS))) ||
-	// 	 coop->value))
-	// {
-	// 	if (OnSameTeam(targ, attacker))
-	// 	{
-	// 		if ((int)(dmflags->value) & DF_NO_FRIENDLY_FIRE)
-	// 		{
-	// 			damage = 0;
-	// 		}
-	// 		else
-	// 		{
-	// 			mod |= MOD_FRIENDLY_FIRE;
-	// 		}
-	// 	}
-	// }
+	if (targ != attacker) && ((G.deathmatch.Bool() &&
+		(G.dmflags.Int()&(shared.DF_MODELTEAMS|shared.DF_SKINTEAMS)) != 0) ||
+		G.coop.Bool()) {
+		if G.onSameTeam(targ, attacker) {
+			if (G.dmflags.Int() & shared.DF_NO_FRIENDLY_FIRE) != 0 {
+				damage = 0
+			} else {
+				mod |= MOD_FRIENDLY_FIRE
+			}
+		}
+	}
 
 	G.meansOfDeath = mod
 
@@ -238,71 +426,59 @@ func (G *qGame) tDamage(targ, inflictor, attacker *edict_t,
 
 	/* figure momentum add */
 	if (dflags & DAMAGE_NO_KNOCKBACK) == 0 {
-		// 	if ((knockback) && (targ->movetype != MOVETYPE_NONE) &&
-		// 		(targ->movetype != MOVETYPE_BOUNCE) &&
-		// 		(targ->movetype != MOVETYPE_PUSH) &&
-		// 		(targ->movetype != MOVETYPE_STOP))
-		// 	{
-		// 		vec3_t kvel;
-		// 		float mass;
-
-		// 		if (targ->mass < 50)
-		// 		{
-		// 			mass = 50;
-		// 		}
-		// 		else
-		// 		{
-		// 			mass = targ->mass;
-		// 		}
-
-		// 		if (targ->client && (attacker == targ))
-		// 		{
-		// 			/* This allows rocket jumps */
-		// 			VectorScale(dir, 1600.0 * (float)knockback / mass, kvel);
-		// 		}
-		// 		else
-		// 		{
-		// 			VectorScale(dir, 500.0 * (float)knockback / mass, kvel);
-		// 		}
-
-		// 		VectorAdd(targ->velocity, kvel, targ->velocity);
-		// 	}
+		if (knockback != 0) && (targ.movetype != MOVETYPE_NONE) &&
+			(targ.movetype != MOVETYPE_BOUNCE) &&
+			(targ.movetype != MOVETYPE_PUSH) &&
+			(targ.movetype != MOVETYPE_STOP) {
+			kvel := make([]float32, 3)
+
+			mass := float32(50)
+			if targ.Mass >= 50 {
+				mass = float32(targ.Mass)
+			}
+
+			if targ.client != nil && (attacker == targ) {
+				/* This allows rocket jumps */
+				shared.VectorScale(dir, 1600.0*float32(knockback)/mass, kvel)
+			} else {
+				shared.VectorScale(dir, 500.0*float32(knockback)/mass, kvel)
+			}
+
+			shared.VectorAdd(targ.velocity[:], kvel, targ.velocity[:])
+		}
 	}
 
 	take := damage
-	// save := 0
+	save := 0
 
 	/* check for godmode */
-	// if ((targ->flags & FL_GODMODE) && !(dflags & DAMAGE_NO_PROTECTION))
-	// {
-	// 	take = 0;
-	// 	save = damage;
-	// 	SpawnDamage(te_sparks, point, normal);
-	// }
+	if (targ.flags&FL_GODMODE) != 0 && (dflags&DAMAGE_NO_PROTECTION) == 0 {
+		take = 0
+		save = damage
+		G.spawnDamage(te_sparks, point, normal)
+	}
 
 	/* check for invincibility */
-	// if ((client && (client->invincible_framenum > level.framenum)) &&
-	// 	!(dflags & DAMAGE_NO_PROTECTION))
-	// {
-	// 	if (targ->pain_debounce_time < level.time)
-	// 	{
-	// 		gi.sound(targ, CHAN_ITEM, gi.soundindex(
-	// 					"items/protect4.wav"), 1, ATTN_NORM, 0);
-	// 		targ->pain_debounce_time = level.time + 2;
-	// 	}
+	if (client != nil && (client.invincible_framenum > float32(G.level.framenum))) &&
+		(dflags&DAMAGE_NO_PROTECTION) == 0 {
+		if targ.pain_debounce_time < G.level.time {
+			G.gi.Sound(targ, shared.CHAN_ITEM, G.gi.Soundindex(
+				"items/protect4.wav"), 1, shared.ATTN_NORM, 0)
+			targ.pain_debounce_time = G.level.time + 2
+		}
 
-	// 	take = 0;
-	// 	save = damage;
-	// }
+		take = 0
+		save = damage
+	}
 
-	// psave = CheckPowerArmor(targ, point, normal, take, dflags);
-	// take -= psave;
+	psave := G.checkPowerArmor(targ, point, normal, take, dflags)
+	take -= psave
 
-	// asave = CheckArmor(targ, point, normal, take, te_sparks, dflags);
-	// take -= asave;
+	asave := G.checkArmor(targ, point, normal, take, te_sparks, dflags)
+	take -= asave
 
 	/* treat cheat/powerup savings the same as armor */
-	// asave += save
+	asave += save
 
 	/* do the damage */
 	if take != 0 {
@@ -349,10 +525,54 @@ func (G *qGame) tDamage(targ, inflictor, attacker *edict_t,
 	   the total will be turned into screen blends and view
 	   angle kicks at the end of the frame */
 	if client != nil {
-		// 	client->damage_parmor += psave;
-		// 	client->damage_armor += asave;
+		client.damage_parmor += psave
+		client.damage_armor += asave
 		client.damage_blood += take
 		client.damage_knockback += knockback
 		copy(client.damage_from[:], point)
 	}
 }
+
+func (G *qGame) tRadiusDamage(inflictor, attacker *edict_t, damage float32,
+	ignore *edict_t, radius float32, mod int) {
+
+	if inflictor == nil || attacker == nil {
+		return
+	}
+
+	v := make([]float32, 3)
+	dir := make([]float32, 3)
+
+	var ent *edict_t = nil
+	for {
+		ent = G.findradius(ent, inflictor.s.Origin[:], radius)
+		if ent == nil {
+			break
+		}
+
+		if ent == ignore {
+			continue
+		}
+
+		if ent.takedamage == 0 {
+			continue
+		}
+
+		shared.VectorAdd(ent.mins[:], ent.maxs[:], v)
+		shared.VectorMA(ent.s.Origin[:], 0.5, v, v)
+		shared.VectorSubtract(inflictor.s.Origin[:], v, v)
+		points := damage - 0.5*shared.VectorLength(v)
+
+		if ent == attacker {
+			points = points * 0.5
+		}
+
+		if points > 0 {
+			if G.canDamage(ent, inflictor) {
+				shared.VectorSubtract(ent.s.Origin[:], inflictor.s.Origin[:], dir)
+				G.tDamage(ent, inflictor, attacker, dir, inflictor.s.Origin[:],
+					[]float32{0, 0, 0}, int(points), int(points), DAMAGE_RADIUS, mod)
+			}
+		}
+	}
+}
@@ -415,7 +415,9 @@ func (G *qGame) door_use_areaportals(self *edict_t, open bool) {
 			break
 		}
 		if t.Classname == "func_areaportal" {
-			// G.gi.SetAreaPortalState(t.Style, open)
+			if err := G.gi.SetAreaPortalState(t.Style, open); err != nil {
+				G.gi.Dprintf("%s: %v\n", t.Classname, err)
+			}
 		}
 	}
 }
@@ -426,12 +428,12 @@ func door_hit_top(self *edict_t, G *qGame) {
 	}
 
 	if (self.flags & FL_TEAMSLAVE) == 0 {
-		//  if (self->moveinfo.sound_end) {
-		// 	 gi.sound(self, CHAN_NO_PHS_ADD + CHAN_VOICE, self->moveinfo.sound_end,
-		// 			 1, ATTN_STATIC, 0);
-		//  }
+		if self.moveinfo.sound_end != 0 {
+			G.gi.Sound(self, shared.CHAN_NO_PHS_ADD+shared.CHAN_VOICE, self.moveinfo.sound_end,
+				1, shared.ATTN_STATIC, 0)
+		}
 
-		//  self->s.sound = 0;
+		self.s.Sound = 0
 	}
 
 	self.moveinfo.state = STATE_TOP
@@ -452,13 +454,13 @@ func door_hit_bottom(self *edict_t, G *qGame) {
 	}
 
 	if (self.flags & FL_TEAMSLAVE) == 0 {
-		//  if (self->moveinfo.sound_end) {
-		// 	 gi.sound(self, CHAN_NO_PHS_ADD + CHAN_VOICE,
-		// 			 self->moveinfo.sound_end, 1,
-		// 			 ATTN_STATIC, 0);
-		//  }
+		if self.moveinfo.sound_end != 0 {
+			G.gi.Sound(self, shared.CHAN_NO_PHS_ADD+shared.CHAN_VOICE,
+				self.moveinfo.sound_end, 1,
+				shared.ATTN_STATIC, 0)
+		}
 
-		//  self->s.sound = 0;
+		self.s.Sound = 0
 	}
 
 	self.moveinfo.state = STATE_BOTTOM
@@ -471,13 +473,13 @@ func door_go_down(self *edict_t, G *qGame) {
 	}
 
 	if (self.flags & FL_TEAMSLAVE) == 0 {
-		// if (self->moveinfo.sound_start) {
-		// 	gi.sound(self, CHAN_NO_PHS_ADD + CHAN_VOICE,
-		// 			self->moveinfo.sound_start, 1,
-		// 			ATTN_STATIC, 0);
-		// }
+		if self.moveinfo.sound_start != 0 {
+			G.gi.Sound(self, shared.CHAN_NO_PHS_ADD+shared.CHAN_VOICE,
+				self.moveinfo.sound_start, 1,
+				shared.ATTN_STATIC, 0)
+		}
 
-		// self->s.sound = self->moveinfo.sound_middle;
+		self.s.Sound = self.moveinfo.sound_middle
 	}
 
 	if self.max_health != 0 {
@@ -513,13 +515,13 @@ func (G *qGame) door_go_up(self, activator *edict_t) {
 	}
 
 	if (self.flags & FL_TEAMSLAVE) == 0 {
-		// if (self->moveinfo.sound_start) {
-		// 	gi.sound(self, CHAN_NO_PHS_ADD + CHAN_VOICE,
-		// 			self->moveinfo.sound_start, 1,
-		// 			ATTN_STATIC, 0);
-		// }
+		if self.moveinfo.sound_start != 0 {
+			G.gi.Sound(self, shared.CHAN_NO_PHS_ADD+shared.CHAN_VOICE,
+				self.moveinfo.sound_start, 1,
+				shared.ATTN_STATIC, 0)
+		}
 
-		// self->s.sound = self->moveinfo.sound_middle;
+		self.s.Sound = self.moveinfo.sound_middle
 	}
 
 	self.moveinfo.state = STATE_UP
@@ -722,6 +724,317 @@ func think_SpawnDoorTrigger(ent *edict_t, G *qGame) {
  * =========================================================
  */
 
+const PLAT_LOW_TRIGGER = 1
+
+func plat_hit_top(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	if (ent.flags & FL_TEAMSLAVE) == 0 {
+		if ent.moveinfo.sound_end != 0 {
+			G.gi.Sound(ent, shared.CHAN_NO_PHS_ADD+shared.CHAN_VOICE,
+				ent.moveinfo.sound_end, 1, shared.ATTN_STATIC, 0)
+		}
+
+		ent.s.Sound = 0
+	}
+
+	ent.moveinfo.state = STATE_TOP
+
+	ent.think = plat_go_down
+	ent.nextthink = G.level.time + 3
+}
+
+func plat_hit_bottom(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	if (ent.flags & FL_TEAMSLAVE) == 0 {
+		if ent.moveinfo.sound_end != 0 {
+			G.gi.Sound(ent, shared.CHAN_NO_PHS_ADD+shared.CHAN_VOICE,
+				ent.moveinfo.sound_end, 1, shared.ATTN_STATIC, 0)
+		}
+
+		ent.s.Sound = 0
+	}
+
+	ent.moveinfo.state = STATE_BOTTOM
+}
+
+func plat_go_down(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	if (ent.flags & FL_TEAMSLAVE) == 0 {
+		if ent.moveinfo.sound_start != 0 {
+			G.gi.Sound(ent, shared.CHAN_NO_PHS_ADD+shared.CHAN_VOICE,
+				ent.moveinfo.sound_start, 1, shared.ATTN_STATIC, 0)
+		}
+
+		ent.s.Sound = ent.moveinfo.sound_middle
+	}
+
+	ent.moveinfo.state = STATE_DOWN
+	G.move_Calc(ent, ent.moveinfo.end_origin[:], plat_hit_bottom)
+}
+
+func plat_go_up(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	if (ent.flags & FL_TEAMSLAVE) == 0 {
+		if ent.moveinfo.sound_start != 0 {
+			G.gi.Sound(ent, shared.CHAN_NO_PHS_ADD+shared.CHAN_VOICE,
+				ent.moveinfo.sound_start, 1, shared.ATTN_STATIC, 0)
+		}
+
+		ent.s.Sound = ent.moveinfo.sound_middle
+	}
+
+	ent.moveinfo.state = STATE_UP
+	G.move_Calc(ent, ent.moveinfo.start_origin[:], plat_hit_top)
+}
+
+func plat_blocked(self, other *edict_t, G *qGame) {
+	if self == nil || other == nil || G == nil {
+		return
+	}
+
+	if (other.svflags&shared.SVF_MONSTER) == 0 && (other.client == nil) {
+		/* give it a chance to go away on it's own terms (like gibs) */
+		G.tDamage(other, self, self, []float32{0, 0, 0}, other.s.Origin[:],
+			[]float32{0, 0, 0}, 100000, 1, 0, MOD_CRUSH)
+
+		/* if it's still there, nuke it */
+		if other.inuse {
+			G.becomeExplosion1(other)
+		}
+
+		return
+	}
+
+	G.tDamage(other, self, self, []float32{0, 0, 0}, other.s.Origin[:],
+		[]float32{0, 0, 0}, self.Dmg, 1, 0, MOD_CRUSH)
+
+	if self.moveinfo.state == STATE_UP {
+		plat_go_down(self, G)
+	} else if self.moveinfo.state == STATE_DOWN {
+		plat_go_up(self, G)
+	}
+}
+
+func use_Plat(ent, other, activator *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	if ent.think != nil {
+		return /* already down */
+	}
+
+	plat_go_down(ent, G)
+}
+
+func wait_and_change_think(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	afterwaitfunc := ent.moveinfo.endfunc
+	ent.moveinfo.endfunc = nil
+	afterwaitfunc(ent, G)
+}
+
+/*
+ * In coop mode, this waits for coop_elevator_delay seconds
+ * before calling afterwaitfunc(ent); otherwise it just calls
+ * afterwaitfunc(ent);
+ */
+func (G *qGame) wait_and_change(ent *edict_t, afterwaitfunc func(*edict_t, *qGame)) {
+	waittime := G.coop_elevator_delay.Float()
+
+	if G.coop.Bool() && waittime > 0 {
+		if ent.nextthink == 0 {
+			ent.moveinfo.endfunc = afterwaitfunc
+			ent.think = wait_and_change_think
+			ent.nextthink = G.level.time + waittime
+		}
+	} else {
+		afterwaitfunc(ent, G)
+	}
+}
+
+func touch_Plat_Center(ent, other *edict_t, plane *shared.Cplane_t, surf *shared.Csurface_t, G *qGame) {
+	if ent == nil || other == nil || G == nil {
+		return
+	}
+
+	if other.client == nil {
+		return
+	}
+
+	if other.Health <= 0 {
+		return
+	}
+
+	ent = ent.enemy /* now point at the plat, not the trigger */
+
+	if ent.moveinfo.state == STATE_BOTTOM {
+		G.wait_and_change(ent, plat_go_up)
+	} else if ent.moveinfo.state == STATE_TOP {
+		ent.nextthink = G.level.time + 1 /* the player is still on the plat, so delay going down */
+	}
+}
+
+func (G *qGame) plat_spawn_inside_trigger(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	/* middle trigger */
+	trigger, _ := G.gSpawn()
+	trigger.touch = touch_Plat_Center
+	trigger.movetype = MOVETYPE_NONE
+	trigger.solid = shared.SOLID_TRIGGER
+	trigger.enemy = ent
+
+	tmin := make([]float32, 3)
+	tmax := make([]float32, 3)
+	tmin[0] = ent.mins[0] + 25
+	tmin[1] = ent.mins[1] + 25
+	tmin[2] = ent.mins[2]
+
+	tmax[0] = ent.maxs[0] - 25
+	tmax[1] = ent.maxs[1] - 25
+	tmax[2] = ent.maxs[2] + 8
+
+	tmin[2] = tmax[2] - (ent.pos1[2] - ent.pos2[2] + float32(G.st.Lip))
+
+	if (ent.Spawnflags & PLAT_LOW_TRIGGER) != 0 {
+		tmax[2] = tmin[2] + 8
+	}
+
+	if tmax[0]-tmin[0] <= 0 {
+		tmin[0] = (ent.mins[0] + ent.maxs[0]) * 0.5
+		tmax[0] = tmin[0] + 1
+	}
+
+	if tmax[1]-tmin[1] <= 0 {
+		tmin[1] = (ent.mins[1] + ent.maxs[1]) * 0.5
+		tmax[1] = tmin[1] + 1
+	}
+
+	copy(trigger.mins[:], tmin)
+	copy(trigger.maxs[:], tmax)
+
+	G.gi.Linkentity(trigger)
+}
+
+/*
+ * QUAKED func_plat (0 .5 .8) ? PLAT_LOW_TRIGGER
+ * speed	default 150
+ *
+ * Plats are always drawn in the extended position,
+ * so they will light correctly.
+ *
+ * If the plat is the target of another trigger or button,
+ * it will start out disabled in the extended position until
+ * it is trigger, when it will lower and become a normal plat.
+ *
+ * "speed"	overrides default 200.
+ * "accel" overrides default 500
+ * "lip"	overrides default 8 pixel lip
+ *
+ * If the "height" key is set, that will determine the amount
+ * the plat moves, instead of being implicitly determoveinfoned
+ * by the model's height.
+ *
+ * Set "sounds" to one of the following:
+ * 1) base fast
+ * 2) chain slow
+ */
+func spFuncPlat(ent *edict_t, G *qGame) error {
+	if ent == nil || G == nil {
+		return nil
+	}
+
+	copy(ent.s.Angles[:], []float32{0, 0, 0})
+	ent.solid = shared.SOLID_BSP
+	ent.movetype = MOVETYPE_PUSH
+
+	G.gi.Setmodel(ent, ent.Model)
+
+	ent.blocked = plat_blocked
+
+	if ent.Speed == 0 {
+		ent.Speed = 20
+	} else {
+		ent.Speed *= 0.1
+	}
+
+	if ent.Accel == 0 {
+		ent.Accel = 5
+	} else {
+		ent.Accel *= 0.1
+	}
+
+	if ent.Decel == 0 {
+		ent.Decel = 5
+	} else {
+		ent.Decel *= 0.1
+	}
+
+	if ent.Dmg == 0 {
+		ent.Dmg = 2
+	}
+
+	if G.st.Lip == 0 {
+		G.st.Lip = 8
+	}
+
+	/* pos1 is the top position, pos2 is the bottom */
+	copy(ent.pos1[:], ent.s.Origin[:])
+	copy(ent.pos2[:], ent.s.Origin[:])
+
+	if G.st.Height != 0 {
+		ent.pos2[2] -= float32(G.st.Height)
+	} else {
+		ent.pos2[2] -= (ent.maxs[2] - ent.mins[2]) - float32(G.st.Lip)
+	}
+
+	ent.use = use_Plat
+
+	G.plat_spawn_inside_trigger(ent) /* the "start moving" trigger */
+
+	if len(ent.Targetname) > 0 {
+		ent.moveinfo.state = STATE_UP
+	} else {
+		copy(ent.s.Origin[:], ent.pos2[:])
+		G.gi.Linkentity(ent)
+		ent.moveinfo.state = STATE_BOTTOM
+	}
+
+	ent.moveinfo.speed = ent.Speed
+	ent.moveinfo.accel = ent.Accel
+	ent.moveinfo.decel = ent.Decel
+	ent.moveinfo.wait = ent.Wait
+	copy(ent.moveinfo.start_origin[:], ent.pos1[:])
+	copy(ent.moveinfo.start_angles[:], ent.s.Angles[:])
+	copy(ent.moveinfo.end_origin[:], ent.pos2[:])
+	copy(ent.moveinfo.end_angles[:], ent.s.Angles[:])
+
+	ent.moveinfo.sound_start = G.gi.Soundindex("plats/pt1_strt.wav")
+	ent.moveinfo.sound_middle = G.gi.Soundindex("plats/pt1_mid.wav")
+	ent.moveinfo.sound_end = G.gi.Soundindex("plats/pt1_end.wav")
+	return nil
+}
+
+/* ==================================================================== */
+
 func spFuncDoor(ent *edict_t, G *qGame) error {
 
 	if ent == nil || G == nil {
@@ -729,12 +1042,11 @@ func spFuncDoor(ent *edict_t, G *qGame) error {
 	}
 	println("spFuncDoor")
 
-	// if (ent.sounds != 1)
-	// {
-	// 	ent->moveinfo.sound_start = gi.soundindex("doors/dr1_strt.wav");
-	// 	ent->moveinfo.sound_middle = gi.soundindex("doors/dr1_mid.wav");
-	// 	ent->moveinfo.sound_end = gi.soundindex("doors/dr1_end.wav");
-	// }
+	if ent.Sounds != 1 {
+		ent.moveinfo.sound_start = G.gi.Soundindex("doors/dr1_strt.wav")
+		ent.moveinfo.sound_middle = G.gi.Soundindex("doors/dr1_mid.wav")
+		ent.moveinfo.sound_end = G.gi.Soundindex("doors/dr1_end.wav")
+	}
 
 	gSetMovedir(ent.s.Angles[:], ent.movedir[:])
 	ent.movetype = MOVETYPE_PUSH
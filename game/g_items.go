@@ -25,7 +25,10 @@
  */
 package game
 
-import "goquake2/shared"
+import (
+	"goquake2/shared"
+	"strings"
+)
 
 const HEALTH_IGNORE_MAX = 1
 const HEALTH_TIMED = 2
@@ -37,7 +40,7 @@ var bodyarmor_info = gitem_armor_t{100, 200, .80, .60, ARMOR_BODY}
 /* ====================================================================== */
 
 func getItemByIndex(index int) *gitem_t {
-	if (index == 0) || (index >= len(gameitemlist)) {
+	if (index <= 0) || (index >= len(gameitemlist)) {
 		return nil
 	}
 
@@ -63,7 +66,26 @@ func (G *qGame) findItem(pickup_name string) *gitem_t {
 			continue
 		}
 
-		if it.pickup_name == pickup_name {
+		if strings.EqualFold(it.pickup_name, pickup_name) {
+			return &gameitemlist[i]
+		}
+	}
+
+	return nil
+}
+
+func findItemByClassname(classname string) *gitem_t {
+
+	if len(classname) == 0 {
+		return nil
+	}
+
+	for i, it := range gameitemlist {
+		if len(it.classname) == 0 {
+			continue
+		}
+
+		if it.classname == classname {
 			return &gameitemlist[i]
 		}
 	}
@@ -82,7 +104,7 @@ func (G *qGame) findItemIndex(pickup_name string) int {
 			continue
 		}
 
-		if it.pickup_name == pickup_name {
+		if strings.EqualFold(it.pickup_name, pickup_name) {
 			return i
 		}
 	}
@@ -108,6 +130,469 @@ func (G *qGame) setItemNames() {
 
 /* ====================================================================== */
 
+func doRespawn(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	if len(ent.Team) > 0 {
+		master := ent.teammaster
+
+		count := 0
+		for ent = master; ent != nil; ent = ent.chain {
+			count++
+		}
+
+		choice := 0
+		if count > 0 {
+			choice = shared.Randk() % count
+		}
+
+		ent = master
+		for count = 0; count < choice; count++ {
+			ent = ent.chain
+		}
+	}
+
+	ent.svflags &^= shared.SVF_NOCLIENT
+	ent.solid = shared.SOLID_TRIGGER
+	G.gi.Linkentity(ent)
+
+	/* send an effect */
+	ent.s.Event = shared.EV_ITEM_RESPAWN
+}
+
+func (G *qGame) setRespawn(ent *edict_t, delay float32) {
+	if ent == nil {
+		return
+	}
+
+	ent.flags |= FL_RESPAWN
+	ent.svflags |= shared.SVF_NOCLIENT
+	ent.solid = shared.SOLID_NOT
+	ent.nextthink = G.level.time + delay
+	ent.think = doRespawn
+	G.gi.Linkentity(ent)
+}
+
+/* ====================================================================== */
+
+func Pickup_Powerup(ent, other *edict_t, G *qGame) bool {
+	if ent == nil || other == nil || G == nil {
+		return false
+	}
+
+	quantity := other.client.pers.inventory[itemIndex(ent.item)]
+
+	if ((G.skill.Int() == SKILL_MEDIUM) && (quantity >= 2)) ||
+		((G.skill.Int() >= SKILL_HARD) && (quantity >= 1)) {
+		return false
+	}
+
+	if G.coop.Bool() && (ent.item.flags&IT_STAY_COOP) != 0 && (quantity > 0) {
+		return false
+	}
+
+	other.client.pers.inventory[itemIndex(ent.item)]++
+
+	if G.deathmatch.Bool() {
+		if (ent.Spawnflags & DROPPED_ITEM) == 0 {
+			G.setRespawn(ent, float32(ent.item.quantity))
+		}
+	}
+
+	return true
+}
+
+func drop_General(ent *edict_t, item *gitem_t, G *qGame) {
+	if ent == nil || item == nil || G == nil {
+		return
+	}
+
+	G.dropItem(ent, item)
+	ent.client.pers.inventory[itemIndex(item)]--
+	G.validateSelectedItem(ent)
+}
+
+/* ====================================================================== */
+
+func Pickup_Adrenaline(ent, other *edict_t, G *qGame) bool {
+	if ent == nil || other == nil || G == nil {
+		return false
+	}
+
+	if !G.deathmatch.Bool() {
+		other.max_health += 1
+	}
+
+	if other.Health < other.max_health {
+		other.Health = other.max_health
+	}
+
+	if (ent.Spawnflags&DROPPED_ITEM) == 0 && G.deathmatch.Bool() {
+		G.setRespawn(ent, float32(ent.item.quantity))
+	}
+
+	return true
+}
+
+func Pickup_AncientHead(ent, other *edict_t, G *qGame) bool {
+	if ent == nil || other == nil || G == nil {
+		return false
+	}
+
+	other.max_health += 2
+
+	if (ent.Spawnflags&DROPPED_ITEM) == 0 && G.deathmatch.Bool() {
+		G.setRespawn(ent, float32(ent.item.quantity))
+	}
+
+	return true
+}
+
+/*
+ * Adds the quantity of the named ammo
+ * item, clamped to the given maximum
+ */
+func (G *qGame) addPackAmmo(other *edict_t, pickup_name string, max int) {
+	item := G.findItem(pickup_name)
+
+	if item != nil {
+		index := itemIndex(item)
+		other.client.pers.inventory[index] += item.quantity
+
+		if other.client.pers.inventory[index] > max {
+			other.client.pers.inventory[index] = max
+		}
+	}
+}
+
+func Pickup_Bandolier(ent, other *edict_t, G *qGame) bool {
+	if ent == nil || other == nil || G == nil {
+		return false
+	}
+
+	if other.client.pers.max_bullets < 250 {
+		other.client.pers.max_bullets = 250
+	}
+
+	if other.client.pers.max_shells < 150 {
+		other.client.pers.max_shells = 150
+	}
+
+	if other.client.pers.max_cells < 250 {
+		other.client.pers.max_cells = 250
+	}
+
+	if other.client.pers.max_slugs < 75 {
+		other.client.pers.max_slugs = 75
+	}
+
+	G.addPackAmmo(other, "Bullets", other.client.pers.max_bullets)
+	G.addPackAmmo(other, "Shells", other.client.pers.max_shells)
+
+	if (ent.Spawnflags&DROPPED_ITEM) == 0 && G.deathmatch.Bool() {
+		G.setRespawn(ent, float32(ent.item.quantity))
+	}
+
+	return true
+}
+
+func Pickup_Pack(ent, other *edict_t, G *qGame) bool {
+	if ent == nil || other == nil || G == nil {
+		return false
+	}
+
+	if other.client.pers.max_bullets < 300 {
+		other.client.pers.max_bullets = 300
+	}
+
+	if other.client.pers.max_shells < 200 {
+		other.client.pers.max_shells = 200
+	}
+
+	if other.client.pers.max_rockets < 100 {
+		other.client.pers.max_rockets = 100
+	}
+
+	if other.client.pers.max_grenades < 100 {
+		other.client.pers.max_grenades = 100
+	}
+
+	if other.client.pers.max_cells < 300 {
+		other.client.pers.max_cells = 300
+	}
+
+	if other.client.pers.max_slugs < 100 {
+		other.client.pers.max_slugs = 100
+	}
+
+	G.addPackAmmo(other, "Bullets", other.client.pers.max_bullets)
+	G.addPackAmmo(other, "Shells", other.client.pers.max_shells)
+	G.addPackAmmo(other, "Cells", other.client.pers.max_cells)
+	G.addPackAmmo(other, "Grenades", other.client.pers.max_grenades)
+	G.addPackAmmo(other, "Rockets", other.client.pers.max_rockets)
+	G.addPackAmmo(other, "Slugs", other.client.pers.max_slugs)
+
+	if (ent.Spawnflags&DROPPED_ITEM) == 0 && G.deathmatch.Bool() {
+		G.setRespawn(ent, float32(ent.item.quantity))
+	}
+
+	return true
+}
+
+/* ====================================================================== */
+
+func use_Quad(ent *edict_t, item *gitem_t, G *qGame) {
+	if ent == nil || item == nil || G == nil {
+		return
+	}
+
+	ent.client.pers.inventory[itemIndex(item)]--
+	G.validateSelectedItem(ent)
+
+	timeout := 300
+	if G.quad_drop_timeout_hack != 0 {
+		timeout = G.quad_drop_timeout_hack
+		G.quad_drop_timeout_hack = 0
+	}
+
+	if ent.client.quad_framenum > float32(G.level.framenum) {
+		ent.client.quad_framenum += float32(timeout)
+	} else {
+		ent.client.quad_framenum = float32(G.level.framenum + timeout)
+	}
+
+	G.gi.Sound(ent, shared.CHAN_ITEM, G.gi.Soundindex(
+		"items/damage.wav"), 1, shared.ATTN_NORM, 0)
+}
+
+/* ====================================================================== */
+
+func use_Breather(ent *edict_t, item *gitem_t, G *qGame) {
+	if ent == nil || item == nil || G == nil {
+		return
+	}
+
+	ent.client.pers.inventory[itemIndex(item)]--
+	G.validateSelectedItem(ent)
+
+	if ent.client.breather_framenum > float32(G.level.framenum) {
+		ent.client.breather_framenum += 300
+	} else {
+		ent.client.breather_framenum = float32(G.level.framenum + 300)
+	}
+}
+
+/* ====================================================================== */
+
+func use_Envirosuit(ent *edict_t, item *gitem_t, G *qGame) {
+	if ent == nil || item == nil || G == nil {
+		return
+	}
+
+	ent.client.pers.inventory[itemIndex(item)]--
+	G.validateSelectedItem(ent)
+
+	if ent.client.enviro_framenum > float32(G.level.framenum) {
+		ent.client.enviro_framenum += 300
+	} else {
+		ent.client.enviro_framenum = float32(G.level.framenum + 300)
+	}
+}
+
+/* ====================================================================== */
+
+func use_Invulnerability(ent *edict_t, item *gitem_t, G *qGame) {
+	if ent == nil || item == nil || G == nil {
+		return
+	}
+
+	ent.client.pers.inventory[itemIndex(item)]--
+	G.validateSelectedItem(ent)
+
+	if ent.client.invincible_framenum > float32(G.level.framenum) {
+		ent.client.invincible_framenum += 300
+	} else {
+		ent.client.invincible_framenum = float32(G.level.framenum + 300)
+	}
+
+	G.gi.Sound(ent, shared.CHAN_ITEM, G.gi.Soundindex(
+		"items/protect.wav"), 1, shared.ATTN_NORM, 0)
+}
+
+/* ====================================================================== */
+
+func use_Silencer(ent *edict_t, item *gitem_t, G *qGame) {
+	if ent == nil || item == nil || G == nil {
+		return
+	}
+
+	ent.client.pers.inventory[itemIndex(item)]--
+	G.validateSelectedItem(ent)
+	ent.client.silencer_shots += 30
+}
+
+/* ====================================================================== */
+
+func Pickup_Key(ent, other *edict_t, G *qGame) bool {
+	if ent == nil || other == nil || G == nil {
+		return false
+	}
+
+	if G.coop.Bool() {
+		if ent.Classname == "key_power_cube" {
+			if (other.client.pers.power_cubes &
+				((ent.Spawnflags & 0x0000ff00) >> 8)) != 0 {
+				return false
+			}
+
+			other.client.pers.inventory[itemIndex(ent.item)]++
+			other.client.pers.power_cubes |=
+				((ent.Spawnflags & 0x0000ff00) >> 8)
+		} else {
+			if other.client.pers.inventory[itemIndex(ent.item)] != 0 {
+				return false
+			}
+
+			other.client.pers.inventory[itemIndex(ent.item)] = 1
+		}
+
+		return true
+	}
+
+	other.client.pers.inventory[itemIndex(ent.item)]++
+	return true
+}
+
+/* ====================================================================== */
+
+func (G *qGame) addAmmo(ent *edict_t, item *gitem_t, count int) bool {
+	if ent == nil || item == nil {
+		return false
+	}
+
+	if ent.client == nil {
+		return false
+	}
+
+	var max int
+	switch item.tag {
+	case AMMO_BULLETS:
+		max = ent.client.pers.max_bullets
+	case AMMO_SHELLS:
+		max = ent.client.pers.max_shells
+	case AMMO_ROCKETS:
+		max = ent.client.pers.max_rockets
+	case AMMO_GRENADES:
+		max = ent.client.pers.max_grenades
+	case AMMO_CELLS:
+		max = ent.client.pers.max_cells
+	case AMMO_SLUGS:
+		max = ent.client.pers.max_slugs
+	default:
+		return false
+	}
+
+	index := itemIndex(item)
+
+	if ent.client.pers.inventory[index] == max {
+		return false
+	}
+
+	ent.client.pers.inventory[index] += count
+
+	if ent.client.pers.inventory[index] > max {
+		ent.client.pers.inventory[index] = max
+	}
+
+	return true
+}
+
+func Pickup_Ammo(ent, other *edict_t, G *qGame) bool {
+	if ent == nil || other == nil || G == nil {
+		return false
+	}
+
+	weapon := (ent.item.flags & IT_WEAPON) != 0
+
+	var count int
+	if weapon && (G.dmflags.Int()&shared.DF_INFINITE_AMMO) != 0 {
+		count = 1000
+	} else if ent.count != 0 {
+		count = ent.count
+	} else {
+		count = ent.item.quantity
+	}
+
+	oldcount := other.client.pers.inventory[itemIndex(ent.item)]
+
+	if !G.addAmmo(other, ent.item, count) {
+		return false
+	}
+
+	if weapon && oldcount == 0 {
+		if (other.client.pers.weapon != ent.item) &&
+			(!G.deathmatch.Bool() || (other.client.pers.weapon == G.findItem("blaster"))) {
+			other.client.newweapon = ent.item
+		}
+	}
+
+	if (ent.Spawnflags&(DROPPED_ITEM|DROPPED_PLAYER_ITEM)) == 0 &&
+		G.deathmatch.Bool() {
+		G.setRespawn(ent, 30)
+	}
+
+	return true
+}
+
+func drop_Ammo(ent *edict_t, item *gitem_t, G *qGame) {
+	if ent == nil || item == nil || G == nil {
+		return
+	}
+
+	index := itemIndex(item)
+	dropped := G.dropItem(ent, item)
+
+	if ent.client.pers.inventory[index] >= item.quantity {
+		dropped.count = item.quantity
+	} else {
+		dropped.count = ent.client.pers.inventory[index]
+	}
+
+	if ent.client.pers.weapon != nil &&
+		(ent.client.pers.weapon.tag == AMMO_GRENADES) &&
+		(item.tag == AMMO_GRENADES) &&
+		(ent.client.pers.inventory[index]-dropped.count <= 0) {
+		G.gi.Cprintf(ent, shared.PRINT_HIGH, "Can't drop current weapon\n")
+		G.gFreeEdict(dropped)
+		return
+	}
+
+	ent.client.pers.inventory[index] -= dropped.count
+	G.validateSelectedItem(ent)
+}
+
+/* ====================================================================== */
+
+func megaHealth_think(self *edict_t, G *qGame) {
+	if self == nil || G == nil {
+		return
+	}
+
+	if self.owner.Health > self.owner.max_health {
+		self.nextthink = G.level.time + 1
+		self.owner.Health -= 1
+		return
+	}
+
+	if (self.Spawnflags&DROPPED_ITEM) == 0 && G.deathmatch.Bool() {
+		G.setRespawn(self, 20)
+	} else {
+		G.gFreeEdict(self)
+	}
+}
+
 func touch_Item(ent, other *edict_t, plane /* unused */ *shared.Cplane_t, surf /* unused */ *shared.Csurface_t, G *qGame) {
 
 	if ent == nil || other == nil || G == nil {
@@ -143,55 +628,43 @@ func touch_Item(ent, other *edict_t, plane /* unused */ *shared.Cplane_t, surf /
 			other.client.pers.selected_item = int(other.client.ps.Stats[shared.STAT_SELECTED_ITEM])
 		}
 
-		// if (ent->item->pickup == Pickup_Health) {
-		// 	if (ent->count == 2) {
-		// 		gi.sound(other, CHAN_ITEM, gi.soundindex(
-		// 						"items/s_health.wav"), 1, ATTN_NORM, 0);
-		// 	}
-		// 	else if (ent->count == 10)
-		// 	{
-		// 		gi.sound(other, CHAN_ITEM, gi.soundindex(
-		// 						"items/n_health.wav"), 1, ATTN_NORM, 0);
-		// 	}
-		// 	else if (ent->count == 25)
-		// 	{
-		// 		gi.sound(other, CHAN_ITEM, gi.soundindex(
-		// 						"items/l_health.wav"), 1, ATTN_NORM, 0);
-		// 	}
-		// 	else /* (ent->count == 100) */
-		// 	{
-		// 		gi.sound(other, CHAN_ITEM, gi.soundindex(
-		// 						"items/m_health.wav"), 1, ATTN_NORM, 0);
-		// 	}
-		// }
-		// else if (ent->item->pickup_sound)
-		// {
-		// 	gi.sound(other, CHAN_ITEM, gi.soundindex(
-		// 					ent->item->pickup_sound), 1, ATTN_NORM, 0);
-		// }
+		if sameFunc(ent.item.pickup, Pickup_Health) {
+			if ent.count == 2 {
+				G.gi.Sound(other, shared.CHAN_ITEM, G.gi.Soundindex(
+					"items/s_health.wav"), 1, shared.ATTN_NORM, 0)
+			} else if ent.count == 10 {
+				G.gi.Sound(other, shared.CHAN_ITEM, G.gi.Soundindex(
+					"items/n_health.wav"), 1, shared.ATTN_NORM, 0)
+			} else if ent.count == 25 {
+				G.gi.Sound(other, shared.CHAN_ITEM, G.gi.Soundindex(
+					"items/l_health.wav"), 1, shared.ATTN_NORM, 0)
+			} else { /* (ent->count == 100) */
+				G.gi.Sound(other, shared.CHAN_ITEM, G.gi.Soundindex(
+					"items/m_health.wav"), 1, shared.ATTN_NORM, 0)
+			}
+		} else if len(ent.item.pickup_sound) > 0 {
+			G.gi.Sound(other, shared.CHAN_ITEM, G.gi.Soundindex(
+				ent.item.pickup_sound), 1, shared.ATTN_NORM, 0)
+		}
 
 		/* activate item instantly if appropriate */
 		/* moved down here so activation sounds override the pickup sound */
-		// if (deathmatch->value)
-		// {
-		// 	if ((((int)dmflags->value & DF_INSTANT_ITEMS) &&
-		// 		 (ent->item->flags & IT_INSTANT_USE)) ||
-		// 		((ent->item->use == Use_Quad) &&
-		// 		 (ent->spawnflags & DROPPED_PLAYER_ITEM)))
-		// 	{
-		// 		if ((ent->item->use == Use_Quad) &&
-		// 			(ent->spawnflags & DROPPED_PLAYER_ITEM))
-		// 		{
-		// 			quad_drop_timeout_hack =
-		// 				(ent->nextthink - level.time) / FRAMETIME;
-		// 		}
-
-		// 		if (ent->item->use)
-		// 		{
-		// 			ent->item->use(other, ent->item);
-		// 		}
-		// 	}
-		// }
+		if G.deathmatch.Bool() {
+			isQuad := sameFunc(ent.item.use, use_Quad)
+
+			if ((G.dmflags.Int()&shared.DF_INSTANT_ITEMS) != 0 &&
+				(ent.item.flags&IT_INSTANT_USE) != 0) ||
+				(isQuad && (ent.Spawnflags&DROPPED_PLAYER_ITEM) != 0) {
+				if isQuad && (ent.Spawnflags&DROPPED_PLAYER_ITEM) != 0 {
+					G.quad_drop_timeout_hack =
+						int((ent.nextthink - G.level.time) / FRAMETIME)
+				}
+
+				if ent.item.use != nil {
+					ent.item.use(other, ent.item, G)
+				}
+			}
+		}
 	}
 
 	if (ent.Spawnflags & ITEM_TARGETS_USED) == 0 {
@@ -214,6 +687,78 @@ func touch_Item(ent, other *edict_t, plane /* unused */ *shared.Cplane_t, surf /
 	}
 }
 
+/* ====================================================================== */
+
+func drop_temp_touch(ent, other *edict_t, plane *shared.Cplane_t, surf *shared.Csurface_t, G *qGame) {
+	if ent == nil || other == nil || G == nil {
+		return
+	}
+
+	if other == ent.owner {
+		return
+	}
+
+	touch_Item(ent, other, plane, surf, G)
+}
+
+func drop_make_touchable(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	ent.touch = touch_Item
+
+	if G.deathmatch.Bool() {
+		ent.nextthink = G.level.time + 29
+		ent.think = gFreeEdictFunc
+	}
+}
+
+func (G *qGame) dropItem(ent *edict_t, item *gitem_t) *edict_t {
+	if ent == nil || item == nil {
+		return nil
+	}
+
+	dropped, _ := G.gSpawn()
+
+	dropped.Classname = item.classname
+	dropped.item = item
+	dropped.Spawnflags = DROPPED_ITEM
+	dropped.s.Effects = uint(item.world_model_flags)
+	dropped.s.Renderfx = shared.RF_GLOW
+	copy(dropped.mins[:], []float32{-15, -15, -15})
+	copy(dropped.maxs[:], []float32{15, 15, 15})
+	G.gi.Setmodel(dropped, dropped.item.world_model)
+	dropped.solid = shared.SOLID_TRIGGER
+	dropped.movetype = MOVETYPE_TOSS
+	dropped.touch = drop_temp_touch
+	dropped.owner = ent
+
+	forward := make([]float32, 3)
+	right := make([]float32, 3)
+
+	if ent.client != nil {
+		shared.AngleVectors(ent.client.v_angle[:], forward, right, nil)
+		offset := []float32{24, 0, -16}
+		gProjectSource(ent.s.Origin[:], offset, forward, right, dropped.s.Origin[:])
+		trace := G.gi.Trace(ent.s.Origin[:], dropped.mins[:], dropped.maxs[:],
+			dropped.s.Origin[:], ent, shared.CONTENTS_SOLID)
+		copy(dropped.s.Origin[:], trace.Endpos[:])
+	} else {
+		shared.AngleVectors(ent.s.Angles[:], forward, right, nil)
+		copy(dropped.s.Origin[:], ent.s.Origin[:])
+	}
+
+	shared.VectorScale(forward, 100, dropped.velocity[:])
+	dropped.velocity[2] = 300
+
+	dropped.think = drop_make_touchable
+	dropped.nextthink = G.level.time + 1
+
+	G.gi.Linkentity(dropped)
+	return dropped
+}
+
 func use_Item(ent, other /* unused */, activator /* unused */ *edict_t, G *qGame) {
 	if ent == nil {
 		return
@@ -252,7 +797,7 @@ func droptofloor(ent *edict_t, G *qGame) {
 
 	ent.solid = shared.SOLID_TRIGGER
 	ent.movetype = MOVETYPE_TOSS
-	// ent->touch = Touch_Item;
+	ent.touch = touch_Item
 
 	dest := make([]float32, 3)
 	shared.VectorAdd(ent.s.Origin[:], []float32{0, 0, -129}, dest)
@@ -268,19 +813,19 @@ func droptofloor(ent *edict_t, G *qGame) {
 
 	copy(ent.s.Origin[:], tr.Endpos[:])
 
-	// if (ent->team) {
-	// 	ent->flags &= ~FL_TEAMSLAVE;
-	// 	ent->chain = ent->teamchain;
-	// 	ent->teamchain = NULL;
+	if len(ent.Team) > 0 {
+		ent.flags &^= FL_TEAMSLAVE
+		ent.chain = ent.teamchain
+		ent.teamchain = nil
 
-	// 	ent->svflags |= SVF_NOCLIENT;
-	// 	ent->solid = SOLID_NOT;
+		ent.svflags |= shared.SVF_NOCLIENT
+		ent.solid = shared.SOLID_NOT
 
-	// 	if (ent == ent->teammaster) {
-	// 		ent->nextthink = level.time + FRAMETIME;
-	// 		ent->think = DoRespawn;
-	// 	}
-	// }
+		if ent == ent.teammaster {
+			ent.nextthink = G.level.time + FRAMETIME
+			ent.think = doRespawn
+		}
+	}
 
 	if (ent.Spawnflags & ITEM_NO_TOUCH) != 0 {
 		ent.solid = shared.SOLID_BBOX
@@ -298,6 +843,58 @@ func droptofloor(ent *edict_t, G *qGame) {
 	G.gi.Linkentity(ent)
 }
 
+/*
+ * Precaches all data needed for a given item.
+ * This will be called for each item spawned in a level,
+ * and for each item in each client's inventory.
+ */
+func (G *qGame) precacheItem(it *gitem_t) {
+	if it == nil {
+		return
+	}
+
+	if len(it.pickup_sound) > 0 {
+		G.gi.Soundindex(it.pickup_sound)
+	}
+
+	if len(it.world_model) > 0 {
+		G.gi.Modelindex(it.world_model)
+	}
+
+	if len(it.view_model) > 0 {
+		G.gi.Modelindex(it.view_model)
+	}
+
+	if len(it.icon) > 0 {
+		G.gi.Imageindex(it.icon)
+	}
+
+	/* parse everything for its ammo */
+	if len(it.ammo) > 0 {
+		ammo := G.findItem(it.ammo)
+
+		if ammo != it {
+			G.precacheItem(ammo)
+		}
+	}
+
+	/* parse the space seperated precache string for other items */
+	for _, data := range strings.Fields(it.precaches) {
+		if (len(data) >= shared.MAX_QPATH) || (len(data) < 5) {
+			G.gi.Error("PrecacheItem: %s has bad precache string", it.classname)
+		}
+
+		/* determine type based on extension */
+		if strings.HasSuffix(data, "md2") || strings.HasSuffix(data, "sp2") {
+			G.gi.Modelindex(data)
+		} else if strings.HasSuffix(data, "wav") {
+			G.gi.Soundindex(data)
+		} else if strings.HasSuffix(data, "pcx") {
+			G.gi.Imageindex(data)
+		}
+	}
+}
+
 /*
  * ============
  * Sets the clipping size and
@@ -313,7 +910,7 @@ func (G *qGame) spawnItem(ent *edict_t, item *gitem_t) {
 		return
 	}
 
-	// PrecacheItem(item)
+	G.precacheItem(item)
 
 	if ent.Spawnflags != 0 {
 		if ent.Classname != "key_power_cube" {
@@ -324,54 +921,53 @@ func (G *qGame) spawnItem(ent *edict_t, item *gitem_t) {
 	}
 
 	/* some items will be prevented in deathmatch */
-	//  if (deathmatch.value) {
-	// 	 if ((int)dmflags->value & DF_NO_ARMOR) != 0 {
-	// 		 if ((item->pickup == Pickup_Armor) ||
-	// 			 (item->pickup == Pickup_PowerArmor)) {
-	// 			 G_FreeEdict(ent);
-	// 			 return;
-	// 		 }
-	// 	 }
-
-	// 	 if ((int)dmflags->value & DF_NO_ITEMS) != 0 {
-	// 		 if (item->pickup == Pickup_Powerup) {
-	// 			 G_FreeEdict(ent);
-	// 			 return;
-	// 		 }
-	// 	 }
-
-	// 	 if ((int)dmflags->value & DF_NO_HEALTH) != 0 {
-	// 		 if ((item->pickup == Pickup_Health) ||
-	// 			 (item->pickup == Pickup_Adrenaline) ||
-	// 			 (item->pickup == Pickup_AncientHead)) {
-	// 			 G_FreeEdict(ent);
-	// 			 return;
-	// 		 }
-	// 	 }
-
-	// 	 if ((int)dmflags->value & DF_INFINITE_AMMO) != 0 {
-	// 		 if ((item->flags == IT_AMMO) ||
-	// 			 (strcmp(ent->classname, "weapon_bfg") == 0)) {
-	// 			 G_FreeEdict(ent);
-	// 			 return;
-	// 		 }
-	// 	 }
-	//  }
-
-	//  if (coop->value && (strcmp(ent->classname, "key_power_cube") == 0)) {
-	// 	 ent->spawnflags |= (1 << (8 + level.power_cubes));
-	// 	 level.power_cubes++;
-	//  }
+	if G.deathmatch.Bool() {
+		if (G.dmflags.Int() & shared.DF_NO_ARMOR) != 0 {
+			if (item.flags & IT_ARMOR) != 0 {
+				G.gFreeEdict(ent)
+				return
+			}
+		}
+
+		if (G.dmflags.Int() & shared.DF_NO_ITEMS) != 0 {
+			if sameFunc(item.pickup, Pickup_Powerup) {
+				G.gFreeEdict(ent)
+				return
+			}
+		}
+
+		if (G.dmflags.Int() & shared.DF_NO_HEALTH) != 0 {
+			if sameFunc(item.pickup, Pickup_Health) ||
+				sameFunc(item.pickup, Pickup_Adrenaline) ||
+				sameFunc(item.pickup, Pickup_AncientHead) {
+				G.gFreeEdict(ent)
+				return
+			}
+		}
+
+		if (G.dmflags.Int() & shared.DF_INFINITE_AMMO) != 0 {
+			if (item.flags == IT_AMMO) ||
+				(ent.Classname == "weapon_bfg") {
+				G.gFreeEdict(ent)
+				return
+			}
+		}
+	}
+
+	if G.coop.Bool() && (ent.Classname == "key_power_cube") {
+		ent.Spawnflags |= (1 << (8 + G.level.power_cubes))
+		G.level.power_cubes++
+	}
 
 	/* don't let them drop items that stay in a coop game */
-	//  if ((coop->value) && (item->flags & IT_STAY_COOP) != 0) {
-	// 	 item->drop = NULL;
-	//  }
+	if G.coop.Bool() && (item.flags&IT_STAY_COOP) != 0 {
+		item.drop = nil
+	}
 
 	ent.item = item
 	ent.nextthink = G.level.time + 2*FRAMETIME /* items start after other solids */
 	ent.think = droptofloor
-	//  ent->s.effects = item->world_model_flags;
+	ent.s.Effects = uint(item.world_model_flags)
 	ent.s.Renderfx = shared.RF_GLOW
 
 	if len(ent.Model) > 0 {
@@ -399,16 +995,16 @@ func Pickup_Health(ent, other *edict_t, G *qGame) bool {
 	}
 
 	if (ent.Style & HEALTH_TIMED) != 0 {
-		// 	ent.think = MegaHealth_think
+		ent.think = megaHealth_think
 		ent.nextthink = G.level.time + 5
 		ent.owner = other
 		ent.flags |= FL_RESPAWN
 		ent.svflags |= shared.SVF_NOCLIENT
 		ent.solid = shared.SOLID_NOT
 	} else {
-		// 	if (ent.Spawnflags&DROPPED_ITEM) == 0 && G.deathmatch.Bool() {
-		// 		SetRespawn(ent, 30)
-		// 	}
+		if (ent.Spawnflags&DROPPED_ITEM) == 0 && G.deathmatch.Bool() {
+			G.setRespawn(ent, 30)
+		}
 	}
 
 	return true
@@ -440,922 +1036,1090 @@ func (G *qGame) armorIndex(ent *edict_t) int {
 	return 0
 }
 
+func Pickup_Armor(ent, other *edict_t, G *qGame) bool {
+	if ent == nil || other == nil || G == nil {
+		return false
+	}
+
+	/* get info on new armor */
+	newinfo, _ := ent.item.info.(*gitem_armor_t)
+
+	old_armor_index := G.armorIndex(other)
+
+	/* handle armor shards specially */
+	if ent.item.tag == ARMOR_SHARD {
+		if old_armor_index == 0 {
+			other.client.pers.inventory[G.jacket_armor_index] = 2
+		} else {
+			other.client.pers.inventory[old_armor_index] += 2
+		}
+	} else if old_armor_index == 0 {
+		/* if player has no armor, just use it */
+		other.client.pers.inventory[itemIndex(ent.item)] = newinfo.base_count
+	} else {
+		/* use the better armor */
+
+		/* get info on old armor */
+		var oldinfo *gitem_armor_t
+		if old_armor_index == G.jacket_armor_index {
+			oldinfo = &jacketarmor_info
+		} else if old_armor_index == G.combat_armor_index {
+			oldinfo = &combatarmor_info
+		} else {
+			oldinfo = &bodyarmor_info
+		}
+
+		if newinfo.normal_protection > oldinfo.normal_protection {
+			/* calc new armor values */
+			salvage := oldinfo.normal_protection / newinfo.normal_protection
+			salvagecount := int(salvage * float32(other.client.pers.inventory[old_armor_index]))
+			newcount := newinfo.base_count + salvagecount
+
+			if newcount > newinfo.max_count {
+				newcount = newinfo.max_count
+			}
+
+			/* zero count of old armor so it goes away */
+			other.client.pers.inventory[old_armor_index] = 0
+
+			/* change armor to new item with computed value */
+			other.client.pers.inventory[itemIndex(ent.item)] = newcount
+		} else {
+			/* calc new armor values */
+			salvage := newinfo.normal_protection / oldinfo.normal_protection
+			salvagecount := int(salvage * float32(newinfo.base_count))
+			newcount := other.client.pers.inventory[old_armor_index] + salvagecount
+
+			if newcount > oldinfo.max_count {
+				newcount = oldinfo.max_count
+			}
+
+			/* if we're already maxed out then we don't need the new armor */
+			if other.client.pers.inventory[old_armor_index] >= newcount {
+				return false
+			}
+
+			/* update current armor value */
+			other.client.pers.inventory[old_armor_index] = newcount
+		}
+	}
+
+	if (ent.Spawnflags&DROPPED_ITEM) == 0 && G.deathmatch.Bool() {
+		G.setRespawn(ent, 20)
+	}
+
+	return true
+}
+
 /* ====================================================================== */
 
-var gameitemlist = []gitem_t{
-	{}, /* leave index 0 alone */
-
-	/* QUAKED item_armor_body (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"item_armor_body",
-		nil, // Pickup_Armor,
-		nil,
-		nil,
-		nil,
-		"misc/ar1_pkup.wav",
-		"models/items/armor/body/tris.md2", shared.EF_ROTATE,
-		"",
-		"i_bodyarmor",
-		"Body Armor",
-		3,
-		0,
-		"",
-		IT_ARMOR,
-		0,
-		&bodyarmor_info,
-		ARMOR_BODY,
-		"",
-	},
-
-	/* QUAKED item_armor_combat (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"item_armor_combat",
-		nil, // Pickup_Armor,
-		nil,
-		nil,
-		nil,
-		"misc/ar1_pkup.wav",
-		"models/items/armor/combat/tris.md2", shared.EF_ROTATE,
-		"",
-		"i_combatarmor",
-		"Combat Armor",
-		3,
-		0,
-		"",
-		IT_ARMOR,
-		0,
-		&combatarmor_info,
-		ARMOR_COMBAT,
-		"",
-	},
-
-	/* QUAKED item_armor_jacket (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"item_armor_jacket",
-		nil, // Pickup_Armor,
-		nil,
-		nil,
-		nil,
-		"misc/ar1_pkup.wav",
-		"models/items/armor/jacket/tris.md2", shared.EF_ROTATE,
-		"",
-		"i_jacketarmor",
-		"Jacket Armor",
-		3,
-		0,
-		"",
-		IT_ARMOR,
-		0,
-		&jacketarmor_info,
-		ARMOR_JACKET,
-		"",
-	},
-
-	/* QUAKED item_armor_shard (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"item_armor_shard",
-		nil, // Pickup_Armor,
-		nil,
-		nil,
-		nil,
-		"misc/ar2_pkup.wav",
-		"models/items/armor/shard/tris.md2", shared.EF_ROTATE,
-		"",
-		"i_jacketarmor",
-		"Armor Shard",
-		3,
-		0,
-		"",
-		IT_ARMOR,
-		0,
-		nil,
-		ARMOR_SHARD,
-		"",
-	},
-
-	/* QUAKED item_power_screen (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"item_power_screen",
-		nil, // Pickup_PowerArmor,
-		nil, // Use_PowerArmor,
-		nil, // Drop_PowerArmor,
-		nil,
-		"misc/ar3_pkup.wav",
-		"models/items/armor/screen/tris.md2", shared.EF_ROTATE,
-		"",
-		"i_powerscreen",
-		"Power Screen",
-		0,
-		60,
-		"",
-		IT_ARMOR,
-		0,
-		nil,
-		0,
-		"",
-	},
-
-	/* QUAKED item_power_shield (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"item_power_shield",
-		nil, // Pickup_PowerArmor,
-		nil, // Use_PowerArmor,
-		nil, // Drop_PowerArmor,
-		nil,
-		"misc/ar3_pkup.wav",
-		"models/items/armor/shield/tris.md2", shared.EF_ROTATE,
-		"",
-		"i_powershield",
-		"Power Shield",
-		0,
-		60,
-		"",
-		IT_ARMOR,
-		0,
-		nil,
-		0,
-		"misc/power2.wav misc/power1.wav",
-	},
-
-	/* weapon_blaster (.3 .3 1) (-16 -16 -16) (16 16 16)
-	   always owned, never in the world */
-	{
-		"weapon_blaster",
-		nil,
-		use_Weapon,
-		nil,
-		weapon_Blaster,
-		"misc/w_pkup.wav",
-		"", 0,
-		"models/weapons/v_blast/tris.md2",
-		"w_blaster",
-		"Blaster",
-		0,
-		0,
-		"",
-		IT_WEAPON | IT_STAY_COOP,
-		WEAP_BLASTER,
-		nil,
-		0,
-		"weapons/blastf1a.wav misc/lasfly.wav",
-	},
-
-	/* QUAKED weapon_shotgun (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"weapon_shotgun",
-		nil, // Pickup_Weapon,
-		use_Weapon,
-		nil, // Drop_Weapon,
-		nil, // Weapon_Shotgun,
-		"misc/w_pkup.wav",
-		"models/weapons/g_shotg/tris.md2", shared.EF_ROTATE,
-		"models/weapons/v_shotg/tris.md2",
-		"w_shotgun",
-		"Shotgun",
-		0,
-		1,
-		"Shells",
-		IT_WEAPON | IT_STAY_COOP,
-		WEAP_SHOTGUN,
-		nil,
-		0,
-		"weapons/shotgf1b.wav weapons/shotgr1b.wav",
-	},
-
-	/* QUAKED weapon_supershotgun (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"weapon_supershotgun",
-		nil, // Pickup_Weapon,
-		use_Weapon,
-		nil, // Drop_Weapon,
-		nil, // Weapon_SuperShotgun,
-		"misc/w_pkup.wav",
-		"models/weapons/g_shotg2/tris.md2", shared.EF_ROTATE,
-		"models/weapons/v_shotg2/tris.md2",
-		"w_sshotgun",
-		"Super Shotgun",
-		0,
-		2,
-		"Shells",
-		IT_WEAPON | IT_STAY_COOP,
-		WEAP_SUPERSHOTGUN,
-		nil,
-		0,
-		"weapons/sshotf1b.wav",
-	},
-
-	/* QUAKED weapon_machinegun (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"weapon_machinegun",
-		nil, // Pickup_Weapon,
-		use_Weapon,
-		nil, // Drop_Weapon,
-		nil, // Weapon_Machinegun,
-		"misc/w_pkup.wav",
-		"models/weapons/g_machn/tris.md2", shared.EF_ROTATE,
-		"models/weapons/v_machn/tris.md2",
-		"w_machinegun",
-		"Machinegun",
-		0,
-		1,
-		"Bullets",
-		IT_WEAPON | IT_STAY_COOP,
-		WEAP_MACHINEGUN,
-		nil,
-		0,
-		"weapons/machgf1b.wav weapons/machgf2b.wav weapons/machgf3b.wav weapons/machgf4b.wav weapons/machgf5b.wav",
-	},
-
-	/* QUAKED weapon_chaingun (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"weapon_chaingun",
-		nil, // Pickup_Weapon,
-		use_Weapon,
-		nil, // Drop_Weapon,
-		nil, // Weapon_Chaingun,
-		"misc/w_pkup.wav",
-		"models/weapons/g_chain/tris.md2", shared.EF_ROTATE,
-		"models/weapons/v_chain/tris.md2",
-		"w_chaingun",
-		"Chaingun",
-		0,
-		1,
-		"Bullets",
-		IT_WEAPON | IT_STAY_COOP,
-		WEAP_CHAINGUN,
-		nil,
-		0,
-		"weapons/chngnu1a.wav weapons/chngnl1a.wav weapons/machgf3b.wav` weapons/chngnd1a.wav",
-	},
-
-	/* QUAKED ammo_grenades (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"ammo_grenades",
-		nil, // Pickup_Ammo,
-		use_Weapon,
-		nil, // Drop_Ammo,
-		nil, // Weapon_Grenade,
-		"misc/am_pkup.wav",
-		"models/items/ammo/grenades/medium/tris.md2", 0,
-		"models/weapons/v_handgr/tris.md2",
-		"a_grenades",
-		"Grenades",
-		3,
-		5,
-		"grenades",
-		IT_AMMO | IT_WEAPON,
-		WEAP_GRENADES,
-		nil,
-		AMMO_GRENADES,
-		"weapons/hgrent1a.wav weapons/hgrena1b.wav weapons/hgrenc1b.wav weapons/hgrenb1a.wav weapons/hgrenb2a.wav ",
-	},
-
-	/* QUAKED weapon_grenadelauncher (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"weapon_grenadelauncher",
-		nil, // Pickup_Weapon,
-		use_Weapon,
-		nil, // Drop_Weapon,
-		nil, // Weapon_GrenadeLauncher,
-		"misc/w_pkup.wav",
-		"models/weapons/g_launch/tris.md2", shared.EF_ROTATE,
-		"models/weapons/v_launch/tris.md2",
-		"w_glauncher",
-		"Grenade Launcher",
-		0,
-		1,
-		"Grenades",
-		IT_WEAPON | IT_STAY_COOP,
-		WEAP_GRENADELAUNCHER,
-		nil,
-		0,
-		"models/objects/grenade/tris.md2 weapons/grenlf1a.wav weapons/grenlr1b.wav weapons/grenlb1b.wav",
-	},
-
-	/* QUAKED weapon_rocketlauncher (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"weapon_rocketlauncher",
-		nil, // Pickup_Weapon,
-		use_Weapon,
-		nil, // Drop_Weapon,
-		nil, // Weapon_RocketLauncher,
-		"misc/w_pkup.wav",
-		"models/weapons/g_rocket/tris.md2", shared.EF_ROTATE,
-		"models/weapons/v_rocket/tris.md2",
-		"w_rlauncher",
-		"Rocket Launcher",
-		0,
-		1,
-		"Rockets",
-		IT_WEAPON | IT_STAY_COOP,
-		WEAP_ROCKETLAUNCHER,
-		nil,
-		0,
-		"models/objects/rocket/tris.md2 weapons/rockfly.wav weapons/rocklf1a.wav weapons/rocklr1b.wav models/objects/debris2/tris.md2",
-	},
-
-	/* QUAKED weapon_hyperblaster (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"weapon_hyperblaster",
-		nil, // Pickup_Weapon,
-		use_Weapon,
-		nil, // Drop_Weapon,
-		nil, // Weapon_HyperBlaster,
-		"misc/w_pkup.wav",
-		"models/weapons/g_hyperb/tris.md2", shared.EF_ROTATE,
-		"models/weapons/v_hyperb/tris.md2",
-		"w_hyperblaster",
-		"HyperBlaster",
-		0,
-		1,
-		"Cells",
-		IT_WEAPON | IT_STAY_COOP,
-		WEAP_HYPERBLASTER,
-		nil,
-		0,
-		"weapons/hyprbu1a.wav weapons/hyprbl1a.wav weapons/hyprbf1a.wav weapons/hyprbd1a.wav misc/lasfly.wav",
-	},
-
-	/* QUAKED weapon_railgun (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"weapon_railgun",
-		nil, // Pickup_Weapon,
-		use_Weapon,
-		nil, // Drop_Weapon,
-		nil, // Weapon_Railgun,
-		"misc/w_pkup.wav",
-		"models/weapons/g_rail/tris.md2", shared.EF_ROTATE,
-		"models/weapons/v_rail/tris.md2",
-		"w_railgun",
-		"Railgun",
-		0,
-		1,
-		"Slugs",
-		IT_WEAPON | IT_STAY_COOP,
-		WEAP_RAILGUN,
-		nil,
-		0,
-		"weapons/rg_hum.wav",
-	},
-
-	/* QUAKED weapon_bfg (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"weapon_bfg",
-		nil, // Pickup_Weapon,
-		use_Weapon,
-		nil, // Drop_Weapon,
-		nil, // Weapon_BFG,
-		"misc/w_pkup.wav",
-		"models/weapons/g_bfg/tris.md2", shared.EF_ROTATE,
-		"models/weapons/v_bfg/tris.md2",
-		"w_bfg",
-		"BFG10K",
-		0,
-		50,
-		"Cells",
-		IT_WEAPON | IT_STAY_COOP,
-		WEAP_BFG,
-		nil,
-		0,
-		"sprites/s_bfg1.sp2 sprites/s_bfg2.sp2 sprites/s_bfg3.sp2 weapons/bfg__f1y.wav weapons/bfg__l1a.wav weapons/bfg__x1b.wav weapons/bfg_hum.wav",
-	},
-
-	/* QUAKED ammo_shells (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"ammo_shells",
-		nil, // Pickup_Ammo,
-		nil,
-		nil, // Drop_Ammo,
-		nil,
-		"misc/am_pkup.wav",
-		"models/items/ammo/shells/medium/tris.md2", 0,
-		"",
-		"a_shells",
-		"Shells",
-		3,
-		10,
-		"",
-		IT_AMMO,
-		0,
-		nil,
-		AMMO_SHELLS,
-		"",
-	},
-
-	/* QUAKED ammo_bullets (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"ammo_bullets",
-		nil, // Pickup_Ammo,
-		nil,
-		nil, // Drop_Ammo,
-		nil,
-		"misc/am_pkup.wav",
-		"models/items/ammo/bullets/medium/tris.md2", 0,
-		"",
-		"a_bullets",
-		"Bullets",
-		3,
-		50,
-		"",
-		IT_AMMO,
-		0,
-		nil,
-		AMMO_BULLETS,
-		"",
-	},
-
-	/* QUAKED ammo_cells (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"ammo_cells",
-		nil, // Pickup_Ammo,
-		nil,
-		nil, // Drop_Ammo,
-		nil,
-		"misc/am_pkup.wav",
-		"models/items/ammo/cells/medium/tris.md2", 0,
-		"",
-		"a_cells",
-		"Cells",
-		3,
-		50,
-		"",
-		IT_AMMO,
-		0,
-		nil,
-		AMMO_CELLS,
-		"",
-	},
-
-	/* QUAKED ammo_rockets (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"ammo_rockets",
-		nil, // Pickup_Ammo,
-		nil,
-		nil, // Drop_Ammo,
-		nil,
-		"misc/am_pkup.wav",
-		"models/items/ammo/rockets/medium/tris.md2", 0,
-		"",
-		"a_rockets",
-		"Rockets",
-		3,
-		5,
-		"",
-		IT_AMMO,
-		0,
-		nil,
-		AMMO_ROCKETS,
-		"",
-	},
-
-	/* QUAKED ammo_slugs (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"ammo_slugs",
-		nil, // Pickup_Ammo,
-		nil,
-		nil, // Drop_Ammo,
-		nil,
-		"misc/am_pkup.wav",
-		"models/items/ammo/slugs/medium/tris.md2", 0,
-		"",
-		"a_slugs",
-		"Slugs",
-		3,
-		10,
-		"",
-		IT_AMMO,
-		0,
-		nil,
-		AMMO_SLUGS,
-		"",
-	},
-
-	/* QUAKED item_quad (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"item_quad",
-		nil, // Pickup_Powerup,
-		nil, // Use_Quad,
-		nil, // Drop_General,
-		nil,
-		"items/pkup.wav",
-		"models/items/quaddama/tris.md2", shared.EF_ROTATE,
-		"",
-		"p_quad",
-		"Quad Damage",
-		2,
-		60,
-		"",
-		IT_POWERUP | IT_INSTANT_USE,
-		0,
-		nil,
-		0,
-		"items/damage.wav items/damage2.wav items/damage3.wav",
-	},
-
-	/* QUAKED item_invulnerability (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"item_invulnerability",
-		nil, // Pickup_Powerup,
-		nil, // Use_Invulnerability,
-		nil, // Drop_General,
-		nil,
-		"items/pkup.wav",
-		"models/items/invulner/tris.md2", shared.EF_ROTATE,
-		"",
-		"p_invulnerability",
-		"Invulnerability",
-		2,
-		300,
-		"",
-		IT_POWERUP | IT_INSTANT_USE,
-		0,
-		nil,
-		0,
-		"items/protect.wav items/protect2.wav items/protect4.wav",
-	},
-
-	/* QUAKED item_silencer (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"item_silencer",
-		nil, // Pickup_Powerup,
-		nil, // Use_Silencer,
-		nil, // Drop_General,
-		nil,
-		"items/pkup.wav",
-		"models/items/silencer/tris.md2", shared.EF_ROTATE,
-		"",
-		"p_silencer",
-		"Silencer",
-		2,
-		60,
-		"",
-		IT_POWERUP | IT_INSTANT_USE,
-		0,
-		nil,
-		0,
-		"",
-	},
-
-	/* QUAKED item_breather (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"item_breather",
-		nil, // Pickup_Powerup,
-		nil, // Use_Breather,
-		nil, // Drop_General,
-		nil,
-		"items/pkup.wav",
-		"models/items/breather/tris.md2", shared.EF_ROTATE,
-		"",
-		"p_rebreather",
-		"Rebreather",
-		2,
-		60,
-		"",
-		IT_STAY_COOP | IT_POWERUP | IT_INSTANT_USE,
-		0,
-		nil,
-		0,
-		"items/airout.wav",
-	},
-
-	/* QUAKED item_enviro (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"item_enviro",
-		nil, // Pickup_Powerup,
-		nil, // Use_Envirosuit,
-		nil, // Drop_General,
-		nil,
-		"items/pkup.wav",
-		"models/items/enviro/tris.md2", shared.EF_ROTATE,
-		"",
-		"p_envirosuit",
-		"Environment Suit",
-		2,
-		60,
-		"",
-		IT_STAY_COOP | IT_POWERUP | IT_INSTANT_USE,
-		0,
-		nil,
-		0,
-		"items/airout.wav",
-	},
-
-	/* QUAKED item_ancient_head (.3 .3 1) (-16 -16 -16) (16 16 16)
-	   Special item that gives +2 to maximum health */
-	{
-		"item_ancient_head",
-		nil, // Pickup_AncientHead,
-		nil,
-		nil,
-		nil,
-		"items/pkup.wav",
-		"models/items/c_head/tris.md2", shared.EF_ROTATE,
-		"",
-		"i_fixme",
-		"Ancient Head",
-		2,
-		60,
-		"",
-		0,
-		0,
-		nil,
-		0,
-		"",
-	},
-
-	/* QUAKED item_adrenaline (.3 .3 1) (-16 -16 -16) (16 16 16)
-	   gives +1 to maximum health */
-	{
-		"item_adrenaline",
-		nil, // Pickup_Adrenaline,
-		nil,
-		nil,
-		nil,
-		"items/pkup.wav",
-		"models/items/adrenal/tris.md2", shared.EF_ROTATE,
-		"",
-		"p_adrenaline",
-		"Adrenaline",
-		2,
-		60,
-		"",
-		0,
-		0,
-		nil,
-		0,
-		"",
-	},
-
-	/* QUAKED item_bandolier (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"item_bandolier",
-		nil, // Pickup_Bandolier,
-		nil,
-		nil,
-		nil,
-		"items/pkup.wav",
-		"models/items/band/tris.md2", shared.EF_ROTATE,
-		"",
-		"p_bandolier",
-		"Bandolier",
-		2,
-		60,
-		"",
-		0,
-		0,
-		nil,
-		0,
-		"",
-	},
-
-	/* QUAKED item_pack (.3 .3 1) (-16 -16 -16) (16 16 16) */
-	{
-		"item_pack",
-		nil, // Pickup_Pack,
-		nil,
-		nil,
-		nil,
-		"items/pkup.wav",
-		"models/items/pack/tris.md2", shared.EF_ROTATE,
-		"",
-		"i_pack",
-		"Ammo Pack",
-		2,
-		180,
-		"",
-		0,
-		0,
-		nil,
-		0,
-		"",
-	},
-
-	/* QUAKED key_data_cd (0 .5 .8) (-16 -16 -16) (16 16 16)
-	   key for computer centers */
-	{
-		"key_data_cd",
-		nil, // Pickup_Key,
-		nil,
-		nil, // Drop_General,
-		nil,
-		"items/pkup.wav",
-		"models/items/keys/data_cd/tris.md2", shared.EF_ROTATE,
-		"",
-		"k_datacd",
-		"Data CD",
-		2,
-		0,
-		"",
-		IT_STAY_COOP | IT_KEY,
-		0,
-		nil,
-		0,
-		"",
-	},
-
-	/* QUAKED key_power_cube (0 .5 .8) (-16 -16 -16) (16 16 16) TRIGGER_SPAWN NO_TOUCH
-	   warehouse circuits */
-	{
-		"key_power_cube",
-		nil, // Pickup_Key,
-		nil,
-		nil, // Drop_General,
-		nil,
-		"items/pkup.wav",
-		"models/items/keys/power/tris.md2", shared.EF_ROTATE,
-		"",
-		"k_powercube",
-		"Power Cube",
-		2,
-		0,
-		"",
-		IT_STAY_COOP | IT_KEY,
-		0,
-		nil,
-		0,
-		"",
-	},
-
-	/* QUAKED key_pyramid (0 .5 .8) (-16 -16 -16) (16 16 16)
-	   key for the entrance of jail3 */
-	{
-		"key_pyramid",
-		nil, // Pickup_Key,
-		nil,
-		nil, // Drop_General,
-		nil,
-		"items/pkup.wav",
-		"models/items/keys/pyramid/tris.md2", shared.EF_ROTATE,
-		"",
-		"k_pyramid",
-		"Pyramid Key",
-		2,
-		0,
-		"",
-		IT_STAY_COOP | IT_KEY,
-		0,
-		nil,
-		0,
-		"",
-	},
-
-	/* QUAKED key_data_spinner (0 .5 .8) (-16 -16 -16) (16 16 16)
-	   key for the city computer */
-	{
-		"key_data_spinner",
-		nil, // Pickup_Key,
-		nil,
-		nil, // Drop_General,
-		nil,
-		"items/pkup.wav",
-		"models/items/keys/spinner/tris.md2", shared.EF_ROTATE,
-		"",
-		"k_dataspin",
-		"Data Spinner",
-		2,
-		0,
-		"",
-		IT_STAY_COOP | IT_KEY,
-		0,
-		nil,
-		0,
-		"",
-	},
-
-	/* QUAKED key_pass (0 .5 .8) (-16 -16 -16) (16 16 16)
-	   security pass for the security level */
-	{
-		"key_pass",
-		nil, // Pickup_Key,
-		nil,
-		nil, // Drop_General,
-		nil,
-		"items/pkup.wav",
-		"models/items/keys/pass/tris.md2", shared.EF_ROTATE,
-		"",
-		"k_security",
-		"Security Pass",
-		2,
-		0,
-		"",
-		IT_STAY_COOP | IT_KEY,
-		0,
-		nil,
-		0,
-		"",
-	},
-
-	/* QUAKED key_blue_key (0 .5 .8) (-16 -16 -16) (16 16 16)
-	   normal door key - blue */
-	{
-		"key_blue_key",
-		nil, // Pickup_Key,
-		nil,
-		nil, // Drop_General,
-		nil,
-		"items/pkup.wav",
-		"models/items/keys/key/tris.md2", shared.EF_ROTATE,
-		"",
-		"k_bluekey",
-		"Blue Key",
-		2,
-		0,
-		"",
-		IT_STAY_COOP | IT_KEY,
-		0,
-		nil,
-		0,
-		"",
-	},
-
-	/* QUAKED key_red_key (0 .5 .8) (-16 -16 -16) (16 16 16)
-	   normal door key - red */
-	{
-		"key_red_key",
-		nil, // Pickup_Key,
-		nil,
-		nil, // Drop_General,
-		nil,
-		"items/pkup.wav",
-		"models/items/keys/red_key/tris.md2", shared.EF_ROTATE,
-		"",
-		"k_redkey",
-		"Red Key",
-		2,
-		0,
-		"",
-		IT_STAY_COOP | IT_KEY,
-		0,
-		nil,
-		0,
-		"",
-	},
-
-	/* QUAKED key_commander_head (0 .5 .8) (-16 -16 -16) (16 16 16)
-	   tank commander's head */
-	{
-		"key_commander_head",
-		nil, // Pickup_Key,
-		nil,
-		nil, // Drop_General,
-		nil,
-		"items/pkup.wav",
-		"models/monsters/commandr/head/tris.md2", shared.EF_GIB,
-		"",
-		"k_comhead",
-		"Commander's Head",
-		2,
-		0,
-		"",
-		IT_STAY_COOP | IT_KEY,
-		0,
-		nil,
-		0,
-		"",
-	},
-
-	/* QUAKED key_airstrike_target (0 .5 .8) (-16 -16 -16) (16 16 16) */
-	{
-		"key_airstrike_target",
-		nil, // Pickup_Key,
-		nil,
-		nil, // Drop_General,
-		nil,
-		"items/pkup.wav",
-		"models/items/keys/target/tris.md2", shared.EF_ROTATE,
-		"",
-		"i_airstrike",
-		"Airstrike Marker",
-		2,
-		0,
-		"",
-		IT_STAY_COOP | IT_KEY,
-		0,
-		nil,
-		0,
-		"",
-	},
-
-	{
-		"",
-		Pickup_Health,
-		nil,
-		nil,
-		nil,
-		"items/pkup.wav",
-		"", 0,
-		"",
-		"i_health",
-		"Health",
-		3,
-		0,
-		"",
-		0,
-		0,
-		nil,
-		0,
-		"items/s_health.wav items/n_health.wav items/l_health.wav items/m_health.wav",
-	},
+func (G *qGame) powerArmorType(ent *edict_t) int {
+	if ent == nil {
+		return POWER_ARMOR_NONE
+	}
+
+	if ent.client == nil {
+		return POWER_ARMOR_NONE
+	}
+
+	if (ent.flags & FL_POWER_ARMOR) == 0 {
+		return POWER_ARMOR_NONE
+	}
+
+	if ent.client.pers.inventory[G.power_shield_index] > 0 {
+		return POWER_ARMOR_SHIELD
+	}
+
+	if ent.client.pers.inventory[G.power_screen_index] > 0 {
+		return POWER_ARMOR_SCREEN
+	}
+
+	return POWER_ARMOR_NONE
+}
+
+func use_PowerArmor(ent *edict_t, item *gitem_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	if (ent.flags & FL_POWER_ARMOR) != 0 {
+		ent.flags &^= FL_POWER_ARMOR
+		G.gi.Sound(ent, shared.CHAN_AUTO, G.gi.Soundindex(
+			"misc/power2.wav"), 1, shared.ATTN_NORM, 0)
+	} else {
+		index := G.findItemIndex("cells")
+
+		if ent.client.pers.inventory[index] == 0 {
+			G.gi.Cprintf(ent, shared.PRINT_HIGH, "No cells for power armor.\n")
+			return
+		}
+
+		ent.flags |= FL_POWER_ARMOR
+		G.gi.Sound(ent, shared.CHAN_AUTO, G.gi.Soundindex(
+			"misc/power1.wav"), 1, shared.ATTN_NORM, 0)
+	}
+}
+
+func Pickup_PowerArmor(ent, other *edict_t, G *qGame) bool {
+	if ent == nil || other == nil || G == nil {
+		return false
+	}
+
+	quantity := other.client.pers.inventory[itemIndex(ent.item)]
+
+	other.client.pers.inventory[itemIndex(ent.item)]++
+
+	if G.deathmatch.Bool() {
+		if (ent.Spawnflags & DROPPED_ITEM) == 0 {
+			G.setRespawn(ent, float32(ent.item.quantity))
+		}
+
+		/* auto-use for DM only if we didn't already have one */
+		if quantity == 0 {
+			ent.item.use(other, ent.item, G)
+		}
+	}
+
+	return true
+}
+
+func drop_PowerArmor(ent *edict_t, item *gitem_t, G *qGame) {
+	if ent == nil || item == nil || G == nil {
+		return
+	}
+
+	if (ent.flags&FL_POWER_ARMOR) != 0 &&
+		(ent.client.pers.inventory[itemIndex(item)] == 1) {
+		use_PowerArmor(ent, item, G)
+	}
+
+	drop_General(ent, item, G)
+}
+
+/* ====================================================================== */
+
+var gameitemlist []gitem_t
+
+/*
+ * The item callbacks refer back into the
+ * list, so it has to be filled at init time
+ */
+func init() {
+	gameitemlist = []gitem_t{
+		{}, /* leave index 0 alone */
+
+		/* QUAKED item_armor_body (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"item_armor_body",
+			Pickup_Armor,
+			nil,
+			nil,
+			nil,
+			"misc/ar1_pkup.wav",
+			"models/items/armor/body/tris.md2", shared.EF_ROTATE,
+			"",
+			"i_bodyarmor",
+			"Body Armor",
+			3,
+			0,
+			"",
+			IT_ARMOR,
+			0,
+			&bodyarmor_info,
+			ARMOR_BODY,
+			"",
+		},
+
+		/* QUAKED item_armor_combat (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"item_armor_combat",
+			Pickup_Armor,
+			nil,
+			nil,
+			nil,
+			"misc/ar1_pkup.wav",
+			"models/items/armor/combat/tris.md2", shared.EF_ROTATE,
+			"",
+			"i_combatarmor",
+			"Combat Armor",
+			3,
+			0,
+			"",
+			IT_ARMOR,
+			0,
+			&combatarmor_info,
+			ARMOR_COMBAT,
+			"",
+		},
+
+		/* QUAKED item_armor_jacket (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"item_armor_jacket",
+			Pickup_Armor,
+			nil,
+			nil,
+			nil,
+			"misc/ar1_pkup.wav",
+			"models/items/armor/jacket/tris.md2", shared.EF_ROTATE,
+			"",
+			"i_jacketarmor",
+			"Jacket Armor",
+			3,
+			0,
+			"",
+			IT_ARMOR,
+			0,
+			&jacketarmor_info,
+			ARMOR_JACKET,
+			"",
+		},
+
+		/* QUAKED item_armor_shard (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"item_armor_shard",
+			Pickup_Armor,
+			nil,
+			nil,
+			nil,
+			"misc/ar2_pkup.wav",
+			"models/items/armor/shard/tris.md2", shared.EF_ROTATE,
+			"",
+			"i_jacketarmor",
+			"Armor Shard",
+			3,
+			0,
+			"",
+			IT_ARMOR,
+			0,
+			nil,
+			ARMOR_SHARD,
+			"",
+		},
+
+		/* QUAKED item_power_screen (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"item_power_screen",
+			Pickup_PowerArmor,
+			use_PowerArmor,
+			drop_PowerArmor,
+			nil,
+			"misc/ar3_pkup.wav",
+			"models/items/armor/screen/tris.md2", shared.EF_ROTATE,
+			"",
+			"i_powerscreen",
+			"Power Screen",
+			0,
+			60,
+			"",
+			IT_ARMOR,
+			0,
+			nil,
+			0,
+			"",
+		},
+
+		/* QUAKED item_power_shield (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"item_power_shield",
+			Pickup_PowerArmor,
+			use_PowerArmor,
+			drop_PowerArmor,
+			nil,
+			"misc/ar3_pkup.wav",
+			"models/items/armor/shield/tris.md2", shared.EF_ROTATE,
+			"",
+			"i_powershield",
+			"Power Shield",
+			0,
+			60,
+			"",
+			IT_ARMOR,
+			0,
+			nil,
+			0,
+			"misc/power2.wav misc/power1.wav",
+		},
+
+		/* weapon_blaster (.3 .3 1) (-16 -16 -16) (16 16 16)
+		   always owned, never in the world */
+		{
+			"weapon_blaster",
+			nil,
+			use_Weapon,
+			nil,
+			weapon_Blaster,
+			"misc/w_pkup.wav",
+			"", 0,
+			"models/weapons/v_blast/tris.md2",
+			"w_blaster",
+			"Blaster",
+			0,
+			0,
+			"",
+			IT_WEAPON | IT_STAY_COOP,
+			WEAP_BLASTER,
+			nil,
+			0,
+			"weapons/blastf1a.wav misc/lasfly.wav",
+		},
+
+		/* QUAKED weapon_shotgun (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"weapon_shotgun",
+			Pickup_Weapon,
+			use_Weapon,
+			drop_Weapon,
+			weapon_Shotgun,
+			"misc/w_pkup.wav",
+			"models/weapons/g_shotg/tris.md2", shared.EF_ROTATE,
+			"models/weapons/v_shotg/tris.md2",
+			"w_shotgun",
+			"Shotgun",
+			0,
+			1,
+			"Shells",
+			IT_WEAPON | IT_STAY_COOP,
+			WEAP_SHOTGUN,
+			nil,
+			0,
+			"weapons/shotgf1b.wav weapons/shotgr1b.wav",
+		},
+
+		/* QUAKED weapon_supershotgun (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"weapon_supershotgun",
+			Pickup_Weapon,
+			use_Weapon,
+			drop_Weapon,
+			weapon_SuperShotgun,
+			"misc/w_pkup.wav",
+			"models/weapons/g_shotg2/tris.md2", shared.EF_ROTATE,
+			"models/weapons/v_shotg2/tris.md2",
+			"w_sshotgun",
+			"Super Shotgun",
+			0,
+			2,
+			"Shells",
+			IT_WEAPON | IT_STAY_COOP,
+			WEAP_SUPERSHOTGUN,
+			nil,
+			0,
+			"weapons/sshotf1b.wav",
+		},
+
+		/* QUAKED weapon_machinegun (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"weapon_machinegun",
+			Pickup_Weapon,
+			use_Weapon,
+			drop_Weapon,
+			weapon_Machinegun,
+			"misc/w_pkup.wav",
+			"models/weapons/g_machn/tris.md2", shared.EF_ROTATE,
+			"models/weapons/v_machn/tris.md2",
+			"w_machinegun",
+			"Machinegun",
+			0,
+			1,
+			"Bullets",
+			IT_WEAPON | IT_STAY_COOP,
+			WEAP_MACHINEGUN,
+			nil,
+			0,
+			"weapons/machgf1b.wav weapons/machgf2b.wav weapons/machgf3b.wav weapons/machgf4b.wav weapons/machgf5b.wav",
+		},
+
+		/* QUAKED weapon_chaingun (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"weapon_chaingun",
+			Pickup_Weapon,
+			use_Weapon,
+			drop_Weapon,
+			weapon_Chaingun,
+			"misc/w_pkup.wav",
+			"models/weapons/g_chain/tris.md2", shared.EF_ROTATE,
+			"models/weapons/v_chain/tris.md2",
+			"w_chaingun",
+			"Chaingun",
+			0,
+			1,
+			"Bullets",
+			IT_WEAPON | IT_STAY_COOP,
+			WEAP_CHAINGUN,
+			nil,
+			0,
+			"weapons/chngnu1a.wav weapons/chngnl1a.wav weapons/machgf3b.wav` weapons/chngnd1a.wav",
+		},
+
+		/* QUAKED ammo_grenades (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"ammo_grenades",
+			Pickup_Ammo,
+			use_Weapon,
+			drop_Ammo,
+			weapon_Grenade,
+			"misc/am_pkup.wav",
+			"models/items/ammo/grenades/medium/tris.md2", 0,
+			"models/weapons/v_handgr/tris.md2",
+			"a_grenades",
+			"Grenades",
+			3,
+			5,
+			"grenades",
+			IT_AMMO | IT_WEAPON,
+			WEAP_GRENADES,
+			nil,
+			AMMO_GRENADES,
+			"weapons/hgrent1a.wav weapons/hgrena1b.wav weapons/hgrenc1b.wav weapons/hgrenb1a.wav weapons/hgrenb2a.wav ",
+		},
+
+		/* QUAKED weapon_grenadelauncher (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"weapon_grenadelauncher",
+			Pickup_Weapon,
+			use_Weapon,
+			drop_Weapon,
+			weapon_GrenadeLauncher,
+			"misc/w_pkup.wav",
+			"models/weapons/g_launch/tris.md2", shared.EF_ROTATE,
+			"models/weapons/v_launch/tris.md2",
+			"w_glauncher",
+			"Grenade Launcher",
+			0,
+			1,
+			"Grenades",
+			IT_WEAPON | IT_STAY_COOP,
+			WEAP_GRENADELAUNCHER,
+			nil,
+			0,
+			"models/objects/grenade/tris.md2 weapons/grenlf1a.wav weapons/grenlr1b.wav weapons/grenlb1b.wav",
+		},
+
+		/* QUAKED weapon_rocketlauncher (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"weapon_rocketlauncher",
+			Pickup_Weapon,
+			use_Weapon,
+			drop_Weapon,
+			weapon_RocketLauncher,
+			"misc/w_pkup.wav",
+			"models/weapons/g_rocket/tris.md2", shared.EF_ROTATE,
+			"models/weapons/v_rocket/tris.md2",
+			"w_rlauncher",
+			"Rocket Launcher",
+			0,
+			1,
+			"Rockets",
+			IT_WEAPON | IT_STAY_COOP,
+			WEAP_ROCKETLAUNCHER,
+			nil,
+			0,
+			"models/objects/rocket/tris.md2 weapons/rockfly.wav weapons/rocklf1a.wav weapons/rocklr1b.wav models/objects/debris2/tris.md2",
+		},
+
+		/* QUAKED weapon_hyperblaster (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"weapon_hyperblaster",
+			Pickup_Weapon,
+			use_Weapon,
+			drop_Weapon,
+			weapon_HyperBlaster,
+			"misc/w_pkup.wav",
+			"models/weapons/g_hyperb/tris.md2", shared.EF_ROTATE,
+			"models/weapons/v_hyperb/tris.md2",
+			"w_hyperblaster",
+			"HyperBlaster",
+			0,
+			1,
+			"Cells",
+			IT_WEAPON | IT_STAY_COOP,
+			WEAP_HYPERBLASTER,
+			nil,
+			0,
+			"weapons/hyprbu1a.wav weapons/hyprbl1a.wav weapons/hyprbf1a.wav weapons/hyprbd1a.wav misc/lasfly.wav",
+		},
+
+		/* QUAKED weapon_railgun (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"weapon_railgun",
+			Pickup_Weapon,
+			use_Weapon,
+			drop_Weapon,
+			weapon_Railgun,
+			"misc/w_pkup.wav",
+			"models/weapons/g_rail/tris.md2", shared.EF_ROTATE,
+			"models/weapons/v_rail/tris.md2",
+			"w_railgun",
+			"Railgun",
+			0,
+			1,
+			"Slugs",
+			IT_WEAPON | IT_STAY_COOP,
+			WEAP_RAILGUN,
+			nil,
+			0,
+			"weapons/rg_hum.wav",
+		},
+
+		/* QUAKED weapon_bfg (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"weapon_bfg",
+			Pickup_Weapon,
+			use_Weapon,
+			drop_Weapon,
+			weapon_BFG,
+			"misc/w_pkup.wav",
+			"models/weapons/g_bfg/tris.md2", shared.EF_ROTATE,
+			"models/weapons/v_bfg/tris.md2",
+			"w_bfg",
+			"BFG10K",
+			0,
+			50,
+			"Cells",
+			IT_WEAPON | IT_STAY_COOP,
+			WEAP_BFG,
+			nil,
+			0,
+			"sprites/s_bfg1.sp2 sprites/s_bfg2.sp2 sprites/s_bfg3.sp2 weapons/bfg__f1y.wav weapons/bfg__l1a.wav weapons/bfg__x1b.wav weapons/bfg_hum.wav",
+		},
+
+		/* QUAKED ammo_shells (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"ammo_shells",
+			Pickup_Ammo,
+			nil,
+			drop_Ammo,
+			nil,
+			"misc/am_pkup.wav",
+			"models/items/ammo/shells/medium/tris.md2", 0,
+			"",
+			"a_shells",
+			"Shells",
+			3,
+			10,
+			"",
+			IT_AMMO,
+			0,
+			nil,
+			AMMO_SHELLS,
+			"",
+		},
+
+		/* QUAKED ammo_bullets (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"ammo_bullets",
+			Pickup_Ammo,
+			nil,
+			drop_Ammo,
+			nil,
+			"misc/am_pkup.wav",
+			"models/items/ammo/bullets/medium/tris.md2", 0,
+			"",
+			"a_bullets",
+			"Bullets",
+			3,
+			50,
+			"",
+			IT_AMMO,
+			0,
+			nil,
+			AMMO_BULLETS,
+			"",
+		},
+
+		/* QUAKED ammo_cells (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"ammo_cells",
+			Pickup_Ammo,
+			nil,
+			drop_Ammo,
+			nil,
+			"misc/am_pkup.wav",
+			"models/items/ammo/cells/medium/tris.md2", 0,
+			"",
+			"a_cells",
+			"Cells",
+			3,
+			50,
+			"",
+			IT_AMMO,
+			0,
+			nil,
+			AMMO_CELLS,
+			"",
+		},
+
+		/* QUAKED ammo_rockets (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"ammo_rockets",
+			Pickup_Ammo,
+			nil,
+			drop_Ammo,
+			nil,
+			"misc/am_pkup.wav",
+			"models/items/ammo/rockets/medium/tris.md2", 0,
+			"",
+			"a_rockets",
+			"Rockets",
+			3,
+			5,
+			"",
+			IT_AMMO,
+			0,
+			nil,
+			AMMO_ROCKETS,
+			"",
+		},
+
+		/* QUAKED ammo_slugs (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"ammo_slugs",
+			Pickup_Ammo,
+			nil,
+			drop_Ammo,
+			nil,
+			"misc/am_pkup.wav",
+			"models/items/ammo/slugs/medium/tris.md2", 0,
+			"",
+			"a_slugs",
+			"Slugs",
+			3,
+			10,
+			"",
+			IT_AMMO,
+			0,
+			nil,
+			AMMO_SLUGS,
+			"",
+		},
+
+		/* QUAKED item_quad (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"item_quad",
+			Pickup_Powerup,
+			use_Quad,
+			drop_General,
+			nil,
+			"items/pkup.wav",
+			"models/items/quaddama/tris.md2", shared.EF_ROTATE,
+			"",
+			"p_quad",
+			"Quad Damage",
+			2,
+			60,
+			"",
+			IT_POWERUP | IT_INSTANT_USE,
+			0,
+			nil,
+			0,
+			"items/damage.wav items/damage2.wav items/damage3.wav",
+		},
+
+		/* QUAKED item_invulnerability (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"item_invulnerability",
+			Pickup_Powerup,
+			use_Invulnerability,
+			drop_General,
+			nil,
+			"items/pkup.wav",
+			"models/items/invulner/tris.md2", shared.EF_ROTATE,
+			"",
+			"p_invulnerability",
+			"Invulnerability",
+			2,
+			300,
+			"",
+			IT_POWERUP | IT_INSTANT_USE,
+			0,
+			nil,
+			0,
+			"items/protect.wav items/protect2.wav items/protect4.wav",
+		},
+
+		/* QUAKED item_silencer (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"item_silencer",
+			Pickup_Powerup,
+			use_Silencer,
+			drop_General,
+			nil,
+			"items/pkup.wav",
+			"models/items/silencer/tris.md2", shared.EF_ROTATE,
+			"",
+			"p_silencer",
+			"Silencer",
+			2,
+			60,
+			"",
+			IT_POWERUP | IT_INSTANT_USE,
+			0,
+			nil,
+			0,
+			"",
+		},
+
+		/* QUAKED item_breather (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"item_breather",
+			Pickup_Powerup,
+			use_Breather,
+			drop_General,
+			nil,
+			"items/pkup.wav",
+			"models/items/breather/tris.md2", shared.EF_ROTATE,
+			"",
+			"p_rebreather",
+			"Rebreather",
+			2,
+			60,
+			"",
+			IT_STAY_COOP | IT_POWERUP | IT_INSTANT_USE,
+			0,
+			nil,
+			0,
+			"items/airout.wav",
+		},
+
+		/* QUAKED item_enviro (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"item_enviro",
+			Pickup_Powerup,
+			use_Envirosuit,
+			drop_General,
+			nil,
+			"items/pkup.wav",
+			"models/items/enviro/tris.md2", shared.EF_ROTATE,
+			"",
+			"p_envirosuit",
+			"Environment Suit",
+			2,
+			60,
+			"",
+			IT_STAY_COOP | IT_POWERUP | IT_INSTANT_USE,
+			0,
+			nil,
+			0,
+			"items/airout.wav",
+		},
+
+		/* QUAKED item_ancient_head (.3 .3 1) (-16 -16 -16) (16 16 16)
+		   Special item that gives +2 to maximum health */
+		{
+			"item_ancient_head",
+			Pickup_AncientHead,
+			nil,
+			nil,
+			nil,
+			"items/pkup.wav",
+			"models/items/c_head/tris.md2", shared.EF_ROTATE,
+			"",
+			"i_fixme",
+			"Ancient Head",
+			2,
+			60,
+			"",
+			0,
+			0,
+			nil,
+			0,
+			"",
+		},
+
+		/* QUAKED item_adrenaline (.3 .3 1) (-16 -16 -16) (16 16 16)
+		   gives +1 to maximum health */
+		{
+			"item_adrenaline",
+			Pickup_Adrenaline,
+			nil,
+			nil,
+			nil,
+			"items/pkup.wav",
+			"models/items/adrenal/tris.md2", shared.EF_ROTATE,
+			"",
+			"p_adrenaline",
+			"Adrenaline",
+			2,
+			60,
+			"",
+			0,
+			0,
+			nil,
+			0,
+			"",
+		},
+
+		/* QUAKED item_bandolier (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"item_bandolier",
+			Pickup_Bandolier,
+			nil,
+			nil,
+			nil,
+			"items/pkup.wav",
+			"models/items/band/tris.md2", shared.EF_ROTATE,
+			"",
+			"p_bandolier",
+			"Bandolier",
+			2,
+			60,
+			"",
+			0,
+			0,
+			nil,
+			0,
+			"",
+		},
+
+		/* QUAKED item_pack (.3 .3 1) (-16 -16 -16) (16 16 16) */
+		{
+			"item_pack",
+			Pickup_Pack,
+			nil,
+			nil,
+			nil,
+			"items/pkup.wav",
+			"models/items/pack/tris.md2", shared.EF_ROTATE,
+			"",
+			"i_pack",
+			"Ammo Pack",
+			2,
+			180,
+			"",
+			0,
+			0,
+			nil,
+			0,
+			"",
+		},
+
+		/* QUAKED key_data_cd (0 .5 .8) (-16 -16 -16) (16 16 16)
+		   key for computer centers */
+		{
+			"key_data_cd",
+			Pickup_Key,
+			nil,
+			drop_General,
+			nil,
+			"items/pkup.wav",
+			"models/items/keys/data_cd/tris.md2", shared.EF_ROTATE,
+			"",
+			"k_datacd",
+			"Data CD",
+			2,
+			0,
+			"",
+			IT_STAY_COOP | IT_KEY,
+			0,
+			nil,
+			0,
+			"",
+		},
+
+		/* QUAKED key_power_cube (0 .5 .8) (-16 -16 -16) (16 16 16) TRIGGER_SPAWN NO_TOUCH
+		   warehouse circuits */
+		{
+			"key_power_cube",
+			Pickup_Key,
+			nil,
+			drop_General,
+			nil,
+			"items/pkup.wav",
+			"models/items/keys/power/tris.md2", shared.EF_ROTATE,
+			"",
+			"k_powercube",
+			"Power Cube",
+			2,
+			0,
+			"",
+			IT_STAY_COOP | IT_KEY,
+			0,
+			nil,
+			0,
+			"",
+		},
+
+		/* QUAKED key_pyramid (0 .5 .8) (-16 -16 -16) (16 16 16)
+		   key for the entrance of jail3 */
+		{
+			"key_pyramid",
+			Pickup_Key,
+			nil,
+			drop_General,
+			nil,
+			"items/pkup.wav",
+			"models/items/keys/pyramid/tris.md2", shared.EF_ROTATE,
+			"",
+			"k_pyramid",
+			"Pyramid Key",
+			2,
+			0,
+			"",
+			IT_STAY_COOP | IT_KEY,
+			0,
+			nil,
+			0,
+			"",
+		},
+
+		/* QUAKED key_data_spinner (0 .5 .8) (-16 -16 -16) (16 16 16)
+		   key for the city computer */
+		{
+			"key_data_spinner",
+			Pickup_Key,
+			nil,
+			drop_General,
+			nil,
+			"items/pkup.wav",
+			"models/items/keys/spinner/tris.md2", shared.EF_ROTATE,
+			"",
+			"k_dataspin",
+			"Data Spinner",
+			2,
+			0,
+			"",
+			IT_STAY_COOP | IT_KEY,
+			0,
+			nil,
+			0,
+			"",
+		},
+
+		/* QUAKED key_pass (0 .5 .8) (-16 -16 -16) (16 16 16)
+		   security pass for the security level */
+		{
+			"key_pass",
+			Pickup_Key,
+			nil,
+			drop_General,
+			nil,
+			"items/pkup.wav",
+			"models/items/keys/pass/tris.md2", shared.EF_ROTATE,
+			"",
+			"k_security",
+			"Security Pass",
+			2,
+			0,
+			"",
+			IT_STAY_COOP | IT_KEY,
+			0,
+			nil,
+			0,
+			"",
+		},
+
+		/* QUAKED key_blue_key (0 .5 .8) (-16 -16 -16) (16 16 16)
+		   normal door key - blue */
+		{
+			"key_blue_key",
+			Pickup_Key,
+			nil,
+			drop_General,
+			nil,
+			"items/pkup.wav",
+			"models/items/keys/key/tris.md2", shared.EF_ROTATE,
+			"",
+			"k_bluekey",
+			"Blue Key",
+			2,
+			0,
+			"",
+			IT_STAY_COOP | IT_KEY,
+			0,
+			nil,
+			0,
+			"",
+		},
+
+		/* QUAKED key_red_key (0 .5 .8) (-16 -16 -16) (16 16 16)
+		   normal door key - red */
+		{
+			"key_red_key",
+			Pickup_Key,
+			nil,
+			drop_General,
+			nil,
+			"items/pkup.wav",
+			"models/items/keys/red_key/tris.md2", shared.EF_ROTATE,
+			"",
+			"k_redkey",
+			"Red Key",
+			2,
+			0,
+			"",
+			IT_STAY_COOP | IT_KEY,
+			0,
+			nil,
+			0,
+			"",
+		},
+
+		/* QUAKED key_commander_head (0 .5 .8) (-16 -16 -16) (16 16 16)
+		   tank commander's head */
+		{
+			"key_commander_head",
+			Pickup_Key,
+			nil,
+			drop_General,
+			nil,
+			"items/pkup.wav",
+			"models/monsters/commandr/head/tris.md2", shared.EF_GIB,
+			"",
+			"k_comhead",
+			"Commander's Head",
+			2,
+			0,
+			"",
+			IT_STAY_COOP | IT_KEY,
+			0,
+			nil,
+			0,
+			"",
+		},
+
+		/* QUAKED key_airstrike_target (0 .5 .8) (-16 -16 -16) (16 16 16) */
+		{
+			"key_airstrike_target",
+			Pickup_Key,
+			nil,
+			drop_General,
+			nil,
+			"items/pkup.wav",
+			"models/items/keys/target/tris.md2", shared.EF_ROTATE,
+			"",
+			"i_airstrike",
+			"Airstrike Marker",
+			2,
+			0,
+			"",
+			IT_STAY_COOP | IT_KEY,
+			0,
+			nil,
+			0,
+			"",
+		},
+
+		{
+			"",
+			Pickup_Health,
+			nil,
+			nil,
+			nil,
+			"items/pkup.wav",
+			"", 0,
+			"",
+			"i_health",
+			"Health",
+			3,
+			0,
+			"",
+			0,
+			0,
+			nil,
+			0,
+			"items/s_health.wav items/n_health.wav items/l_health.wav items/m_health.wav",
+		},
+	}
 }
 
 /*
@@ -25,7 +25,11 @@
  */
 package game
 
-import "goquake2/shared"
+import (
+	"fmt"
+	"goquake2/shared"
+	"strings"
+)
 
 /* ====================================================================== */
 
@@ -44,6 +48,119 @@ func (G *qGame) clientEndServerFrames() {
 	}
 }
 
+/*
+ * Returns the created target changelevel
+ */
+func (G *qGame) createTargetChangeLevel(mapname string) *edict_t {
+	ent, _ := G.gSpawn()
+	ent.Classname = "target_changelevel"
+	G.level.nextmap = mapname
+	ent.Map = G.level.nextmap
+	return ent
+}
+
+/*
+ * The timelimit or fraglimit has been exceeded
+ */
+func (G *qGame) endDMLevel() {
+	/* stay on same level flag */
+	if (G.dmflags.Int() & shared.DF_SAME_LEVEL) != 0 {
+		G.beginIntermission(G.createTargetChangeLevel(G.level.mapname))
+		return
+	}
+
+	/* see if it's in the map list */
+	if len(G.sv_maplist.String) > 0 {
+		maps := strings.FieldsFunc(G.sv_maplist.String, func(r rune) bool {
+			return strings.ContainsRune(" ,\n\r", r)
+		})
+
+		for i, t := range maps {
+			if strings.EqualFold(t, G.level.mapname) {
+				/* it's in the list, go to the next one */
+				if i+1 < len(maps) {
+					G.beginIntermission(G.createTargetChangeLevel(maps[i+1]))
+				} else {
+					/* end of list, go to first one */
+					G.beginIntermission(G.createTargetChangeLevel(maps[0]))
+				}
+
+				return
+			}
+		}
+	}
+
+	if len(G.level.nextmap) > 0 { /* go to a specific map */
+		G.beginIntermission(G.createTargetChangeLevel(G.level.nextmap))
+	} else { /* search for a changelevel */
+		ent := G.gFind(nil, "Classname", "target_changelevel")
+
+		if ent == nil {
+			/* the map designer didn't include a changelevel,
+			   so create a fake ent that goes back to the same level */
+			G.beginIntermission(G.createTargetChangeLevel(G.level.mapname))
+			return
+		}
+
+		G.beginIntermission(ent)
+	}
+}
+
+func (G *qGame) checkDMRules() {
+	if G.level.intermissiontime != 0 {
+		return
+	}
+
+	if !G.deathmatch.Bool() {
+		return
+	}
+
+	if G.timelimit.Bool() {
+		if G.level.time >= G.timelimit.Float()*60 {
+			G.gi.Bprintf(shared.PRINT_HIGH, "Timelimit hit.\n")
+			G.endDMLevel()
+			return
+		}
+	}
+
+	if G.fraglimit.Bool() {
+		for i := 0; i < G.maxclients.Int(); i++ {
+			cl := &G.game.clients[i]
+
+			if !G.g_edicts[i+1].inuse || cl.resp.spectator {
+				continue
+			}
+
+			if cl.resp.score >= G.fraglimit.Int() {
+				G.gi.Bprintf(shared.PRINT_HIGH, "Fraglimit hit.\n")
+				G.endDMLevel()
+				return
+			}
+		}
+	}
+}
+
+func (G *qGame) exitLevel() {
+	G.gi.AddCommandString(fmt.Sprintf("gamemap \"%s\"\n", G.level.changemap))
+	G.level.changemap = ""
+	G.level.exitintermission = false
+	G.level.intermissiontime = 0
+	G.clientEndServerFrames()
+
+	/* clear some things before going to next level */
+	for i := 0; i < G.maxclients.Int(); i++ {
+		ent := &G.g_edicts[1+i]
+
+		if !ent.inuse {
+			continue
+		}
+
+		if ent.Health > ent.client.pers.max_health {
+			ent.Health = ent.client.pers.max_health
+		}
+	}
+}
+
 /*
  * Advances the world by 0.1 seconds
  */
@@ -60,11 +177,11 @@ func (G *qGame) RunFrame() error {
 	/* choose a client for monsters to target this frame */
 	//  AI_SetSightClient();
 
-	//  /* exit intermissions */
-	//  if (level.exitintermission) {
-	// 	 ExitLevel();
-	// 	 return;
-	//  }
+	/* exit intermissions */
+	if G.level.exitintermission {
+		G.exitLevel()
+		return nil
+	}
 
 	/* treat each object in turn
 	even the world gets a chance
@@ -94,7 +211,9 @@ func (G *qGame) RunFrame() error {
 		// 	 }
 
 		if (i > 0) && (i <= G.maxclients.Int()) {
-			G.clientBeginServerFrame(ent)
+			if err := G.clientBeginServerFrame(ent); err != nil {
+				return err
+			}
 			continue
 		}
 
@@ -104,7 +223,7 @@ func (G *qGame) RunFrame() error {
 	}
 
 	/* see if it is time to end a deathmatch */
-	//  CheckDMRules();
+	G.checkDMRules()
 
 	/* see if needpass needs updated */
 	//  CheckNeedPass();
@@ -29,6 +29,51 @@ import "goquake2/shared"
 
 /* ===================================================== */
 
+func use_Areaportal(ent, other, activator *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	ent.count ^= 1 /* toggle state */
+	if err := G.gi.SetAreaPortalState(ent.Style, ent.count != 0); err != nil {
+		G.gi.Dprintf("%s: %v\n", ent.Classname, err)
+	}
+}
+
+/*
+ * QUAKED func_areaportal (0 0 0) ?
+ *
+ * This is a non-visible object that divides the world into
+ * areas that are seperated when this portal is not activated.
+ * Usually enclosed in the middle of a door.
+ */
+func spFuncAreaportal(ent *edict_t, G *qGame) error {
+	if ent == nil {
+		return nil
+	}
+
+	ent.use = use_Areaportal
+	ent.count = 0 /* always start closed; */
+	return nil
+}
+
+/* ===================================================== */
+
+func (G *qGame) becomeExplosion1(self *edict_t) {
+	if self == nil {
+		return
+	}
+
+	G.gi.WriteUByte(shared.SvcTempEntity)
+	G.gi.WriteUByte(shared.TE_EXPLOSION1)
+	G.gi.WritePosition(self.s.Origin[:])
+	G.gi.Multicast(self.s.Origin[:], shared.MULTICAST_PVS)
+
+	G.gFreeEdict(self)
+}
+
+/* ===================================================== */
+
 /*
  * QUAKED path_corner (.5 .3 0) (-8 -8 -8) (8 8 8) TELEPORT
  * Target: next path corner
@@ -235,7 +235,7 @@ func (G *qGame) monster_death_use(self *edict_t) {
 	self.monsterinfo.aiflags &= AI_GOOD_GUY
 
 	if self.item != nil {
-		// Drop_Item(self, self.item)
+		G.dropItem(self, self.item)
 		self.item = nil
 	}
 
@@ -283,7 +283,7 @@ func (G *qGame) monster_start(self *edict_t) bool {
 	self.svflags |= shared.SVF_MONSTER
 	self.s.Renderfx |= shared.RF_FRAMELERP
 	self.takedamage = DAMAGE_AIM
-	// self.air_finished = level.time + 12
+	self.air_finished = G.level.time + 12
 	// self.use = monster_use
 
 	if self.max_health == 0 {
@@ -92,6 +92,46 @@ func (G *qGame) svImpact(e1 *edict_t, trace *shared.Trace_t) {
 	}
 }
 
+const STOP_EPSILON = 0.1
+
+/*
+ * Slide off of the impacting object
+ * returns the blocked flags (1 = floor,
+ * 2 = step / wall)
+ */
+func clipVelocity(in, normal, out []float32, overbounce float32) int {
+	blocked := 0
+
+	if normal[2] > 0 {
+		blocked |= 1 /* floor */
+	}
+
+	if normal[2] == 0 {
+		blocked |= 2 /* step */
+	}
+
+	backoff := shared.DotProduct(in, normal) * overbounce
+
+	for i := 0; i < 3; i++ {
+		change := normal[i] * backoff
+		out[i] = in[i] - change
+
+		if (out[i] > -STOP_EPSILON) && (out[i] < STOP_EPSILON) {
+			out[i] = 0
+		}
+	}
+
+	return blocked
+}
+
+func (G *qGame) svAddGravity(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	ent.velocity[2] -= ent.gravity * G.sv_gravity.Float() * FRAMETIME
+}
+
 /* ================================================================== */
 
 /* PUSHMOVE */
@@ -407,13 +447,12 @@ func (G *qGame) svPhysics_Pusher(ent *edict_t) {
 			}
 		}
 
-		// 	 /* if the pusher has a "blocked" function, call it
-		// 		otherwise, just stay in place until the obstacle
-		// 		is gone */
-		// 	 if (part->blocked)
-		// 	 {
-		// 		 part->blocked(part, obstacle);
-		// 	 }
+		/* if the pusher has a "blocked" function, call it
+		otherwise, just stay in place until the obstacle
+		is gone */
+		if part.blocked != nil {
+			part.blocked(part, G.obstacle, G)
+		}
 	} else {
 		/* the move succeeded, so call all think functions */
 		for part := ent; part != nil; part = part.teamchain {
@@ -445,14 +484,6 @@ func (G *qGame) svPhysics_None(ent *edict_t) {
  * When onground, do nothing.
  */
 func (G *qGame) svPhysics_Toss(ent *edict_t) {
-	//  trace_t trace;
-	//  vec3_t move;
-	//  float backoff;
-	//  edict_t *slave;
-	//  qboolean wasinwater;
-	//  qboolean isinwater;
-	//  vec3_t old_origin;
-
 	if ent == nil {
 		return
 	}
@@ -482,14 +513,15 @@ func (G *qGame) svPhysics_Toss(ent *edict_t) {
 		return
 	}
 
-	//  VectorCopy(ent->s.origin, old_origin);
+	old_origin := make([]float32, 3)
+	copy(old_origin, ent.s.Origin[:])
 
 	G.svCheckVelocity(ent)
 
 	/* add gravity */
 	if (ent.movetype != MOVETYPE_FLY) &&
 		(ent.movetype != MOVETYPE_FLYMISSILE) {
-		// 	 SV_AddGravity(ent);
+		G.svAddGravity(ent)
 	}
 
 	/* move angles */
@@ -505,58 +537,49 @@ func (G *qGame) svPhysics_Toss(ent *edict_t) {
 	}
 
 	if trace.Fraction < 1 {
-		// 	 if (ent.movetype == MOVETYPE_BOUNCE) {
-		// 		 backoff = 1.5;
-		// 	 } else {
-		// 		 backoff = 1;
-		// 	 }
+		var backoff float32 = 1
+		if ent.movetype == MOVETYPE_BOUNCE {
+			backoff = 1.5
+		}
 
-		// 	 ClipVelocity(ent->velocity, trace.plane.normal, ent->velocity, backoff);
+		clipVelocity(ent.velocity[:], trace.Plane.Normal[:], ent.velocity[:], backoff)
 
-		// 	 /* stop if on ground */
-		// 	 if (trace.plane.normal[2] > 0.7)
-		// 	 {
-		// 		 if ((ent->velocity[2] < 60) || (ent->movetype != MOVETYPE_BOUNCE))
-		// 		 {
-		// 			 ent->groundentity = trace.ent;
-		// 			 ent->groundentity_linkcount = trace.ent->linkcount;
-		// 			 VectorCopy(vec3_origin, ent->velocity);
-		// 			 VectorCopy(vec3_origin, ent->avelocity);
-		// 		 }
-		// 	 }
+		/* stop if on ground */
+		if trace.Plane.Normal[2] > 0.7 {
+			if (ent.velocity[2] < 60) || (ent.movetype != MOVETYPE_BOUNCE) {
+				ground := trace.Ent.(*edict_t)
+				ent.groundentity = ground
+				ent.groundentity_linkcount = ground.linkcount
+				copy(ent.velocity[:], []float32{0, 0, 0})
+				copy(ent.avelocity[:], []float32{0, 0, 0})
+			}
+		}
 	}
 
-	//  /* check for water transition */
-	//  wasinwater = (ent->watertype & MASK_WATER);
-	//  ent->watertype = gi.pointcontents(ent->s.origin);
-	//  isinwater = ent->watertype & MASK_WATER;
+	/* check for water transition */
+	wasinwater := (ent.watertype & shared.MASK_WATER) != 0
+	ent.watertype = G.gi.Pointcontents(ent.s.Origin[:])
+	isinwater := (ent.watertype & shared.MASK_WATER) != 0
 
-	//  if (isinwater)
-	//  {
-	// 	 ent->waterlevel = 1;
-	//  }
-	//  else
-	//  {
-	ent.waterlevel = 0
-	//  }
+	if isinwater {
+		ent.waterlevel = 1
+	} else {
+		ent.waterlevel = 0
+	}
 
-	//  if (!wasinwater && isinwater)
-	//  {
-	// 	 gi.positioned_sound(old_origin, g_edicts, CHAN_AUTO,
-	// 			 gi.soundindex("misc/h2ohit1.wav"), 1, 1, 0);
-	//  }
-	//  else if (wasinwater && !isinwater)
-	//  {
-	// 	 gi.positioned_sound(ent->s.origin, g_edicts, CHAN_AUTO,
-	// 			 gi.soundindex("misc/h2ohit1.wav"), 1, 1, 0);
-	//  }
+	if !wasinwater && isinwater {
+		G.gi.PositionedSound(old_origin, &G.g_edicts[0], shared.CHAN_AUTO,
+			G.gi.Soundindex("misc/h2ohit1.wav"), 1, 1, 0)
+	} else if wasinwater && !isinwater {
+		G.gi.PositionedSound(ent.s.Origin[:], &G.g_edicts[0], shared.CHAN_AUTO,
+			G.gi.Soundindex("misc/h2ohit1.wav"), 1, 1, 0)
+	}
 
-	//  /* move teamslaves */
-	//  for (slave = ent->teamchain; slave; slave = slave->teamchain)
-	//  {
-	// 	 VectorCopy(ent->s.origin, slave->s.origin);
-	// 	 gi.linkentity(slave);
-	//  }
+	/* move teamslaves */
+	for slave := ent.teamchain; slave != nil; slave = slave.teamchain {
+		copy(slave.s.Origin[:], ent.s.Origin[:])
+		G.gi.Linkentity(slave)
+	}
 }
 
 func (G *qGame) svPhysics_Step(ent *edict_t) {
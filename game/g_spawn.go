@@ -34,26 +34,33 @@ import (
 )
 
 var spawns = map[string]func(ent *edict_t, G *qGame) error{
-	"item_health":            spItemHealth,
-	"item_health_small":      spItemHealthSmall,
-	"item_health_large":      spItemHealthLarge,
-	"item_health_mega":       spItemHealthMega,
-	"info_player_start":      spInfoPlayerStart,
-	"info_player_deathmatch": spInfoPlayerDeathmatch,
-	"func_door":              spFuncDoor,
-	"func_timer":             spFuncTimer,
-	"trigger_always":         spTriggerAlways,
-	"trigger_once":           spTriggerOnce,
-	"trigger_multiple":       spTriggerMultiple,
-	"trigger_relay":          spTriggerRelay,
-	"target_speaker":         spTargetSpeaker,
-	"target_explosion":       spTargetExplosion,
-	"worldspawn":             spWorldspawn,
-	"light":                  spLight,
-	"point_combat":           spPointCombat,
-	"path_corner":            spPathCorner,
-	"misc_teleporter_dest":   spMiscTeleporterDest,
-	"monster_soldier":        spMonsterSoldier,
+	"item_health":              spItemHealth,
+	"item_health_small":        spItemHealthSmall,
+	"item_health_large":        spItemHealthLarge,
+	"item_health_mega":         spItemHealthMega,
+	"info_player_start":        spInfoPlayerStart,
+	"info_player_deathmatch":   spInfoPlayerDeathmatch,
+	"info_player_coop":         spInfoPlayerCoop,
+	"info_player_intermission": spInfoPlayerIntermission,
+	"func_plat":                spFuncPlat,
+	"func_door":                spFuncDoor,
+	"func_areaportal":          spFuncAreaportal,
+	"func_timer":               spFuncTimer,
+	"trigger_always":           spTriggerAlways,
+	"trigger_once":             spTriggerOnce,
+	"trigger_multiple":         spTriggerMultiple,
+	"trigger_relay":            spTriggerRelay,
+	"target_speaker":           spTargetSpeaker,
+	"target_explosion":         spTargetExplosion,
+	"target_changelevel":       spTargetChangelevel,
+	"worldspawn":               spWorldspawn,
+	"light":                    spLight,
+	"point_combat":             spPointCombat,
+	"path_corner":              spPathCorner,
+	"misc_teleporter_dest":     spMiscTeleporterDest,
+	"monster_soldier":          spMonsterSoldier,
+	"monster_soldier_light":    spMonsterSoldierLight,
+	"monster_soldier_ss":       spMonsterSoldierSs,
 }
 
 /*
@@ -241,13 +248,13 @@ func (G *qGame) SpawnEntities(mapname, entities, spawnpoint string) error {
 		//  gi.cvar_forceset("skill", va("%f", skill_level));
 	}
 
-	//  SaveClientData();
+	G.saveClientData()
 
 	//  gi.FreeTags(TAG_LEVEL);
 
 	G.level = level_locals_t{}
-	G.g_edicts = make([]edict_t, G.maxentities.Int())
 	for i := range G.g_edicts {
+		G.g_edicts[i] = edict_t{}
 		G.g_edicts[i].index = i
 	}
 
@@ -546,7 +553,7 @@ func spWorldspawn(ent *edict_t, G *qGame) error {
 
 	/* reserve some spots for dead
 	player bodies for coop / deathmatch */
-	//  InitBodyQue();
+	G.initBodyQue()
 
 	/* set configstrings for items */
 	G.setItemNames()
@@ -599,9 +606,9 @@ func spWorldspawn(ent *edict_t, G *qGame) error {
 	// 	 gi.cvar_set("sv_gravity", st.gravity);
 	//  }
 
-	//  snd_fry = gi.soundindex("player/fry.wav"); /* standing in lava / slime */
+	G.snd_fry = G.gi.Soundindex("player/fry.wav") /* standing in lava / slime */
 
-	//  PrecacheItem(FindItem("Blaster"));
+	G.precacheItem(G.findItem("Blaster"))
 
 	G.gi.Soundindex("player/lava1.wav")
 	G.gi.Soundindex("player/lava2.wav")
@@ -0,0 +1,255 @@
+/*
+ * Copyright (C) 1997-2001 Id Software, Inc.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or (at
+ * your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ *
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 59 Temple Place - Suite 330, Boston, MA
+ * 02111-1307, USA.
+ *
+ * =======================================================================
+ *
+ * Game side of server CMDs. At this time only the ipfilter.
+ *
+ * =======================================================================
+ */
+package game
+
+import (
+	"encoding/binary"
+	"fmt"
+	"goquake2/shared"
+	"os"
+	"strings"
+)
+
+func (G *qGame) svcmd_Test_f() {
+	G.gi.Cprintf(nil, shared.PRINT_HIGH, "Svcmd_Test_f()\n")
+}
+
+/*
+ * PACKET FILTERING
+ *
+ * You can add or remove addresses from the filter list with:
+ *
+ * addip <ip>
+ * removeip <ip>
+ *
+ * The ip address is specified in dot format, and any unspecified
+ * digits will match any value, so you can specify an entire class C
+ * network with "addip 192.246.40".
+ *
+ * Removeip will only remove an address specified exactly the same way.
+ * You cannot addip a subnet, then removeip a single host.
+ *
+ * listip
+ * Prints the current list of filters.
+ *
+ * writeip
+ * Dumps "addip <ip>" commands to listip.cfg so it can be execed at a
+ * later date.  The filter lists are not saved and restored by default,
+ * because I beleive it would cause too much confusion.
+ *
+ * filterban <0 or 1>
+ * If 1 (the default), then ip addresses matching the current list will
+ * be prohibited from entering the game.  This is the default setting.
+ * If 0, then only addresses matching the list will be allowed.  This
+ * lets you easily set up a private game, or a game that only allows
+ * players from your local network.
+ */
+
+const MAX_IPFILTERS = 1024
+
+type ipfilter_t struct {
+	mask    uint32
+	compare uint32
+}
+
+func (G *qGame) stringToFilter(s string, f *ipfilter_t) bool {
+	var b [4]byte
+	var m [4]byte
+
+	for i := 0; i < 4; i++ {
+		if len(s) == 0 || (s[0] < '0') || (s[0] > '9') {
+			G.gi.Cprintf(nil, shared.PRINT_HIGH, "Bad filter address: %s\n", s)
+			return false
+		}
+
+		num := 0
+		for len(s) > 0 && s[0] >= '0' && s[0] <= '9' {
+			num = num*10 + int(s[0]-'0')
+			s = s[1:]
+		}
+
+		b[i] = byte(num)
+
+		if b[i] != 0 {
+			m[i] = 255
+		}
+
+		if len(s) == 0 {
+			break
+		}
+
+		s = s[1:]
+	}
+
+	f.mask = binary.LittleEndian.Uint32(m[:])
+	f.compare = binary.LittleEndian.Uint32(b[:])
+
+	return true
+}
+
+func (G *qGame) svFilterPacket(from string) bool {
+	var m [4]byte
+
+	i := 0
+	p := from
+
+	for len(p) > 0 && i < 4 {
+		m[i] = 0
+
+		for len(p) > 0 && p[0] >= '0' && p[0] <= '9' {
+			m[i] = m[i]*10 + (p[0] - '0')
+			p = p[1:]
+		}
+
+		if len(p) == 0 || (p[0] == ':') {
+			break
+		}
+
+		i++
+		p = p[1:]
+	}
+
+	in := binary.LittleEndian.Uint32(m[:])
+
+	for _, f := range G.ipfilters {
+		if (in & f.mask) == f.compare {
+			return G.filterban.Bool()
+		}
+	}
+
+	return !G.filterban.Bool()
+}
+
+func (G *qGame) svcmd_AddIP_f() {
+	if G.gi.Argc() < 3 {
+		G.gi.Cprintf(nil, shared.PRINT_HIGH, "Usage:  addip <ip-mask>\n")
+		return
+	}
+
+	i := 0
+	for i = 0; i < len(G.ipfilters); i++ {
+		if G.ipfilters[i].compare == 0xffffffff {
+			break /* free spot */
+		}
+	}
+
+	if i == len(G.ipfilters) {
+		if len(G.ipfilters) == MAX_IPFILTERS {
+			G.gi.Cprintf(nil, shared.PRINT_HIGH, "IP filter list is full\n")
+			return
+		}
+
+		G.ipfilters = append(G.ipfilters, ipfilter_t{})
+	}
+
+	if !G.stringToFilter(G.gi.Argv(2), &G.ipfilters[i]) {
+		G.ipfilters[i].compare = 0xffffffff
+	}
+}
+
+func (G *qGame) svcmd_RemoveIP_f() {
+	if G.gi.Argc() < 3 {
+		G.gi.Cprintf(nil, shared.PRINT_HIGH, "Usage:  sv removeip <ip-mask>\n")
+		return
+	}
+
+	var f ipfilter_t
+	if !G.stringToFilter(G.gi.Argv(2), &f) {
+		return
+	}
+
+	for i := range G.ipfilters {
+		if (G.ipfilters[i].mask == f.mask) &&
+			(G.ipfilters[i].compare == f.compare) {
+			G.ipfilters = append(G.ipfilters[:i], G.ipfilters[i+1:]...)
+			G.gi.Cprintf(nil, shared.PRINT_HIGH, "Removed.\n")
+			return
+		}
+	}
+
+	G.gi.Cprintf(nil, shared.PRINT_HIGH, "Didn't find %s.\n", G.gi.Argv(2))
+}
+
+func (G *qGame) svcmd_ListIP_f() {
+	var b [4]byte
+
+	G.gi.Cprintf(nil, shared.PRINT_HIGH, "Filter list:\n")
+
+	for _, f := range G.ipfilters {
+		binary.LittleEndian.PutUint32(b[:], f.compare)
+		G.gi.Cprintf(nil, shared.PRINT_HIGH, "%3d.%3d.%3d.%3d\n", b[0], b[1], b[2], b[3])
+	}
+}
+
+func (G *qGame) svcmd_WriteIP_f() {
+	var b [4]byte
+
+	game := G.gi.Cvar("game", "", 0)
+
+	var name string
+	if len(game.String) == 0 {
+		name = shared.BASEDIRNAME + "/listip.cfg"
+	} else {
+		name = game.String + "/listip.cfg"
+	}
+
+	G.gi.Cprintf(nil, shared.PRINT_HIGH, "Writing %s.\n", name)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("set filterban %d\n", G.filterban.Int()))
+
+	for _, f := range G.ipfilters {
+		binary.LittleEndian.PutUint32(b[:], f.compare)
+		sb.WriteString(fmt.Sprintf("sv addip %d.%d.%d.%d\n", b[0], b[1], b[2], b[3]))
+	}
+
+	if err := os.WriteFile(name, []byte(sb.String()), 0644); err != nil {
+		G.gi.Cprintf(nil, shared.PRINT_HIGH, "Couldn't open %s\n", name)
+	}
+}
+
+/*
+ * ServerCommand will be called when an "sv" command is issued.
+ * The game can issue gi.argc() / gi.argv() commands to get the
+ * rest of the parameters
+ */
+func (G *qGame) ServerCommand() {
+	cmd := G.gi.Argv(1)
+
+	if strings.EqualFold(cmd, "test") {
+		G.svcmd_Test_f()
+	} else if strings.EqualFold(cmd, "addip") {
+		G.svcmd_AddIP_f()
+	} else if strings.EqualFold(cmd, "removeip") {
+		G.svcmd_RemoveIP_f()
+	} else if strings.EqualFold(cmd, "listip") {
+		G.svcmd_ListIP_f()
+	} else if strings.EqualFold(cmd, "writeip") {
+		G.svcmd_WriteIP_f()
+	} else {
+		G.gi.Cprintf(nil, shared.PRINT_HIGH, "Unknown server command \"%s\"\n", cmd)
+	}
+}
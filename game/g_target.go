@@ -90,10 +90,10 @@ func target_explosion_explode(self *edict_t, G *qGame) {
 	}
 
 	println("target_explosion_explode")
-	//  gi.WriteByte(svc_temp_entity);
-	//  gi.WriteByte(TE_EXPLOSION1);
-	//  gi.WritePosition(self->s.origin);
-	//  gi.multicast(self->s.origin, MULTICAST_PHS);
+	G.gi.WriteUByte(shared.SvcTempEntity)
+	G.gi.WriteUByte(shared.TE_EXPLOSION1)
+	G.gi.WritePosition(self.s.Origin[:])
+	G.gi.Multicast(self.s.Origin[:], shared.MULTICAST_PHS)
 
 	//  T_RadiusDamage(self, self->activator, self->dmg, NULL,
 	// 		 self->dmg + 40, MOD_EXPLOSIVE);
@@ -132,3 +132,70 @@ func spTargetExplosion(ent *edict_t, G *qGame) error {
 	ent.svflags = shared.SVF_NOCLIENT
 	return nil
 }
+
+/* ========================================================== */
+
+/*
+ * QUAKED target_changelevel (1 0 0) (-8 -8 -8) (8 8 8)
+ * Changes level to "map" when fired
+ */
+func use_target_changelevel(self, other, activator *edict_t, G *qGame) {
+	if self == nil || other == nil || G == nil {
+		return
+	}
+
+	if G.level.intermissiontime != 0 {
+		return /* already activated */
+	}
+
+	if !G.deathmatch.Bool() && !G.coop.Bool() {
+		if G.g_edicts[1].Health <= 0 {
+			return
+		}
+	}
+
+	/* if noexit, do a ton of damage to other */
+	if G.deathmatch.Bool() && (G.dmflags.Int()&shared.DF_ALLOW_EXIT) == 0 &&
+		(other != &G.g_edicts[0]) {
+		G.tDamage(other, self, self, []float32{0, 0, 0}, other.s.Origin[:],
+			[]float32{0, 0, 0}, 10*other.max_health, 1000, 0, MOD_EXIT)
+		return
+	}
+
+	/* if multiplayer, let everyone know who hit the exit */
+	if G.deathmatch.Bool() {
+		if activator != nil && activator.client != nil {
+			G.gi.Bprintf(shared.PRINT_HIGH, "%s exited the level.\n",
+				activator.client.pers.netname)
+		}
+	}
+
+	/* if going to a new unit, clear cross triggers */
+	if strings.Contains(self.Map, "*") {
+		G.game.serverflags &^= SFL_CROSS_TRIGGER_MASK
+	}
+
+	G.beginIntermission(self)
+}
+
+func spTargetChangelevel(ent *edict_t, G *qGame) error {
+	if ent == nil || G == nil {
+		return nil
+	}
+
+	if len(ent.Map) == 0 {
+		G.gi.Dprintf("target_changelevel with no map at %s\n", vtos(ent.s.Origin[:]))
+		G.gFreeEdict(ent)
+		return nil
+	}
+
+	/* Mapquirk for secret exists in fact1 and fact3 */
+	if strings.EqualFold(G.level.mapname, "fact1") &&
+		strings.EqualFold(ent.Map, "fact3") {
+		ent.Map = "fact3$secret1"
+	}
+
+	ent.use = use_target_changelevel
+	ent.svflags = shared.SVF_NOCLIENT
+	return nil
+}
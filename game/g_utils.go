@@ -82,6 +82,43 @@ func (G *qGame) gFind(from *edict_t, fname, match string) *edict_t {
 	return nil
 }
 
+/*
+ * Returns entities that have origins
+ * within a spherical area
+ */
+func (G *qGame) findradius(from *edict_t, org []float32, rad float32) *edict_t {
+	eorg := make([]float32, 3)
+
+	var index int = 0
+	if from != nil {
+		index = from.index + 1
+	}
+
+	for ; index < G.num_edicts; index++ {
+		e := &G.g_edicts[index]
+		if !e.inuse {
+			continue
+		}
+
+		if e.solid == shared.SOLID_NOT {
+			continue
+		}
+
+		for j := 0; j < 3; j++ {
+			eorg[j] = org[j] - (e.s.Origin[j] +
+				(e.mins[j]+e.maxs[j])*0.5)
+		}
+
+		if shared.VectorLength(eorg) > rad {
+			continue
+		}
+
+		return e
+	}
+
+	return nil
+}
+
 /*
  * Searches all active entities for
  * the next one that holds the matching
@@ -177,14 +214,14 @@ func (G *qGame) gUseTargets(ent, activator *edict_t) {
 
 	/* print the message */
 	if len(ent.Message) > 0 && (activator.svflags&shared.SVF_MONSTER) == 0 {
-		//  gi.centerprintf(activator, "%s", ent->message);
-
-		//  if (ent->noise_index) {
-		// 	 gi.sound(activator, CHAN_AUTO, ent->noise_index, 1, ATTN_NORM, 0);
-		//  } else {
-		// 	 gi.sound(activator, CHAN_AUTO, gi.soundindex(
-		// 					 "misc/talk1.wav"), 1, ATTN_NORM, 0);
-		//  }
+		G.gi.Centerprintf(activator, "%s", ent.Message)
+
+		if ent.noise_index != 0 {
+			G.gi.Sound(activator, shared.CHAN_AUTO, ent.noise_index, 1, shared.ATTN_NORM, 0)
+		} else {
+			G.gi.Sound(activator, shared.CHAN_AUTO, G.gi.Soundindex(
+				"misc/talk1.wav"), 1, shared.ATTN_NORM, 0)
+		}
 	}
 
 	/* kill killtargets */
@@ -192,7 +229,7 @@ func (G *qGame) gUseTargets(ent, activator *edict_t) {
 		var t *edict_t
 
 		for {
-			t = G.gFind(t, "TargetName", ent.Killtarget)
+			t = G.gFind(t, "Targetname", ent.Killtarget)
 			if t == nil {
 				break
 			}
@@ -224,20 +261,17 @@ func (G *qGame) gUseTargets(ent, activator *edict_t) {
 		var t *edict_t
 
 		for {
-			t = G.gFind(t, "TargetName", ent.Target)
+			t = G.gFind(t, "Targetname", ent.Target)
 			if t == nil {
 				break
 			}
 
-			// 	 while ((t = G_Find(t, FOFS(targetname), ent->target)))
-			// 	 {
-			// 		 /* doors fire area portals in a specific way */
-			// 		 if (!Q_stricmp(t->classname, "func_areaportal") &&
-			// 			 (!Q_stricmp(ent->classname, "func_door") ||
-			// 			  !Q_stricmp(ent->classname, "func_door_rotating")))
-			// 		 {
-			// 			 continue;
-			// 		 }
+			/* doors fire area portals in a specific way */
+			if t.Classname == "func_areaportal" &&
+				(ent.Classname == "func_door" ||
+					ent.Classname == "func_door_rotating") {
+				continue
+			}
 
 			if t == ent {
 				G.gi.Dprintf("WARNING: Entity used itself.\n")
@@ -263,6 +297,16 @@ func vtos(v []float32) string {
 	return fmt.Sprintf("(%v %v %v)", int(v[0]), int(v[1]), int(v[2]))
 }
 
+/*
+ * Go func values can't be compared with ==,
+ * so callbacks like item->pickup == Pickup_Health
+ * are matched by code address, the same way the
+ * savegame code looks up its function list.
+ */
+func sameFunc(a, b interface{}) bool {
+	return reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+}
+
 var VEC_UP = []float32{0, -1, 0}
 var MOVEDIR_UP = []float32{0, 0, 1}
 var VEC_DOWN = []float32{0, -2, 0}
@@ -25,7 +25,177 @@
  */
 package game
 
-import "goquake2/shared"
+import (
+	"goquake2/shared"
+	"math"
+	"strings"
+)
+
+/*
+ * This is an internal support routine
+ * used for bullet/pellet based weapons.
+ */
+func (G *qGame) fire_lead(self *edict_t, start, aimdir []float32, damage, kick,
+	te_impact, hspread, vspread, mod int) {
+	dir := make([]float32, 3)
+	forward := make([]float32, 3)
+	right := make([]float32, 3)
+	up := make([]float32, 3)
+	end := make([]float32, 3)
+	water_start := make([]float32, 3)
+	water := false
+	content_mask := shared.MASK_SHOT | shared.MASK_WATER
+
+	if self == nil {
+		return
+	}
+
+	tr := G.gi.Trace(self.s.Origin[:], nil, nil, start, self, shared.MASK_SHOT)
+
+	if !(tr.Fraction < 1.0) {
+		vectoangles(aimdir, dir)
+		shared.AngleVectors(dir, forward, right, up)
+
+		r := shared.Crandk() * float32(hspread)
+		u := shared.Crandk() * float32(vspread)
+		shared.VectorMA(start, 8192, forward, end)
+		shared.VectorMA(end, r, right, end)
+		shared.VectorMA(end, u, up, end)
+
+		if (G.gi.Pointcontents(start) & shared.MASK_WATER) != 0 {
+			water = true
+			copy(water_start, start)
+			content_mask &^= shared.MASK_WATER
+		}
+
+		tr = G.gi.Trace(start, nil, nil, end, self, content_mask)
+
+		/* see if we hit water */
+		if (tr.Contents & shared.MASK_WATER) != 0 {
+			water = true
+			copy(water_start, tr.Endpos[:])
+
+			if shared.VectorCompare(start, tr.Endpos[:]) == 0 {
+				var color int
+				if (tr.Contents & shared.CONTENTS_WATER) != 0 {
+					if tr.Surface != nil && tr.Surface.Name == "*brwater" {
+						color = shared.SPLASH_BROWN_WATER
+					} else {
+						color = shared.SPLASH_BLUE_WATER
+					}
+				} else if (tr.Contents & shared.CONTENTS_SLIME) != 0 {
+					color = shared.SPLASH_SLIME
+				} else if (tr.Contents & shared.CONTENTS_LAVA) != 0 {
+					color = shared.SPLASH_LAVA
+				} else {
+					color = shared.SPLASH_UNKNOWN
+				}
+
+				if color != shared.SPLASH_UNKNOWN {
+					G.gi.WriteUByte(shared.SvcTempEntity)
+					G.gi.WriteUByte(shared.TE_SPLASH)
+					G.gi.WriteUByte(8)
+					G.gi.WritePosition(tr.Endpos[:])
+					G.gi.WriteDir(tr.Plane.Normal[:])
+					G.gi.WriteUByte(color)
+					G.gi.Multicast(tr.Endpos[:], shared.MULTICAST_PVS)
+				}
+
+				/* change bullet's course when it enters water */
+				shared.VectorSubtract(end, start, dir)
+				vectoangles(dir, dir)
+				shared.AngleVectors(dir, forward, right, up)
+				r = shared.Crandk() * float32(hspread) * 2
+				u = shared.Crandk() * float32(vspread) * 2
+				shared.VectorMA(water_start, 8192, forward, end)
+				shared.VectorMA(end, r, right, end)
+				shared.VectorMA(end, u, up, end)
+			}
+
+			/* re-trace ignoring water this time */
+			tr = G.gi.Trace(water_start, nil, nil, end, self, shared.MASK_SHOT)
+		}
+	}
+
+	/* send gun puff / flash */
+	if !(tr.Surface != nil && (tr.Surface.Flags&shared.SURF_SKY) != 0) {
+		if tr.Fraction < 1.0 {
+			other, _ := tr.Ent.(*edict_t)
+			if other != nil && other.takedamage != 0 {
+				G.tDamage(other, self, self, aimdir, tr.Endpos[:], tr.Plane.Normal[:],
+					damage, kick, DAMAGE_BULLET, mod)
+			} else {
+				if tr.Surface == nil || !strings.HasPrefix(tr.Surface.Name, "sky") {
+					G.gi.WriteUByte(shared.SvcTempEntity)
+					G.gi.WriteUByte(te_impact)
+					G.gi.WritePosition(tr.Endpos[:])
+					G.gi.WriteDir(tr.Plane.Normal[:])
+					G.gi.Multicast(tr.Endpos[:], shared.MULTICAST_PVS)
+
+					if self.client != nil {
+						G.playerNoise(self, tr.Endpos[:], PNOISE_IMPACT)
+					}
+				}
+			}
+		}
+	}
+
+	/* if went through water, determine
+	   where the end and make a bubble trail */
+	if water {
+		pos := make([]float32, 3)
+
+		shared.VectorSubtract(tr.Endpos[:], water_start, dir)
+		shared.VectorNormalize(dir)
+		shared.VectorMA(tr.Endpos[:], -2, dir, pos)
+
+		if (G.gi.Pointcontents(pos) & shared.MASK_WATER) != 0 {
+			copy(tr.Endpos[:], pos)
+		} else {
+			passent, _ := tr.Ent.(*edict_t)
+			tr = G.gi.Trace(pos, nil, nil, water_start, passent, shared.MASK_WATER)
+		}
+
+		shared.VectorAdd(water_start, tr.Endpos[:], pos)
+		shared.VectorScale(pos, 0.5, pos)
+
+		G.gi.WriteUByte(shared.SvcTempEntity)
+		G.gi.WriteUByte(shared.TE_BUBBLETRAIL)
+		G.gi.WritePosition(water_start)
+		G.gi.WritePosition(tr.Endpos[:])
+		G.gi.Multicast(pos, shared.MULTICAST_PVS)
+	}
+}
+
+/*
+ * Fires a single round. Used for machinegun and
+ * chaingun.  Would be fine for pistols, rifles, etc....
+ */
+func (G *qGame) fire_bullet(self *edict_t, start, aimdir []float32, damage,
+	kick, hspread, vspread, mod int) {
+	if self == nil {
+		return
+	}
+
+	G.fire_lead(self, start, aimdir, damage, kick, shared.TE_GUNSHOT,
+		hspread, vspread, mod)
+}
+
+/*
+ * Shoots shotgun pellets. Used
+ * by shotgun and super shotgun.
+ */
+func (G *qGame) fire_shotgun(self *edict_t, start, aimdir []float32, damage,
+	kick, hspread, vspread, count, mod int) {
+	if self == nil {
+		return
+	}
+
+	for i := 0; i < count; i++ {
+		G.fire_lead(self, start, aimdir, damage, kick, shared.TE_SHOTGUN,
+			hspread, vspread, mod)
+	}
+}
 
 /*
  * Fires a single blaster bolt.
@@ -70,20 +240,17 @@ func blaster_touch(self, other *edict_t, plane *shared.Cplane_t, surf *shared.Cs
 				[]float32{0, 0, 0}, self.Dmg, 1, DAMAGE_ENERGY, mod)
 		}
 	} else {
-		// 	 gi.WriteByte(svc_temp_entity);
-		// 	 gi.WriteByte(TE_BLASTER);
-		// 	 gi.WritePosition(self->s.origin);
+		G.gi.WriteUByte(shared.SvcTempEntity)
+		G.gi.WriteUByte(shared.TE_BLASTER)
+		G.gi.WritePosition(self.s.Origin[:])
 
-		// 	 if (!plane)
-		// 	 {
-		// 		 gi.WriteDir(vec3_origin);
-		// 	 }
-		// 	 else
-		// 	 {
-		// 		 gi.WriteDir(plane->normal);
-		// 	 }
+		if plane == nil {
+			G.gi.WriteDir([]float32{0, 0, 0})
+		} else {
+			G.gi.WriteDir(plane.Normal[:])
+		}
 
-		// 	 gi.multicast(self->s.origin, MULTICAST_PVS);
+		G.gi.Multicast(self.s.Origin[:], shared.MULTICAST_PVS)
 	}
 
 	G.gFreeEdict(self)
@@ -122,7 +289,7 @@ func (G *qGame) fire_blaster(self *edict_t, start, dir []float32, damage,
 		bolt.maxs[i] = 0
 	}
 	bolt.s.Modelindex = G.gi.Modelindex("models/objects/laser/tris.md2")
-	// 	bolt->s.sound = gi.soundindex("misc/lasfly.wav");
+	bolt.s.Sound = G.gi.Soundindex("misc/lasfly.wav")
 	bolt.owner = self
 	bolt.touch = blaster_touch
 	bolt.nextthink = G.level.time + 2
@@ -130,10 +297,9 @@ func (G *qGame) fire_blaster(self *edict_t, start, dir []float32, damage,
 	bolt.Dmg = damage
 	bolt.Classname = "bolt"
 
-	// 	if (hyper)
-	// 	{
-	// 		bolt->spawnflags = 1;
-	// 	}
+	if hyper {
+		bolt.Spawnflags = 1
+	}
 
 	G.gi.Linkentity(bolt)
 
@@ -144,7 +310,617 @@ func (G *qGame) fire_blaster(self *edict_t, start, dir []float32, damage,
 	tr := G.gi.Trace(self.s.Origin[:], nil, nil, bolt.s.Origin[:], bolt, shared.MASK_SHOT)
 
 	if tr.Fraction < 1.0 {
-		// 		VectorMA(bolt->s.origin, -10, dir, bolt->s.origin);
-		// 		bolt->touch(bolt, tr.ent, NULL, NULL);
+		shared.VectorMA(bolt.s.Origin[:], -10, dir, bolt.s.Origin[:])
+		other, _ := tr.Ent.(*edict_t)
+		bolt.touch(bolt, other, nil, nil, G)
+	}
+}
+
+/*
+ * Explodes a grenade, either on
+ * impact or after its timer ran out.
+ */
+func grenade_Explode(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	if ent.owner != nil && ent.owner.client != nil {
+		G.playerNoise(ent.owner, ent.s.Origin[:], PNOISE_IMPACT)
+	}
+
+	/* FIXME: if we are onground then raise our Z just a bit since we are a point? */
+	var mod int
+	if ent.enemy != nil {
+		v := make([]float32, 3)
+		dir := make([]float32, 3)
+
+		shared.VectorAdd(ent.enemy.mins[:], ent.enemy.maxs[:], v)
+		shared.VectorMA(ent.enemy.s.Origin[:], 0.5, v, v)
+		shared.VectorSubtract(ent.s.Origin[:], v, v)
+		points := float32(ent.Dmg) - 0.5*shared.VectorLength(v)
+		shared.VectorSubtract(ent.enemy.s.Origin[:], ent.s.Origin[:], dir)
+
+		if (ent.Spawnflags & 1) != 0 {
+			mod = MOD_HANDGRENADE
+		} else {
+			mod = MOD_GRENADE
+		}
+
+		G.tDamage(ent.enemy, ent, ent.owner, dir, ent.s.Origin[:], []float32{0, 0, 0},
+			int(points), int(points), DAMAGE_RADIUS, mod)
+	}
+
+	if (ent.Spawnflags & 2) != 0 {
+		mod = MOD_HELD_GRENADE
+	} else if (ent.Spawnflags & 1) != 0 {
+		mod = MOD_HG_SPLASH
+	} else {
+		mod = MOD_G_SPLASH
+	}
+
+	G.tRadiusDamage(ent, ent.owner, float32(ent.Dmg), ent.enemy, ent.dmg_radius, mod)
+
+	origin := make([]float32, 3)
+	shared.VectorMA(ent.s.Origin[:], -0.02, ent.velocity[:], origin)
+	G.gi.WriteUByte(shared.SvcTempEntity)
+
+	if ent.waterlevel != 0 {
+		if ent.groundentity != nil {
+			G.gi.WriteUByte(shared.TE_GRENADE_EXPLOSION_WATER)
+		} else {
+			G.gi.WriteUByte(shared.TE_ROCKET_EXPLOSION_WATER)
+		}
+	} else {
+		if ent.groundentity != nil {
+			G.gi.WriteUByte(shared.TE_GRENADE_EXPLOSION)
+		} else {
+			G.gi.WriteUByte(shared.TE_ROCKET_EXPLOSION)
+		}
+	}
+
+	G.gi.WritePosition(origin)
+	G.gi.Multicast(ent.s.Origin[:], shared.MULTICAST_PHS)
+
+	G.gFreeEdict(ent)
+}
+
+func grenade_Touch(ent, other *edict_t, plane *shared.Cplane_t, surf *shared.Csurface_t, G *qGame) {
+	if ent == nil || other == nil || G == nil { /* plane and surf can be NULL */
+		return
+	}
+
+	if other == ent.owner {
+		return
+	}
+
+	if surf != nil && (surf.Flags&shared.SURF_SKY) != 0 {
+		G.gFreeEdict(ent)
+		return
+	}
+
+	if other.takedamage == 0 {
+		if (ent.Spawnflags & 1) != 0 {
+			if shared.Frandk() > 0.5 {
+				G.gi.Sound(ent, shared.CHAN_VOICE, G.gi.Soundindex("weapons/hgrenb1a.wav"), 1, shared.ATTN_NORM, 0)
+			} else {
+				G.gi.Sound(ent, shared.CHAN_VOICE, G.gi.Soundindex("weapons/hgrenb2a.wav"), 1, shared.ATTN_NORM, 0)
+			}
+		} else {
+			G.gi.Sound(ent, shared.CHAN_VOICE, G.gi.Soundindex("weapons/grenlb1b.wav"), 1, shared.ATTN_NORM, 0)
+		}
+
+		return
+	}
+
+	ent.enemy = other
+	grenade_Explode(ent, G)
+}
+
+func (G *qGame) fire_grenade(self *edict_t, start, aimdir []float32, damage,
+	speed int, timer, damage_radius float32) {
+
+	if self == nil {
+		return
+	}
+
+	dir := make([]float32, 3)
+	forward := make([]float32, 3)
+	right := make([]float32, 3)
+	up := make([]float32, 3)
+
+	vectoangles(aimdir, dir)
+	shared.AngleVectors(dir, forward, right, up)
+
+	grenade, _ := G.gSpawn()
+	copy(grenade.s.Origin[:], start)
+	shared.VectorScale(aimdir, float32(speed), grenade.velocity[:])
+	shared.VectorMA(grenade.velocity[:], 200+shared.Crandk()*10.0, up, grenade.velocity[:])
+	shared.VectorMA(grenade.velocity[:], shared.Crandk()*10.0, right, grenade.velocity[:])
+	copy(grenade.avelocity[:], []float32{300, 300, 300})
+	grenade.movetype = MOVETYPE_BOUNCE
+	grenade.clipmask = shared.MASK_SHOT
+	grenade.solid = shared.SOLID_BBOX
+	grenade.s.Effects |= shared.EF_GRENADE
+	for i := range grenade.mins {
+		grenade.mins[i] = 0
+		grenade.maxs[i] = 0
+	}
+	grenade.s.Modelindex = G.gi.Modelindex("models/objects/grenade/tris.md2")
+	grenade.owner = self
+	grenade.touch = grenade_Touch
+	grenade.nextthink = G.level.time + timer
+	grenade.think = grenade_Explode
+	grenade.Dmg = damage
+	grenade.dmg_radius = damage_radius
+	grenade.Classname = "grenade"
+
+	G.gi.Linkentity(grenade)
+}
+
+func (G *qGame) fire_grenade2(self *edict_t, start, aimdir []float32, damage,
+	speed int, timer, damage_radius float32, held bool) {
+
+	if self == nil {
+		return
+	}
+
+	dir := make([]float32, 3)
+	forward := make([]float32, 3)
+	right := make([]float32, 3)
+	up := make([]float32, 3)
+
+	vectoangles(aimdir, dir)
+	shared.AngleVectors(dir, forward, right, up)
+
+	grenade, _ := G.gSpawn()
+	copy(grenade.s.Origin[:], start)
+	shared.VectorScale(aimdir, float32(speed), grenade.velocity[:])
+	shared.VectorMA(grenade.velocity[:], 200+shared.Crandk()*10.0, up, grenade.velocity[:])
+	shared.VectorMA(grenade.velocity[:], shared.Crandk()*10.0, right, grenade.velocity[:])
+	copy(grenade.avelocity[:], []float32{300, 300, 300})
+	grenade.movetype = MOVETYPE_BOUNCE
+	grenade.clipmask = shared.MASK_SHOT
+	grenade.solid = shared.SOLID_BBOX
+	grenade.s.Effects |= shared.EF_GRENADE
+	for i := range grenade.mins {
+		grenade.mins[i] = 0
+		grenade.maxs[i] = 0
+	}
+	grenade.s.Modelindex = G.gi.Modelindex("models/objects/grenade2/tris.md2")
+	grenade.owner = self
+	grenade.touch = grenade_Touch
+	grenade.nextthink = G.level.time + timer
+	grenade.think = grenade_Explode
+	grenade.Dmg = damage
+	grenade.dmg_radius = damage_radius
+	grenade.Classname = "hgrenade"
+
+	if held {
+		grenade.Spawnflags = 3
+	} else {
+		grenade.Spawnflags = 1
+	}
+
+	grenade.s.Sound = G.gi.Soundindex("weapons/hgrenc1b.wav")
+
+	if timer <= 0.0 {
+		grenade_Explode(grenade, G)
+	} else {
+		G.gi.Sound(self, shared.CHAN_WEAPON, G.gi.Soundindex("weapons/hgrent1a.wav"),
+			1, shared.ATTN_NORM, 0)
+		G.gi.Linkentity(grenade)
+	}
+}
+
+func rocket_touch(ent, other *edict_t, plane *shared.Cplane_t, surf *shared.Csurface_t, G *qGame) {
+	if ent == nil || other == nil || G == nil { /* plane and surf can be NULL */
+		return
+	}
+
+	if other == ent.owner {
+		return
+	}
+
+	if surf != nil && (surf.Flags&shared.SURF_SKY) != 0 {
+		G.gFreeEdict(ent)
+		return
+	}
+
+	if ent.owner != nil && ent.owner.client != nil {
+		G.playerNoise(ent.owner, ent.s.Origin[:], PNOISE_IMPACT)
+	}
+
+	/* calculate position for the explosion entity */
+	origin := make([]float32, 3)
+	shared.VectorMA(ent.s.Origin[:], -0.02, ent.velocity[:], origin)
+
+	if other.takedamage != 0 {
+		if plane != nil {
+			G.tDamage(other, ent, ent.owner, ent.velocity[:], ent.s.Origin[:],
+				plane.Normal[:], ent.Dmg, 0, 0, MOD_ROCKET)
+		} else {
+			G.tDamage(other, ent, ent.owner, ent.velocity[:], ent.s.Origin[:],
+				[]float32{0, 0, 0}, ent.Dmg, 0, 0, MOD_ROCKET)
+		}
+	} else {
+		/* don't throw any debris in net games */
+		// if (!deathmatch->value && !coop->value)
+		// {
+		// 	if ((surf) && !(surf->flags &
+		// 		  (SURF_WARP | SURF_TRANS33 | SURF_TRANS66 | SURF_FLOWING)))
+		// 	{
+		// 		n = randk() % 5;
+
+		// 		while (n--)
+		// 		{
+		// 			ThrowDebris(ent, "models/objects/debris2/tris.md2",
+		// 					2, ent->s.origin);
+		// 		}
+		// 	}
+		// }
+	}
+
+	G.tRadiusDamage(ent, ent.owner, float32(ent.radius_dmg), other,
+		ent.dmg_radius, MOD_R_SPLASH)
+
+	G.gi.WriteUByte(shared.SvcTempEntity)
+
+	if ent.waterlevel != 0 {
+		G.gi.WriteUByte(shared.TE_ROCKET_EXPLOSION_WATER)
+	} else {
+		G.gi.WriteUByte(shared.TE_ROCKET_EXPLOSION)
+	}
+
+	G.gi.WritePosition(origin)
+	G.gi.Multicast(ent.s.Origin[:], shared.MULTICAST_PHS)
+
+	G.gFreeEdict(ent)
+}
+
+func (G *qGame) fire_rocket(self *edict_t, start, dir []float32, damage,
+	speed int, damage_radius float32, radius_damage int) {
+
+	if self == nil {
+		return
+	}
+
+	rocket, _ := G.gSpawn()
+	copy(rocket.s.Origin[:], start)
+	copy(rocket.movedir[:], dir)
+	vectoangles(dir, rocket.s.Angles[:])
+	shared.VectorScale(dir, float32(speed), rocket.velocity[:])
+	rocket.movetype = MOVETYPE_FLYMISSILE
+	rocket.clipmask = shared.MASK_SHOT
+	rocket.solid = shared.SOLID_BBOX
+	rocket.s.Effects |= shared.EF_ROCKET
+	for i := range rocket.mins {
+		rocket.mins[i] = 0
+		rocket.maxs[i] = 0
+	}
+	rocket.s.Modelindex = G.gi.Modelindex("models/objects/rocket/tris.md2")
+	rocket.owner = self
+	rocket.touch = rocket_touch
+	rocket.nextthink = G.level.time + 8000/float32(speed)
+	rocket.think = gFreeEdictFunc
+	rocket.Dmg = damage
+	rocket.radius_dmg = radius_damage
+	rocket.dmg_radius = damage_radius
+	rocket.s.Sound = G.gi.Soundindex("weapons/rockfly.wav")
+	rocket.Classname = "rocket"
+
+	// if (self->client) {
+	// 	check_dodge(self, rocket->s.origin, dir, speed);
+	// }
+
+	G.gi.Linkentity(rocket)
+}
+
+func (G *qGame) fire_rail(self *edict_t, start, aimdir []float32, damage, kick int) {
+	if self == nil {
+		return
+	}
+
+	from := make([]float32, 3)
+	end := make([]float32, 3)
+
+	shared.VectorMA(start, 8192, aimdir, end)
+	copy(from, start)
+	ignore := self
+	water := false
+	mask := shared.MASK_SHOT | shared.CONTENTS_SLIME | shared.CONTENTS_LAVA
+
+	var tr shared.Trace_t
+	for ignore != nil {
+		tr = G.gi.Trace(from, nil, nil, end, ignore, mask)
+
+		if (tr.Contents & (shared.CONTENTS_SLIME | shared.CONTENTS_LAVA)) != 0 {
+			mask &^= (shared.CONTENTS_SLIME | shared.CONTENTS_LAVA)
+			water = true
+		} else {
+			other, _ := tr.Ent.(*edict_t)
+			if other == nil {
+				break
+			}
+
+			if (other.svflags&shared.SVF_MONSTER) != 0 || (other.client != nil) ||
+				(other.solid == shared.SOLID_BBOX) {
+				ignore = other
+			} else {
+				ignore = nil
+			}
+
+			if (other != self) && (other.takedamage != 0) {
+				G.tDamage(other, self, self, aimdir, tr.Endpos[:],
+					tr.Plane.Normal[:], damage, kick, 0, MOD_RAILGUN)
+			} else {
+				ignore = nil
+			}
+		}
+
+		copy(from, tr.Endpos[:])
+	}
+
+	/* send gun puff / flash */
+	G.gi.WriteUByte(shared.SvcTempEntity)
+	G.gi.WriteUByte(shared.TE_RAILTRAIL)
+	G.gi.WritePosition(start)
+	G.gi.WritePosition(tr.Endpos[:])
+	G.gi.Multicast(self.s.Origin[:], shared.MULTICAST_PHS)
+
+	if water {
+		G.gi.WriteUByte(shared.SvcTempEntity)
+		G.gi.WriteUByte(shared.TE_RAILTRAIL)
+		G.gi.WritePosition(start)
+		G.gi.WritePosition(tr.Endpos[:])
+		G.gi.Multicast(tr.Endpos[:], shared.MULTICAST_PHS)
+	}
+
+	if self.client != nil {
+		G.playerNoise(self, tr.Endpos[:], PNOISE_IMPACT)
+	}
+}
+
+func bfg_explode(self *edict_t, G *qGame) {
+	if self == nil || G == nil {
+		return
+	}
+
+	if self.s.Frame == 0 {
+		/* the BFG effect */
+		v := make([]float32, 3)
+
+		var ent *edict_t = nil
+		for {
+			ent = G.findradius(ent, self.s.Origin[:], self.dmg_radius)
+			if ent == nil {
+				break
+			}
+
+			if ent.takedamage == 0 {
+				continue
+			}
+
+			if ent == self.owner {
+				continue
+			}
+
+			if !G.canDamage(ent, self) {
+				continue
+			}
+
+			if !G.canDamage(ent, self.owner) {
+				continue
+			}
+
+			shared.VectorAdd(ent.mins[:], ent.maxs[:], v)
+			shared.VectorMA(ent.s.Origin[:], 0.5, v, v)
+			shared.VectorSubtract(self.s.Origin[:], v, v)
+			dist := shared.VectorLength(v)
+			points := float32(self.radius_dmg) *
+				(1.0 - float32(math.Sqrt(float64(dist/self.dmg_radius))))
+
+			if ent == self.owner {
+				points = points * 0.5
+			}
+
+			G.gi.WriteUByte(shared.SvcTempEntity)
+			G.gi.WriteUByte(shared.TE_BFG_EXPLOSION)
+			G.gi.WritePosition(ent.s.Origin[:])
+			G.gi.Multicast(ent.s.Origin[:], shared.MULTICAST_PHS)
+			G.tDamage(ent, self, self.owner, self.velocity[:], ent.s.Origin[:],
+				[]float32{0, 0, 0}, int(points), 0, DAMAGE_ENERGY, MOD_BFG_EFFECT)
+		}
+	}
+
+	self.nextthink = G.level.time + FRAMETIME
+	self.s.Frame++
+
+	if self.s.Frame == 5 {
+		self.think = gFreeEdictFunc
 	}
 }
+
+func bfg_touch(self, other *edict_t, plane *shared.Cplane_t, surf *shared.Csurface_t, G *qGame) {
+	if self == nil || other == nil || G == nil { /* plane and surf can be NULL */
+		return
+	}
+
+	if other == self.owner {
+		return
+	}
+
+	if surf != nil && (surf.Flags&shared.SURF_SKY) != 0 {
+		G.gFreeEdict(self)
+		return
+	}
+
+	if self.owner != nil && self.owner.client != nil {
+		G.playerNoise(self.owner, self.s.Origin[:], PNOISE_IMPACT)
+	}
+
+	/* core explosion - prevents firing it into the wall/floor */
+	if other.takedamage != 0 {
+		if plane != nil {
+			G.tDamage(other, self, self.owner, self.velocity[:], self.s.Origin[:],
+				plane.Normal[:], 200, 0, 0, MOD_BFG_BLAST)
+		} else {
+			G.tDamage(other, self, self.owner, self.velocity[:], self.s.Origin[:],
+				[]float32{0, 0, 0}, 200, 0, 0, MOD_BFG_BLAST)
+		}
+	}
+
+	G.tRadiusDamage(self, self.owner, 200, other, 100, MOD_BFG_BLAST)
+
+	G.gi.Sound(self, shared.CHAN_VOICE, G.gi.Soundindex("weapons/bfg__x1b.wav"), 1, shared.ATTN_NORM, 0)
+	self.solid = shared.SOLID_NOT
+	self.touch = nil
+	shared.VectorMA(self.s.Origin[:], -1*FRAMETIME, self.velocity[:], self.s.Origin[:])
+	for i := range self.velocity {
+		self.velocity[i] = 0
+	}
+	self.s.Modelindex = G.gi.Modelindex("sprites/s_bfg3.sp2")
+	self.s.Frame = 0
+	self.s.Sound = 0
+	self.s.Effects &^= shared.EF_ANIM_ALLFAST
+	self.think = bfg_explode
+	self.nextthink = G.level.time + FRAMETIME
+	self.enemy = other
+
+	G.gi.WriteUByte(shared.SvcTempEntity)
+	G.gi.WriteUByte(shared.TE_BFG_BIGEXPLOSION)
+	G.gi.WritePosition(self.s.Origin[:])
+	G.gi.Multicast(self.s.Origin[:], shared.MULTICAST_PVS)
+}
+
+func bfg_think(self *edict_t, G *qGame) {
+	if self == nil || G == nil {
+		return
+	}
+
+	point := make([]float32, 3)
+	dir := make([]float32, 3)
+	start := make([]float32, 3)
+	end := make([]float32, 3)
+
+	dmg := 10
+	if G.deathmatch.Bool() {
+		dmg = 5
+	}
+
+	var ent *edict_t = nil
+	for {
+		ent = G.findradius(ent, self.s.Origin[:], 256)
+		if ent == nil {
+			break
+		}
+
+		if ent == self {
+			continue
+		}
+
+		if ent == self.owner {
+			continue
+		}
+
+		if ent.takedamage == 0 {
+			continue
+		}
+
+		if (ent.svflags&shared.SVF_MONSTER) == 0 && (ent.client == nil) &&
+			(ent.Classname != "misc_explobox") {
+			continue
+		}
+
+		shared.VectorMA(ent.absmin[:], 0.5, ent.size[:], point)
+		shared.VectorSubtract(point, self.s.Origin[:], dir)
+		shared.VectorNormalize(dir)
+
+		ignore := self
+		copy(start, self.s.Origin[:])
+		shared.VectorMA(start, 2048, dir, end)
+
+		var tr shared.Trace_t
+		for {
+			tr = G.gi.Trace(start, nil, nil, end, ignore,
+				shared.CONTENTS_SOLID|shared.CONTENTS_MONSTER|shared.CONTENTS_DEADMONSTER)
+
+			other, _ := tr.Ent.(*edict_t)
+			if other == nil {
+				break
+			}
+
+			/* hurt it if we can */
+			if (other.takedamage != 0) && (other.flags&FL_IMMUNE_LASER) == 0 &&
+				(other != self.owner) {
+				G.tDamage(other, self, self.owner, dir, tr.Endpos[:],
+					[]float32{0, 0, 0}, dmg, 1, DAMAGE_ENERGY, MOD_BFG_LASER)
+			}
+
+			/* if we hit something that's not a monster or player we're done */
+			if (other.svflags&shared.SVF_MONSTER) == 0 && (other.client == nil) {
+				G.gi.WriteUByte(shared.SvcTempEntity)
+				G.gi.WriteUByte(shared.TE_LASER_SPARKS)
+				G.gi.WriteUByte(4)
+				G.gi.WritePosition(tr.Endpos[:])
+				G.gi.WriteDir(tr.Plane.Normal[:])
+				G.gi.WriteUByte(self.s.Skinnum)
+				G.gi.Multicast(tr.Endpos[:], shared.MULTICAST_PVS)
+				break
+			}
+
+			ignore = other
+			copy(start, tr.Endpos[:])
+		}
+
+		G.gi.WriteUByte(shared.SvcTempEntity)
+		G.gi.WriteUByte(shared.TE_BFG_LASER)
+		G.gi.WritePosition(self.s.Origin[:])
+		G.gi.WritePosition(tr.Endpos[:])
+		G.gi.Multicast(self.s.Origin[:], shared.MULTICAST_PHS)
+	}
+
+	self.nextthink = G.level.time + FRAMETIME
+}
+
+func (G *qGame) fire_bfg(self *edict_t, start, dir []float32, damage,
+	speed int, damage_radius float32) {
+
+	if self == nil {
+		return
+	}
+
+	bfg, _ := G.gSpawn()
+	copy(bfg.s.Origin[:], start)
+	copy(bfg.movedir[:], dir)
+	vectoangles(dir, bfg.s.Angles[:])
+	shared.VectorScale(dir, float32(speed), bfg.velocity[:])
+	bfg.movetype = MOVETYPE_FLYMISSILE
+	bfg.clipmask = shared.MASK_SHOT
+	bfg.solid = shared.SOLID_BBOX
+	bfg.s.Effects |= shared.EF_BFG | shared.EF_ANIM_ALLFAST
+	for i := range bfg.mins {
+		bfg.mins[i] = 0
+		bfg.maxs[i] = 0
+	}
+	bfg.s.Modelindex = G.gi.Modelindex("sprites/s_bfg1.sp2")
+	bfg.owner = self
+	bfg.touch = bfg_touch
+	bfg.nextthink = G.level.time + 8000/float32(speed)
+	bfg.think = gFreeEdictFunc
+	bfg.radius_dmg = damage
+	bfg.dmg_radius = damage_radius
+	bfg.Classname = "bfg blast"
+	bfg.s.Sound = G.gi.Soundindex("weapons/bfg__l1a.wav")
+
+	bfg.think = bfg_think
+	bfg.nextthink = G.level.time + FRAMETIME
+	bfg.teammaster = bfg
+	bfg.teamchain = nil
+
+	// if (self->client) {
+	// 	check_dodge(self, bfg->s.origin, dir, speed);
+	// }
+
+	G.gi.Linkentity(bfg)
+}
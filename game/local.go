@@ -57,6 +57,17 @@ const (
 	FL_COOP_TAKEN    = 0x00002000 /* Another client has already taken it */
 	FL_RESPAWN       = 0x80000000 /* used for item respawning */
 
+	/* game.serverflags values */
+	SFL_CROSS_TRIGGER_1    = 0x00000001
+	SFL_CROSS_TRIGGER_2    = 0x00000002
+	SFL_CROSS_TRIGGER_3    = 0x00000004
+	SFL_CROSS_TRIGGER_4    = 0x00000008
+	SFL_CROSS_TRIGGER_5    = 0x00000010
+	SFL_CROSS_TRIGGER_6    = 0x00000020
+	SFL_CROSS_TRIGGER_7    = 0x00000040
+	SFL_CROSS_TRIGGER_8    = 0x00000080
+	SFL_CROSS_TRIGGER_MASK = 0x000000ff
+
 	FRAMETIME = 0.1
 
 	/* memory tags to allow dynamic memory to be cleaned up */
@@ -118,6 +129,11 @@ const (
 	ARMOR_COMBAT = 2
 	ARMOR_BODY   = 3
 	ARMOR_SHARD  = 4
+
+	/* power armor types */
+	POWER_ARMOR_NONE   = 0
+	POWER_ARMOR_SCREEN = 1
+	POWER_ARMOR_SHIELD = 2
 )
 
 type weaponstate_t int
@@ -250,11 +266,11 @@ type level_locals_t struct {
 	nextmap    string /* go here when fraglimit is hit */
 
 	/* intermission state */
-	intermissiontime float32 /* time the intermission was started */
-	//    char *changemap;
-	//    int exitintermission;
-	//    vec3_t intermission_origin;
-	//    vec3_t intermission_angle;
+	intermissiontime    float32 /* time the intermission was started */
+	changemap           string
+	exitintermission    bool
+	intermission_origin [3]float32
+	intermission_angle  [3]float32
 
 	sight_client *edict_t /* changed once each frame for coop games */
 
@@ -277,9 +293,9 @@ type level_locals_t struct {
 	killed_monsters int
 
 	current_entity *edict_t /* entity running from G_RunFrame */
-	//    int body_que; /* dead bodies */
+	body_que       int      /* dead bodies */
 
-	//    int power_cubes; /* ugly necessity for coop */
+	power_cubes int /* ugly necessity for coop */
 }
 
 /* spawn_temp_t is only used to hold entity field values that
@@ -294,7 +310,7 @@ type spawn_temp_t struct {
 
 	Lip int
 	//    int distance;
-	//    int height;
+	Height    int
 	Noise     string
 	pausetime float32
 	//    char *item;
@@ -385,7 +401,7 @@ type monsterinfo_t struct {
 	// void (*dodge)(edict_t *self, edict_t *other, float eta);
 	// void (*attack)(edict_t *self);
 	// void (*melee)(edict_t *self);
-	// void (*sight)(edict_t *self, edict_t *other);
+	sight       func(self, other *edict_t, G *qGame)
 	checkattack func(self *edict_t, G *qGame) bool
 
 	pausetime       float32
@@ -400,8 +416,8 @@ type monsterinfo_t struct {
 	idle_time float32
 	linkcount int
 
-	// int power_armor_type;
-	// int power_armor_power;
+	power_armor_type  int
+	power_armor_power int
 }
 
 func (G *monsterinfo_t) copy(other monsterinfo_t) {
@@ -417,7 +433,7 @@ func (G *monsterinfo_t) copy(other monsterinfo_t) {
 	// void (*dodge)(edict_t *self, edict_t *other, float eta);
 	// void (*attack)(edict_t *self);
 	// void (*melee)(edict_t *self);
-	// void (*sight)(edict_t *self, edict_t *other);
+	G.sight = other.sight
 	G.checkattack = other.checkattack
 	G.pausetime = other.pausetime
 	G.attack_finished = other.attack_finished
@@ -429,8 +445,8 @@ func (G *monsterinfo_t) copy(other monsterinfo_t) {
 	// int lefty;
 	G.idle_time = other.idle_time
 	G.linkcount = other.linkcount
-	// int power_armor_type;
-	// int power_armor_power;
+	G.power_armor_type = other.power_armor_type
+	G.power_armor_power = other.power_armor_power
 }
 
 const (
@@ -485,6 +501,13 @@ const (
 	DAMAGE_BULLET        = 0x00000010 /* damage is from a bullet (used for ricochets) */
 	DAMAGE_NO_PROTECTION = 0x00000020 /* armor, shields, invulnerability, and godmode have no effect */
 
+	DEFAULT_BULLET_HSPREAD           = 300
+	DEFAULT_BULLET_VSPREAD           = 500
+	DEFAULT_SHOTGUN_HSPREAD          = 1000
+	DEFAULT_SHOTGUN_VSPREAD          = 500
+	DEFAULT_DEATHMATCH_SHOTGUN_COUNT = 12
+	DEFAULT_SHOTGUN_COUNT            = 12
+	DEFAULT_SSHOTGUN_COUNT           = 20
 )
 
 /* ============================================================================ */
@@ -529,8 +552,8 @@ type client_persistant_t struct {
 	weapon     *gitem_t
 	lastweapon *gitem_t
 
-	// int power_cubes /* used for tracking the cubes in coop games */
-	score int /* for calculating total unit score in coop games */
+	power_cubes int /* used for tracking the cubes in coop games */
+	score       int /* for calculating total unit score in coop games */
 
 	// int game_helpchanged
 	// int helpchanged
@@ -559,7 +582,7 @@ func (G *client_persistant_t) copy(other client_persistant_t) {
 	G.max_slugs = other.max_slugs
 	G.weapon = other.weapon
 	G.lastweapon = other.lastweapon
-	// G.power_cubes = other.power_cubes
+	G.power_cubes = other.power_cubes
 	G.score = other.score
 	// int game_helpchanged
 	// int helpchanged
@@ -636,9 +659,9 @@ type gclient_t struct {
 	oldviewangles                       [3]float32
 	oldvelocity                         [3]float32
 
-	// float next_drown_time;
-	// int old_waterlevel;
-	// int breather_sound;
+	next_drown_time float32
+	old_waterlevel  int
+	breather_sound  int
 
 	machinegun_shots int /* for weapon raising */
 
@@ -648,27 +671,27 @@ type gclient_t struct {
 	// qboolean anim_duck;
 	// qboolean anim_run;
 
-	// /* powerup timers */
-	// float quad_framenum;
-	// float invincible_framenum;
-	// float breather_framenum;
-	// float enviro_framenum;
+	/* powerup timers */
+	quad_framenum       float32
+	invincible_framenum float32
+	breather_framenum   float32
+	enviro_framenum     float32
 
-	// qboolean grenade_blew_up;
-	// float grenade_time;
-	// int silencer_shots;
-	// int weapon_sound;
+	grenade_blew_up bool
+	grenade_time    float32
+	silencer_shots  int
+	weapon_sound    int
 
 	pickup_msg_time float32
 
-	// float flood_locktill; /* locked from talking */
-	// float flood_when[10]; /* when messages were said */
-	// int flood_whenhead; /* head pointer for when said */
+	flood_locktill float32     /* locked from talking */
+	flood_when     [10]float32 /* when messages were said */
+	flood_whenhead int         /* head pointer for when said */
 
-	// float respawn_time; /* can respawn when time > this */
+	respawn_time float32 /* can respawn when time > this */
 
 	chase_target *edict_t /* player we are chasing */
-	// qboolean update_chase; /* need to update chase info? */
+	update_chase bool     /* need to update chase info? */
 }
 
 func (G *gclient_t) Ps() *shared.Player_state_t {
@@ -679,6 +702,10 @@ func (G *gclient_t) Ping() int {
 	return G.ping
 }
 
+func (G *gclient_t) SetPing(v int) {
+	G.ping = v
+}
+
 func (G *gclient_t) copy(other gclient_t) {
 	/* known to server */
 	G.ps.Copy(other.ps)
@@ -712,30 +739,29 @@ func (G *gclient_t) copy(other gclient_t) {
 	G.bonus_alpha = other.bonus_alpha
 	copy(G.damage_blend[:], other.damage_blend[:])
 	G.bobtime = other.bobtime
-	// G.next_drown_time = other.next_drown_time
-	// float next_drown_time;
-	// int old_waterlevel;
-	// int breather_sound;
+	G.next_drown_time = other.next_drown_time
+	G.old_waterlevel = other.old_waterlevel
+	G.breather_sound = other.breather_sound
 	G.machinegun_shots = other.machinegun_shots
 	G.anim_end = other.anim_end
 	G.anim_priority = other.anim_priority
 	// qboolean anim_duck;
 	// qboolean anim_run;
-	// float quad_framenum;
-	// float invincible_framenum;
-	// float breather_framenum;
-	// float enviro_framenum;
-	// qboolean grenade_blew_up;
-	// float grenade_time;
-	// int silencer_shots;
-	// int weapon_sound;
+	G.quad_framenum = other.quad_framenum
+	G.invincible_framenum = other.invincible_framenum
+	G.breather_framenum = other.breather_framenum
+	G.enviro_framenum = other.enviro_framenum
+	G.grenade_blew_up = other.grenade_blew_up
+	G.grenade_time = other.grenade_time
+	G.silencer_shots = other.silencer_shots
+	G.weapon_sound = other.weapon_sound
 	G.pickup_msg_time = other.pickup_msg_time
-	// float flood_locktill; /* locked from talking */
-	// float flood_when[10]; /* when messages were said */
-	// int flood_whenhead; /* head pointer for when said */
-	// float respawn_time; /* can respawn when time > this */
+	G.flood_locktill = other.flood_locktill
+	G.flood_when = other.flood_when
+	G.flood_whenhead = other.flood_whenhead
+	G.respawn_time = other.respawn_time
 	G.chase_target = other.chase_target
-	// qboolean update_chase; /* need to update chase info? */
+	G.update_chase = other.update_chase
 
 	for i := 0; i < 3; i++ {
 		G.kick_angles[i] = other.kick_angles[i]
@@ -808,8 +834,9 @@ type edict_t struct {
 	velocity  [3]float32
 	avelocity [3]float32
 	Mass      int
-	// float air_finished;
-	gravity float32 /* per entity gravity multiplier (1.0 is normal)
+
+	air_finished float32
+	gravity      float32 /* per entity gravity multiplier (1.0 is normal)
 	   use for lowgrav artifact, flares */
 
 	goalentity *edict_t
@@ -820,15 +847,15 @@ type edict_t struct {
 	nextthink float32
 	prethink  func(self *edict_t, G *qGame)
 	think     func(self *edict_t, G *qGame)
-	// void (*blocked)(edict_t *self, edict_t *other);
-	touch func(self, other *edict_t, plane *shared.Cplane_t, surf *shared.Csurface_t, G *qGame)
-	use   func(self, other, activator *edict_t, G *qGame)
-	pain  func(self, other *edict_t, kick float32, damage int, G *qGame)
-	die   func(self, inflictor, attacker *edict_t, damage int, point []float32, G *qGame)
-
-	touch_debounce_time float32
-	// float pain_debounce_time;
-	// float damage_debounce_time;
+	blocked   func(self, other *edict_t, G *qGame)
+	touch     func(self, other *edict_t, plane *shared.Cplane_t, surf *shared.Csurface_t, G *qGame)
+	use       func(self, other, activator *edict_t, G *qGame)
+	pain      func(self, other *edict_t, kick float32, damage int, G *qGame)
+	die       func(self, inflictor, attacker *edict_t, damage int, point []float32, G *qGame)
+
+	touch_debounce_time  float32
+	pain_debounce_time   float32
+	damage_debounce_time float32
 	// float fly_sound_debounce_time;	/* now also used by insane marines to store pain sound timeout */
 	// float last_move_time;
 
@@ -837,16 +864,16 @@ type edict_t struct {
 	gib_health int
 	deadflag   int
 
-	show_hostile float32
-	// float powerarmor_time;
+	show_hostile    float32
+	powerarmor_time float32
 
 	Map string /* target_changelevel */
 
 	viewheight int /* height above origin where eyesight is determined */
 	takedamage int
 	Dmg        int
-	// int radius_dmg;
-	// float dmg_radius;
+	radius_dmg int
+	dmg_radius float32
 	Sounds int /* make this a spawntemp var? */
 	count  int
 
@@ -908,6 +935,10 @@ func (G *edict_t) Inuse() bool {
 	return G.inuse
 }
 
+func (G *edict_t) SetInuse(v bool) {
+	G.inuse = v
+}
+
 func (G *edict_t) Linkcount() int {
 	return G.linkcount
 }
@@ -1023,7 +1054,7 @@ func (G *edict_t) copy(other edict_t) {
 	G.Accel = other.Accel
 	G.Decel = other.Decel
 	G.Mass = other.Mass
-	// float air_finished;
+	G.air_finished = other.air_finished
 	G.gravity = other.gravity
 	G.goalentity = other.goalentity
 	G.movetarget = other.movetarget
@@ -1032,14 +1063,14 @@ func (G *edict_t) copy(other edict_t) {
 	G.nextthink = other.nextthink
 	G.prethink = other.prethink
 	G.think = other.think
-	// void (*blocked)(edict_t *self, edict_t *other);
+	G.blocked = other.blocked
 	G.touch = other.touch
 	G.use = other.use
 	G.pain = other.pain
 	G.die = other.die
 	G.touch_debounce_time = other.touch_debounce_time
-	// float pain_debounce_time;
-	// float damage_debounce_time;
+	G.pain_debounce_time = other.pain_debounce_time
+	G.damage_debounce_time = other.damage_debounce_time
 	// float fly_sound_debounce_time;	/* now also used by insane marines to store pain sound timeout */
 	// float last_move_time;
 	G.Health = other.Health
@@ -1047,13 +1078,13 @@ func (G *edict_t) copy(other edict_t) {
 	G.gib_health = other.gib_health
 	G.deadflag = other.deadflag
 	G.show_hostile = other.show_hostile
-	// float powerarmor_time;
+	G.powerarmor_time = other.powerarmor_time
 	G.Map = other.Map
 	G.viewheight = other.viewheight
 	G.takedamage = other.takedamage
 	G.Dmg = other.Dmg
-	// int radius_dmg;
-	// float dmg_radius;
+	G.radius_dmg = other.radius_dmg
+	G.dmg_radius = other.dmg_radius
 	G.Sounds = other.Sounds
 	G.count = other.count
 	G.chain = other.chain
@@ -1146,6 +1177,8 @@ type qGame struct {
 
 	meansOfDeath int
 
+	snd_fry int
+
 	deathmatch            *shared.CvarT
 	coop                  *shared.CvarT
 	coop_pickup_weapons   *shared.CvarT
@@ -1221,6 +1254,15 @@ type qGame struct {
 	body_armor_index   int
 	power_screen_index int
 	power_shield_index int
+
+	is_quad     bool
+	is_silenced int
+
+	quad_drop_timeout_hack int
+
+	player_die_anim int
+
+	ipfilters []ipfilter_t
 }
 
 func QGameCreate(gi shared.Game_import_t) shared.Game_export_t {
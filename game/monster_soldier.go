@@ -33,14 +33,25 @@ import (
 	"math"
 )
 
+var sound_idle int
+var sound_sight1 int
+var sound_sight2 int
+var sound_pain_light int
+var sound_pain int
+var sound_pain_ss int
+var sound_death_light int
+var sound_death int
+var sound_death_ss int
+var sound_cock int
+
 func soldier_idle(self *edict_t, G *qGame) {
 	if self == nil || G == nil {
 		return
 	}
 
-	// if (random() > 0.8) {
-	// 	gi.sound(self, CHAN_VOICE, sound_idle, 1, ATTN_IDLE, 0);
-	// }
+	if shared.Frandk() > 0.8 {
+		G.gi.Sound(self, shared.CHAN_VOICE, sound_idle, 1, shared.ATTN_IDLE, 0)
+	}
 }
 
 func soldier_cock(self *edict_t, G *qGame) {
@@ -48,14 +59,11 @@ func soldier_cock(self *edict_t, G *qGame) {
 		return
 	}
 
-	// if (self->s.frame == FRAME_stand322)
-	// {
-	// 	gi.sound(self, CHAN_WEAPON, sound_cock, 1, ATTN_IDLE, 0);
-	// }
-	// else
-	// {
-	// 	gi.sound(self, CHAN_WEAPON, sound_cock, 1, ATTN_NORM, 0);
-	// }
+	if self.s.Frame == soldier.FRAME_stand322 {
+		G.gi.Sound(self, shared.CHAN_WEAPON, sound_cock, 1, shared.ATTN_IDLE, 0)
+	} else {
+		G.gi.Sound(self, shared.CHAN_WEAPON, sound_cock, 1, shared.ATTN_NORM, 0)
+	}
 }
 
 var soldier_frames_stand1 = []mframe_t{
@@ -397,35 +405,28 @@ func soldier_pain(self, other *edict_t, kick float32, damage int, G *qGame) {
 		self.s.Skinnum |= 1
 	}
 
-	// if (level.time < self->pain_debounce_time)
-	// {
-	// 	if ((self->velocity[2] > 100) &&
-	// 		((self->monsterinfo.currentmove == &soldier_move_pain1) ||
-	// 		 (self->monsterinfo.currentmove == &soldier_move_pain2) ||
-	// 		 (self->monsterinfo.currentmove == &soldier_move_pain3)))
-	// 	{
-	// 		self->monsterinfo.currentmove = &soldier_move_pain4;
-	// 	}
+	if G.level.time < self.pain_debounce_time {
+		if (self.velocity[2] > 100) &&
+			((self.monsterinfo.currentmove == &soldier_move_pain1) ||
+				(self.monsterinfo.currentmove == &soldier_move_pain2) ||
+				(self.monsterinfo.currentmove == &soldier_move_pain3)) {
+			self.monsterinfo.currentmove = &soldier_move_pain4
+		}
 
-	// 	return;
-	// }
+		return
+	}
 
-	// self->pain_debounce_time = level.time + 3;
+	self.pain_debounce_time = G.level.time + 3
 
-	// n := self.s.skinnum | 1;
+	n := self.s.Skinnum | 1
 
-	// if (n == 1)
-	// {
-	// 	gi.sound(self, CHAN_VOICE, sound_pain_light, 1, ATTN_NORM, 0);
-	// }
-	// else if (n == 3)
-	// {
-	// 	gi.sound(self, CHAN_VOICE, sound_pain, 1, ATTN_NORM, 0);
-	// }
-	// else
-	// {
-	// 	gi.sound(self, CHAN_VOICE, sound_pain_ss, 1, ATTN_NORM, 0);
-	// }
+	if n == 1 {
+		G.gi.Sound(self, shared.CHAN_VOICE, sound_pain_light, 1, shared.ATTN_NORM, 0)
+	} else if n == 3 {
+		G.gi.Sound(self, shared.CHAN_VOICE, sound_pain, 1, shared.ATTN_NORM, 0)
+	} else {
+		G.gi.Sound(self, shared.CHAN_VOICE, sound_pain_ss, 1, shared.ATTN_NORM, 0)
+	}
 
 	// if (self->velocity[2] > 100)
 	// {
@@ -578,6 +579,26 @@ func soldier_fire7(self *edict_t, G *qGame) {
 	G.soldier_fire(self, 6)
 }
 
+func soldier_sight(self, other *edict_t, G *qGame) {
+	if self == nil || G == nil {
+		return
+	}
+
+	if shared.Frandk() < 0.5 {
+		G.gi.Sound(self, shared.CHAN_VOICE, sound_sight1, 1, shared.ATTN_NORM, 0)
+	} else {
+		G.gi.Sound(self, shared.CHAN_VOICE, sound_sight2, 1, shared.ATTN_NORM, 0)
+	}
+
+	// if ((skill->value > 0) && (range(self, self->enemy) >= RANGE_MID))
+	// {
+	// 	if (random() > 0.5)
+	// 	{
+	// 		self->monsterinfo.currentmove = &soldier_move_attack6;
+	// 	}
+	// }
+}
+
 func soldier_dead(self *edict_t, G *qGame) {
 	if self == nil || G == nil {
 		return
@@ -900,13 +921,13 @@ func soldier_die(self, inflictor, attacker *edict_t, damage int, point []float32
 	self.takedamage = DAMAGE_YES
 	self.s.Skinnum |= 1
 
-	// if (self.s.skinnum == 1) {
-	// 	gi.sound(self, CHAN_VOICE, sound_death_light, 1, ATTN_NORM, 0);
-	// } else if (self.s.skinnum == 3) {
-	// 	gi.sound(self, CHAN_VOICE, sound_death, 1, ATTN_NORM, 0);
-	// } else {
-	// 	gi.sound(self, CHAN_VOICE, sound_death_ss, 1, ATTN_NORM, 0);
-	// }
+	if self.s.Skinnum == 1 {
+		G.gi.Sound(self, shared.CHAN_VOICE, sound_death_light, 1, shared.ATTN_NORM, 0)
+	} else if self.s.Skinnum == 3 {
+		G.gi.Sound(self, shared.CHAN_VOICE, sound_death, 1, shared.ATTN_NORM, 0)
+	} else {
+		G.gi.Sound(self, shared.CHAN_VOICE, sound_death_ss, 1, shared.ATTN_NORM, 0)
+	}
 
 	if math.Abs(float64((self.s.Origin[2]+float32(self.viewheight))-point[2])) <= 4 {
 		/* head shot */
@@ -947,10 +968,10 @@ func (G *qGame) spMonsterSoldierX(self *edict_t) {
 	self.movetype = MOVETYPE_STEP
 	self.solid = shared.SOLID_BBOX
 
-	// sound_idle = gi.soundindex("soldier/solidle1.wav");
-	// sound_sight1 = gi.soundindex("soldier/solsght1.wav");
-	// sound_sight2 = gi.soundindex("soldier/solsrch1.wav");
-	// sound_cock = gi.soundindex("infantry/infatck3.wav");
+	sound_idle = G.gi.Soundindex("soldier/solidle1.wav")
+	sound_sight1 = G.gi.Soundindex("soldier/solsght1.wav")
+	sound_sight2 = G.gi.Soundindex("soldier/solsrch1.wav")
+	sound_cock = G.gi.Soundindex("infantry/infatck3.wav")
 
 	self.Mass = 100
 
@@ -963,7 +984,7 @@ func (G *qGame) spMonsterSoldierX(self *edict_t) {
 	// self->monsterinfo.dodge = soldier_dodge;
 	// self->monsterinfo.attack = soldier_attack;
 	// self->monsterinfo.melee = NULL;
-	// self.monsterinfo.sight = soldier_sight
+	self.monsterinfo.sight = soldier_sight
 
 	G.gi.Linkentity(self)
 
@@ -987,8 +1008,8 @@ func spMonsterSoldier(self *edict_t, G *qGame) error {
 
 	G.spMonsterSoldierX(self)
 
-	// sound_pain = gi.soundindex("soldier/solpain1.wav");
-	// sound_death = gi.soundindex("soldier/soldeth1.wav");
+	sound_pain = G.gi.Soundindex("soldier/solpain1.wav")
+	sound_death = G.gi.Soundindex("soldier/soldeth1.wav")
 	G.gi.Soundindex("soldier/solatck1.wav")
 
 	self.s.Skinnum = 2
@@ -996,3 +1017,55 @@ func spMonsterSoldier(self *edict_t, G *qGame) error {
 	self.gib_health = -30
 	return nil
 }
+
+/*
+ * QUAKED monster_soldier_light (1 .5 0) (-16 -16 -24) (16 16 32) Ambush Trigger_Spawn Sight
+ */
+func spMonsterSoldierLight(self *edict_t, G *qGame) error {
+	if self == nil {
+		return nil
+	}
+
+	if G.deathmatch.Bool() {
+		G.gFreeEdict(self)
+		return nil
+	}
+
+	G.spMonsterSoldierX(self)
+
+	sound_pain_light = G.gi.Soundindex("soldier/solpain2.wav")
+	sound_death_light = G.gi.Soundindex("soldier/soldeth2.wav")
+	G.gi.Modelindex("models/objects/laser/tris.md2")
+	G.gi.Soundindex("misc/lasfly.wav")
+	G.gi.Soundindex("soldier/solatck2.wav")
+
+	self.s.Skinnum = 0
+	self.Health = 20
+	self.gib_health = -30
+	return nil
+}
+
+/*
+ * QUAKED monster_soldier_ss (1 .5 0) (-16 -16 -24) (16 16 32) Ambush Trigger_Spawn Sight
+ */
+func spMonsterSoldierSs(self *edict_t, G *qGame) error {
+	if self == nil {
+		return nil
+	}
+
+	if G.deathmatch.Bool() {
+		G.gFreeEdict(self)
+		return nil
+	}
+
+	G.spMonsterSoldierX(self)
+
+	sound_pain_ss = G.gi.Soundindex("soldier/solpain3.wav")
+	sound_death_ss = G.gi.Soundindex("soldier/soldeth3.wav")
+	G.gi.Soundindex("soldier/solatck3.wav")
+
+	self.s.Skinnum = 4
+	self.Health = 40
+	self.gib_health = -30
+	return nil
+}
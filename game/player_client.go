@@ -27,8 +27,11 @@ package game
 
 import (
 	"fmt"
+	"goquake2/game/misc"
 	"goquake2/shared"
+	"math"
 	"strconv"
+	"strings"
 )
 
 /* ======================================================================= */
@@ -218,16 +221,43 @@ func (G *qGame) selectDeathmatchSpawnPoint() *edict_t {
 	}
 }
 
+func (G *qGame) selectCoopSpawnPoint(ent *edict_t) *edict_t {
+	if ent == nil {
+		return nil
+	}
+
+	index := ent.index - 1
+
+	/* player 0 starts in normal player spawn point */
+	if index == 0 {
+		return nil
+	}
+
+	var spot *edict_t
+
+	/* assume there are four coop spots at each spawnpoint */
+	for {
+		spot = G.gFind(spot, "Classname", "info_player_coop")
+
+		if spot == nil {
+			return nil /* we didn't have enough... */
+		}
+
+		if strings.EqualFold(G.game.spawnpoint, spot.Targetname) {
+			/* this is a coop spawn point for one of the clients here */
+			index--
+
+			if index == 0 {
+				return spot /* this is it */
+			}
+		}
+	}
+}
+
 /*
  * Chooses a player start, deathmatch start, coop start, etc
  */
 func (G *qGame) selectSpawnPoint(ent *edict_t, origin, angles []float32) error {
-	//  edict_t *spot = NULL;
-	//  edict_t *coopspot = NULL;
-	//  int index;
-	//  int counter = 0;
-	//  vec3_t d;
-
 	if ent == nil {
 		return nil
 	}
@@ -236,7 +266,7 @@ func (G *qGame) selectSpawnPoint(ent *edict_t, origin, angles []float32) error {
 	if G.deathmatch.Bool() {
 		spot = G.selectDeathmatchSpawnPoint()
 	} else if G.coop.Bool() {
-		// 	 spot = SelectCoopSpawnPoint(ent);
+		spot = G.selectCoopSpawnPoint(ent)
 	}
 
 	/* find a single player start spot */
@@ -276,36 +306,33 @@ func (G *qGame) selectSpawnPoint(ent *edict_t, origin, angles []float32) error {
 	connected or the map was loaded via console
 	and thus no previously map is known to the
 	client) use one in 550 units radius. */
-	//  if (coop->value) {
-	// 	 index = ent->client - game.clients;
-
-	// 	 if (Q_stricmp(spot->classname, "info_player_start") == 0 && index != 0) {
-	// 		 while(counter < 3)
-	// 		 {
-	// 			 coopspot = G_Find(coopspot, FOFS(classname), "info_player_coop");
-
-	// 			 if (!coopspot)
-	// 			 {
-	// 				 break;
-	// 			 }
-
-	// 			 VectorSubtract(coopspot->s.origin, spot->s.origin, d);
-
-	// 			 if ((VectorLength(d) < 550))
-	// 			 {
-	// 				 if (index == counter)
-	// 				 {
-	// 					 spot = coopspot;
-	// 					 break;
-	// 				 }
-	// 				 else
-	// 				 {
-	// 					 counter++;
-	// 				 }
-	// 			 }
-	// 		 }
-	// 	 }
-	//  }
+	if G.coop.Bool() {
+		index := ent.index - 1
+		counter := 0
+		var coopspot *edict_t
+		d := make([]float32, 3)
+
+		if spot.Classname == "info_player_start" && index != 0 {
+			for counter < 3 {
+				coopspot = G.gFind(coopspot, "Classname", "info_player_coop")
+
+				if coopspot == nil {
+					break
+				}
+
+				shared.VectorSubtract(coopspot.s.Origin[:], spot.s.Origin[:], d)
+
+				if shared.VectorLength(d) < 550 {
+					if index == counter {
+						spot = coopspot
+						break
+					} else {
+						counter++
+					}
+				}
+			}
+		}
+	}
 
 	copy(origin, spot.s.Origin[:])
 	origin[2] += 9
@@ -313,8 +340,213 @@ func (G *qGame) selectSpawnPoint(ent *edict_t, origin, angles []float32) error {
 	return nil
 }
 
+/*
+ * Only called when pers.spectator changes
+ * note that resp.spectator should be the
+ * opposite of pers.spectator here
+ */
+func (G *qGame) spectatorRespawn(ent *edict_t) error {
+	if ent == nil {
+		return nil
+	}
+
+	/* if the user wants to become a spectator,
+	   make sure he doesn't exceed max_spectators */
+	if ent.client.pers.spectator {
+		value := shared.Info_ValueForKey(ent.client.pers.userinfo, "spectator")
+
+		if len(G.spectator_password.String) > 0 &&
+			G.spectator_password.String != "none" &&
+			G.spectator_password.String != value {
+			G.gi.Cprintf(ent, shared.PRINT_HIGH, "Spectator password incorrect.\n")
+			ent.client.pers.spectator = false
+			G.gi.WriteUByte(shared.SvcStufftext)
+			G.gi.WriteString("spectator 0\n")
+			G.gi.Unicast(ent, true)
+			return nil
+		}
+
+		/* count spectators */
+		numspec := 0
+		for i := 1; i <= G.maxclients.Int(); i++ {
+			if G.g_edicts[i].inuse && G.g_edicts[i].client.pers.spectator {
+				numspec++
+			}
+		}
+
+		if numspec >= G.maxspectators.Int() {
+			G.gi.Cprintf(ent, shared.PRINT_HIGH, "Server spectator limit is full.")
+			ent.client.pers.spectator = false
+
+			/* reset his spectator var */
+			G.gi.WriteUByte(shared.SvcStufftext)
+			G.gi.WriteString("spectator 0\n")
+			G.gi.Unicast(ent, true)
+			return nil
+		}
+	} else {
+		/* he was a spectator and wants to join the
+		   game he must have the right password */
+		value := shared.Info_ValueForKey(ent.client.pers.userinfo, "password")
+
+		if len(G.password.String) > 0 && G.password.String != "none" &&
+			G.password.String != value {
+			G.gi.Cprintf(ent, shared.PRINT_HIGH, "Password incorrect.\n")
+			ent.client.pers.spectator = true
+			G.gi.WriteUByte(shared.SvcStufftext)
+			G.gi.WriteString("spectator 1\n")
+			G.gi.Unicast(ent, true)
+			return nil
+		}
+	}
+
+	/* clear score on respawn */
+	ent.client.resp.score = 0
+	ent.client.pers.score = 0
+
+	ent.svflags &^= shared.SVF_NOCLIENT
+	if err := G.putClientInServer(ent); err != nil {
+		return err
+	}
+
+	/* add a teleportation effect */
+	if !ent.client.pers.spectator {
+		/* send effect */
+		G.gi.WriteUByte(shared.SvcMuzzleflash)
+		G.gi.WriteShort(ent.index)
+		G.gi.WriteUByte(shared.MZ_LOGIN)
+		G.gi.Multicast(ent.s.Origin[:], shared.MULTICAST_PVS)
+
+		/* hold in place briefly */
+		ent.client.ps.Pmove.Pm_flags = shared.PMF_TIME_TELEPORT
+		ent.client.ps.Pmove.Pm_time = 14
+	}
+
+	ent.client.respawn_time = G.level.time
+
+	if ent.client.pers.spectator {
+		G.gi.Bprintf(shared.PRINT_HIGH, "%s has moved to the sidelines\n",
+			ent.client.pers.netname)
+	} else {
+		G.gi.Bprintf(shared.PRINT_HIGH, "%s joined the game\n",
+			ent.client.pers.netname)
+	}
+
+	return nil
+}
+
 /* ============================================================== */
 
+func (G *qGame) initBodyQue() {
+	G.level.body_que = 0
+
+	for i := 0; i < BODY_QUEUE_SIZE; i++ {
+		ent, _ := G.gSpawn()
+		ent.Classname = "bodyque"
+	}
+}
+
+func body_die(self, inflictor, attacker *edict_t, damage int, point []float32, G *qGame) {
+	if self == nil || G == nil {
+		return
+	}
+
+	// if (self->health < -40)
+	// {
+	// 	gi.sound(self, CHAN_BODY, gi.soundindex(
+	// 				"misc/udeath.wav"), 1, ATTN_NORM, 0);
+
+	// 	for (n = 0; n < 4; n++)
+	// 	{
+	// 		ThrowGib(self,
+	// 				"models/objects/gibs/sm_meat/tris.md2",
+	// 				damage, GIB_ORGANIC);
+	// 	}
+
+	// 	self->s.origin[2] -= 48;
+	// 	ThrowClientHead(self, damage);
+	// 	self->takedamage = DAMAGE_NO;
+	// }
+}
+
+func (G *qGame) copyToBodyQue(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	G.gi.Unlinkentity(ent)
+
+	/* grab a body que and cycle to the next one */
+	body := &G.g_edicts[G.game.maxclients+G.level.body_que+1]
+	G.level.body_que = (G.level.body_que + 1) % BODY_QUEUE_SIZE
+
+	/* send an effect on the removed body */
+	if body.s.Modelindex != 0 {
+		G.gi.WriteUByte(shared.SvcTempEntity)
+		G.gi.WriteUByte(shared.TE_BLOOD)
+		G.gi.WritePosition(body.s.Origin[:])
+		G.gi.WriteDir([]float32{0, 0, 0})
+		G.gi.Multicast(body.s.Origin[:], shared.MULTICAST_PVS)
+	}
+
+	G.gi.Unlinkentity(body)
+	body.s.Copy(ent.s)
+	body.s.Number = body.index
+	body.s.Event = shared.EV_OTHER_TELEPORT
+
+	body.svflags = ent.svflags
+	copy(body.mins[:], ent.mins[:])
+	copy(body.maxs[:], ent.maxs[:])
+	copy(body.absmin[:], ent.absmin[:])
+	copy(body.absmax[:], ent.absmax[:])
+	copy(body.size[:], ent.size[:])
+	copy(body.velocity[:], ent.velocity[:])
+	copy(body.avelocity[:], ent.avelocity[:])
+	body.solid = ent.solid
+	body.clipmask = ent.clipmask
+	body.owner = ent.owner
+	body.movetype = ent.movetype
+	body.groundentity = ent.groundentity
+
+	body.die = body_die
+	body.takedamage = DAMAGE_YES
+
+	G.gi.Linkentity(body)
+}
+
+func (G *qGame) respawn(self *edict_t) error {
+	if self == nil {
+		return nil
+	}
+
+	if G.deathmatch.Bool() || G.coop.Bool() {
+		/* spectator's don't leave bodies */
+		if self.movetype != MOVETYPE_NOCLIP {
+			G.copyToBodyQue(self)
+		}
+
+		self.svflags &^= shared.SVF_NOCLIENT
+		if err := G.putClientInServer(self); err != nil {
+			return err
+		}
+
+		/* add a teleportation effect */
+		self.s.Event = shared.EV_PLAYER_TELEPORT
+
+		/* hold in place briefly */
+		self.client.ps.Pmove.Pm_flags = shared.PMF_TIME_TELEPORT
+		self.client.ps.Pmove.Pm_time = 14
+
+		self.client.respawn_time = G.level.time
+
+		return nil
+	}
+
+	/* restart the entire server */
+	G.gi.AddCommandString("pushmenu loadgame\n")
+	return nil
+}
+
 /*
  * Called when a player connects to
  * a server or respawns in a deathmatch.
@@ -352,25 +584,22 @@ func (G *qGame) putClientInServer(ent *edict_t) error {
 		resp.copy(client.resp)
 		userinfo := string(client.pers.userinfo)
 		G.initClientPersistant(client)
-		G.clientUserinfoChanged(ent, userinfo)
+		G.clientUserinfoChanged(ent, &userinfo)
+	} else if G.coop.Bool() {
+		resp.copy(client.resp)
+		userinfo := string(client.pers.userinfo)
+		// resp.coop_respawn.game_helpchanged = client->pers.game_helpchanged;
+		// resp.coop_respawn.helpchanged = client->pers.helpchanged;
+		client.pers.copy(resp.coop_respawn)
+		G.clientUserinfoChanged(ent, &userinfo)
+
+		if resp.score > client.pers.score {
+			client.pers.score = resp.score
+		}
 	}
-	//  else if (coop->value)
-	//  {
-	// 	 resp = client->resp;
-	// 	 memcpy(userinfo, client->pers.userinfo, sizeof(userinfo));
-	// 	 resp.coop_respawn.game_helpchanged = client->pers.game_helpchanged;
-	// 	 resp.coop_respawn.helpchanged = client->pers.helpchanged;
-	// 	 client->pers = resp.coop_respawn;
-	// 	 ClientUserinfoChanged(ent, userinfo);
-
-	// 	 if (resp.score > client->pers.score)
-	// 	 {
-	// 		 client->pers.score = resp.score;
-	// 	 }
-	//  }
 
 	userinfo := string(client.pers.userinfo)
-	G.clientUserinfoChanged(ent, userinfo)
+	G.clientUserinfoChanged(ent, &userinfo)
 
 	/* clear everything but the persistant data */
 	var saved client_persistant_t
@@ -398,11 +627,11 @@ func (G *qGame) putClientInServer(ent *edict_t) error {
 	ent.Mass = 200
 	ent.solid = shared.SOLID_BBOX
 	ent.deadflag = DEAD_NO
-	//  ent->air_finished = level.time + 12;
+	ent.air_finished = G.level.time + 12
 	ent.clipmask = shared.MASK_PLAYERSOLID
 	ent.Model = "players/male/tris.md2"
-	//  ent->pain = player_pain;
-	//  ent->die = player_die;
+	ent.pain = player_pain
+	ent.die = player_die
 	ent.waterlevel = 0
 	ent.watertype = 0
 	ent.flags &^= FL_NO_KNOCKBACK
@@ -459,24 +688,21 @@ func (G *qGame) putClientInServer(ent *edict_t) error {
 	copy(client.ps.Viewangles[:], ent.s.Angles[:])
 	copy(client.v_angle[:], ent.s.Angles[:])
 
-	//  /* spawn a spectator */
-	//  if (client->pers.spectator)
-	//  {
-	// 	 client->chase_target = NULL;
+	/* spawn a spectator */
+	if client.pers.spectator {
+		client.chase_target = nil
 
-	// 	 client->resp.spectator = true;
+		client.resp.spectator = true
 
-	// 	 ent->movetype = MOVETYPE_NOCLIP;
-	// 	 ent->solid = SOLID_NOT;
-	// 	 ent->svflags |= SVF_NOCLIENT;
-	// 	 ent->client->ps.gunindex = 0;
-	// 	 gi.linkentity(ent);
-	// 	 return;
-	//  }
-	//  else
-	//  {
-	client.resp.spectator = false
-	//  }
+		ent.movetype = MOVETYPE_NOCLIP
+		ent.solid = shared.SOLID_NOT
+		ent.svflags |= shared.SVF_NOCLIENT
+		ent.client.ps.Gunindex = 0
+		G.gi.Linkentity(ent)
+		return nil
+	} else {
+		client.resp.spectator = false
+	}
 
 	//  if (!KillBox(ent))
 	//  {
@@ -491,6 +717,70 @@ func (G *qGame) putClientInServer(ent *edict_t) error {
 	return nil
 }
 
+/*
+ * The ugly as hell coop spawnpoint fixup function.
+ * While coop was part of the initial design from the
+ * beginning, at some point it was decided to make
+ * it an afterthought and some of the maps lack the
+ * needed info_player_coop spawnpoints. Try to fix
+ * them up by assigning the targetname of the nearest
+ * info_player_start.
+ */
+func spFixCoopSpots(self *edict_t, G *qGame) {
+	if self == nil || G == nil {
+		return
+	}
+
+	d := make([]float32, 3)
+	var spot *edict_t
+
+	for {
+		spot = G.gFind(spot, "Classname", "info_player_start")
+
+		if spot == nil {
+			return
+		}
+
+		if len(spot.Targetname) == 0 {
+			continue
+		}
+
+		shared.VectorSubtract(self.s.Origin[:], spot.s.Origin[:], d)
+
+		if shared.VectorLength(d) < 384 {
+			if len(self.Targetname) == 0 || !strings.EqualFold(self.Targetname, spot.Targetname) {
+				self.Targetname = spot.Targetname
+			}
+
+			return
+		}
+	}
+}
+
+/*
+ * Now if that one wasn't ugly enough for you then try this one
+ * on for size. Some maps don't have any coop spots at all, so we
+ * need to create them. The security map is the only one we know
+ * of that is affected.
+ */
+func spCreateCoopSpots(self *edict_t, G *qGame) {
+	if self == nil || G == nil {
+		return
+	}
+
+	if strings.EqualFold(G.level.mapname, "security") {
+		for _, x := range []float32{188 - 64, 188 + 64, 188 + 128} {
+			spot, _ := G.gSpawn()
+			spot.Classname = "info_player_coop"
+			spot.s.Origin[0] = x
+			spot.s.Origin[1] = -164
+			spot.s.Origin[2] = 80
+			spot.Targetname = "jail3"
+			spot.s.Angles[1] = 90
+		}
+	}
+}
+
 /*
  * Some maps have no unnamed (e.g. generic)
  * info_player_start. This is no problem in
@@ -654,8 +944,8 @@ func spInfoPlayerStart(self *edict_t, G *qGame) error {
 
 	if G.level.mapname == "security" {
 		/* invoke one of our gross, ugly, disgusting hacks */
-		// 	self->think = SP_CreateCoopSpots;
-		// 	self->nextthink = level.time + FRAMETIME;
+		self.think = spCreateCoopSpots
+		self.nextthink = G.level.time + FRAMETIME
 	}
 	return nil
 }
@@ -677,6 +967,444 @@ func spInfoPlayerDeathmatch(self *edict_t, G *qGame) error {
 	return spMiscTeleporterDest(self, G)
 }
 
+/*
+ * QUAKED info_player_coop (1 0 1) (-16 -16 -24) (16 16 32)
+ * potential spawning position for coop games
+ */
+func spInfoPlayerCoop(self *edict_t, G *qGame) error {
+	if self == nil || G == nil {
+		return nil
+	}
+
+	if !G.coop.Bool() {
+		G.gFreeEdict(self)
+		return nil
+	}
+
+	switch strings.ToLower(G.level.mapname) {
+	case "jail2", "jail4", "mine1", "mine2", "mine3", "mine4", "lab",
+		"boss1", "fact1", "fact3", "waste1", /* really? */
+		"biggun", "space", "command", "power2", "strike":
+		/* invoke one of our gross, ugly, disgusting hacks */
+		self.think = spFixCoopSpots
+		self.nextthink = G.level.time + FRAMETIME
+	}
+
+	return nil
+}
+
+/*
+ * QUAKED info_player_intermission (1 0 1) (-16 -16 -24) (16 16 32)
+ * The deathmatch intermission point will be at one of these
+ * Use 'angles' instead of 'angle', so you can set pitch or
+ * roll as well as yaw.  'pitch yaw roll'
+ */
+func spInfoPlayerIntermission(self *edict_t, G *qGame) error {
+	/* This function cannot be removed
+	 * since the info_player_intermission
+	 * needs a callback function. Like
+	 * every entity. */
+	return nil
+}
+
+/* ======================================================================= */
+
+func player_pain(self, other *edict_t, kick float32, damage int, G *qGame) {
+	/* player pain is handled at the end
+	 * of the frame in P_DamageFeedback.
+	 * This function is still here since
+	 * the player is an entity and needs
+	 * a pain callback */
+}
+
+func isFemale(ent *edict_t) bool {
+	if ent == nil || ent.client == nil {
+		return false
+	}
+
+	info := shared.Info_ValueForKey(ent.client.pers.userinfo, "gender")
+
+	if strings.Contains(info, "crakhor") {
+		return false
+	}
+
+	if len(info) > 0 && ((info[0] == 'f') || (info[0] == 'F')) {
+		return true
+	}
+
+	return false
+}
+
+func isNeutral(ent *edict_t) bool {
+	if ent == nil || ent.client == nil {
+		return false
+	}
+
+	info := shared.Info_ValueForKey(ent.client.pers.userinfo, "gender")
+
+	if strings.Contains(info, "crakhor") {
+		return true
+	}
+
+	if len(info) == 0 || ((info[0] != 'f') && (info[0] != 'F') &&
+		(info[0] != 'm') && (info[0] != 'M')) {
+		return true
+	}
+
+	return false
+}
+
+func (G *qGame) clientObituary(self, inflictor, attacker *edict_t) {
+	if self == nil || inflictor == nil {
+		return
+	}
+
+	if G.coop.Bool() && attacker != nil && attacker.client != nil {
+		G.meansOfDeath |= MOD_FRIENDLY_FIRE
+	}
+
+	if G.deathmatch.Bool() || G.coop.Bool() {
+		ff := (G.meansOfDeath & MOD_FRIENDLY_FIRE) != 0
+		mod := G.meansOfDeath &^ MOD_FRIENDLY_FIRE
+		message := ""
+		message2 := ""
+
+		switch mod {
+		case MOD_SUICIDE:
+			message = "suicides"
+		case MOD_FALLING:
+			message = "cratered"
+		case MOD_CRUSH:
+			message = "was squished"
+		case MOD_WATER:
+			message = "sank like a rock"
+		case MOD_SLIME:
+			message = "melted"
+		case MOD_LAVA:
+			message = "does a back flip into the lava"
+		case MOD_EXPLOSIVE, MOD_BARREL:
+			message = "blew up"
+		case MOD_EXIT:
+			message = "found a way out"
+		case MOD_TARGET_LASER:
+			message = "saw the light"
+		case MOD_TARGET_BLASTER:
+			message = "got blasted"
+		case MOD_BOMB, MOD_SPLASH, MOD_TRIGGER_HURT:
+			message = "was in the wrong place"
+		}
+
+		if attacker == self {
+			switch mod {
+			case MOD_HELD_GRENADE:
+				message = "tried to put the pin back in"
+			case MOD_HG_SPLASH, MOD_G_SPLASH:
+				if isNeutral(self) {
+					message = "tripped on its own grenade"
+				} else if isFemale(self) {
+					message = "tripped on her own grenade"
+				} else {
+					message = "tripped on his own grenade"
+				}
+			case MOD_R_SPLASH:
+				if isNeutral(self) {
+					message = "blew itself up"
+				} else if isFemale(self) {
+					message = "blew herself up"
+				} else {
+					message = "blew himself up"
+				}
+			case MOD_BFG_BLAST:
+				message = "should have used a smaller gun"
+			default:
+				if isNeutral(self) {
+					message = "killed itself"
+				} else if isFemale(self) {
+					message = "killed herself"
+				} else {
+					message = "killed himself"
+				}
+			}
+		}
+
+		if len(message) > 0 {
+			G.gi.Bprintf(shared.PRINT_MEDIUM, "%s %s.\n",
+				self.client.pers.netname, message)
+
+			if G.deathmatch.Bool() {
+				self.client.resp.score--
+			}
+
+			self.enemy = nil
+			return
+		}
+
+		self.enemy = attacker
+
+		if attacker != nil && attacker.client != nil {
+			switch mod {
+			case MOD_BLASTER:
+				message = "was blasted by"
+			case MOD_SHOTGUN:
+				message = "was gunned down by"
+			case MOD_SSHOTGUN:
+				message = "was blown away by"
+				message2 = "'s super shotgun"
+			case MOD_MACHINEGUN:
+				message = "was machinegunned by"
+			case MOD_CHAINGUN:
+				message = "was cut in half by"
+				message2 = "'s chaingun"
+			case MOD_GRENADE:
+				message = "was popped by"
+				message2 = "'s grenade"
+			case MOD_G_SPLASH:
+				message = "was shredded by"
+				message2 = "'s shrapnel"
+			case MOD_ROCKET:
+				message = "ate"
+				message2 = "'s rocket"
+			case MOD_R_SPLASH:
+				message = "almost dodged"
+				message2 = "'s rocket"
+			case MOD_HYPERBLASTER:
+				message = "was melted by"
+				message2 = "'s hyperblaster"
+			case MOD_RAILGUN:
+				message = "was railed by"
+			case MOD_BFG_LASER:
+				message = "saw the pretty lights from"
+				message2 = "'s BFG"
+			case MOD_BFG_BLAST:
+				message = "was disintegrated by"
+				message2 = "'s BFG blast"
+			case MOD_BFG_EFFECT:
+				message = "couldn't hide from"
+				message2 = "'s BFG"
+			case MOD_HANDGRENADE:
+				message = "caught"
+				message2 = "'s handgrenade"
+			case MOD_HG_SPLASH:
+				message = "didn't see"
+				message2 = "'s handgrenade"
+			case MOD_HELD_GRENADE:
+				message = "feels"
+				message2 = "'s pain"
+			case MOD_TELEFRAG:
+				message = "tried to invade"
+				message2 = "'s personal space"
+			}
+
+			if len(message) > 0 {
+				G.gi.Bprintf(shared.PRINT_MEDIUM, "%s %s %s%s\n",
+					self.client.pers.netname, message,
+					attacker.client.pers.netname, message2)
+
+				if G.deathmatch.Bool() {
+					if ff {
+						attacker.client.resp.score--
+					} else {
+						attacker.client.resp.score++
+					}
+				}
+
+				return
+			}
+		}
+	}
+
+	G.gi.Bprintf(shared.PRINT_MEDIUM, "%s died.\n", self.client.pers.netname)
+
+	if G.deathmatch.Bool() {
+		self.client.resp.score--
+	}
+}
+
+func (G *qGame) tossClientWeapon(self *edict_t) {
+	if self == nil {
+		return
+	}
+
+	if !G.deathmatch.Bool() {
+		return
+	}
+
+	item := self.client.pers.weapon
+
+	if self.client.pers.inventory[self.client.ammo_index] == 0 {
+		item = nil
+	}
+
+	if item != nil && (item.pickup_name == "Blaster") {
+		item = nil
+	}
+
+	quad := false
+	if (G.dmflags.Int() & shared.DF_QUAD_DROP) != 0 {
+		quad = (self.client.quad_framenum > float32(G.level.framenum+10))
+	}
+
+	var spread float32
+	if item != nil && quad {
+		spread = 22.5
+	}
+
+	if item != nil {
+		self.client.v_angle[shared.YAW] -= spread
+		drop := G.dropItem(self, item)
+		self.client.v_angle[shared.YAW] += spread
+		drop.Spawnflags = DROPPED_PLAYER_ITEM
+	}
+
+	if quad {
+		self.client.v_angle[shared.YAW] += spread
+		drop := G.dropItem(self, findItemByClassname("item_quad"))
+		self.client.v_angle[shared.YAW] -= spread
+		drop.Spawnflags |= DROPPED_PLAYER_ITEM
+
+		drop.touch = touch_Item
+		drop.nextthink = G.level.time + (self.client.quad_framenum-
+			float32(G.level.framenum))*FRAMETIME
+		drop.think = gFreeEdictFunc
+	}
+}
+
+func (G *qGame) lookAtKiller(self, inflictor, attacker *edict_t) {
+	if self == nil {
+		return
+	}
+
+	dir := make([]float32, 3)
+
+	if attacker != nil && (attacker != &G.g_edicts[0]) && (attacker != self) {
+		shared.VectorSubtract(attacker.s.Origin[:], self.s.Origin[:], dir)
+	} else if inflictor != nil && (inflictor != &G.g_edicts[0]) && (inflictor != self) {
+		shared.VectorSubtract(inflictor.s.Origin[:], self.s.Origin[:], dir)
+	} else {
+		self.client.killer_yaw = self.s.Angles[shared.YAW]
+		return
+	}
+
+	if dir[0] != 0 {
+		self.client.killer_yaw = float32(180 / math.Pi * math.Atan2(float64(dir[1]), float64(dir[0])))
+	} else {
+		self.client.killer_yaw = 0
+
+		if dir[1] > 0 {
+			self.client.killer_yaw = 90
+		} else if dir[1] < 0 {
+			self.client.killer_yaw = 270
+		}
+	}
+
+	if self.client.killer_yaw < 0 {
+		self.client.killer_yaw += 360
+	}
+}
+
+func player_die(self, inflictor, attacker *edict_t, damage int, point []float32, G *qGame) {
+	if self == nil || G == nil {
+		return
+	}
+
+	copy(self.avelocity[:], []float32{0, 0, 0})
+
+	self.takedamage = DAMAGE_YES
+	self.movetype = MOVETYPE_TOSS
+
+	self.s.Modelindex2 = 0 /* remove linked weapon model */
+
+	self.s.Angles[0] = 0
+	self.s.Angles[2] = 0
+
+	self.s.Sound = 0
+	self.client.weapon_sound = 0
+
+	self.maxs[2] = -8
+
+	self.svflags |= shared.SVF_DEADMONSTER
+
+	if self.deadflag == 0 {
+		self.client.respawn_time = G.level.time + 1.0
+		G.lookAtKiller(self, inflictor, attacker)
+		self.client.ps.Pmove.Pm_type = shared.PM_DEAD
+		G.clientObituary(self, inflictor, attacker)
+		G.tossClientWeapon(self)
+
+		if G.deathmatch.Bool() {
+			G.cmd_Help_f(self) /* show scores */
+		}
+
+		/* clear inventory: this is kind of ugly, but
+		   it's how we want to handle keys in coop */
+		for n := range gameitemlist {
+			if G.coop.Bool() && (gameitemlist[n].flags&IT_KEY) != 0 {
+				self.client.resp.coop_respawn.inventory[n] = self.client.pers.inventory[n]
+			}
+
+			self.client.pers.inventory[n] = 0
+		}
+	}
+
+	/* remove powerups */
+	self.client.quad_framenum = 0
+	self.client.invincible_framenum = 0
+	self.client.breather_framenum = 0
+	self.client.enviro_framenum = 0
+	self.flags &^= FL_POWER_ARMOR
+
+	// if (self->health < -40)
+	// {
+	// 	/* gib */
+	// 	gi.sound(self, CHAN_BODY, gi.soundindex(
+	// 				"misc/udeath.wav"), 1, ATTN_NORM, 0);
+
+	// 	for (n = 0; n < 4; n++)
+	// 	{
+	// 		ThrowGib(self, "models/objects/gibs/sm_meat/tris.md2",
+	// 				damage, GIB_ORGANIC);
+	// 	}
+
+	// 	ThrowClientHead(self, damage);
+
+	// 	self->takedamage = DAMAGE_NO;
+	// }
+	// else
+	// {
+	/* normal death */
+	if self.deadflag == 0 {
+		G.player_die_anim = (G.player_die_anim + 1) % 3
+
+		/* start a death animation */
+		self.client.anim_priority = ANIM_DEATH
+
+		if (self.client.ps.Pmove.Pm_flags & shared.PMF_DUCKED) != 0 {
+			self.s.Frame = misc.FRAME_crdeath1 - 1
+			self.client.anim_end = misc.FRAME_crdeath5
+		} else {
+			switch G.player_die_anim {
+			case 0:
+				self.s.Frame = misc.FRAME_death101 - 1
+				self.client.anim_end = misc.FRAME_death106
+			case 1:
+				self.s.Frame = misc.FRAME_death201 - 1
+				self.client.anim_end = misc.FRAME_death206
+			case 2:
+				self.s.Frame = misc.FRAME_death301 - 1
+				self.client.anim_end = misc.FRAME_death308
+			}
+		}
+
+		G.gi.Sound(self, shared.CHAN_VOICE, G.gi.Soundindex(
+			fmt.Sprintf("*death%d.wav", (shared.Randk()%4)+1)), 1, shared.ATTN_NORM, 0)
+	}
+	// }
+
+	self.deadflag = DEAD_DEAD
+
+	G.gi.Linkentity(self)
+}
+
 func (G *qGame) initClientResp(client *gclient_t) {
 	if client == nil {
 		return
@@ -687,6 +1415,32 @@ func (G *qGame) initClientResp(client *gclient_t) {
 	client.resp.coop_respawn = client.pers
 }
 
+/*
+ * Some information that should be persistant, like health,
+ * is still stored in the edict structure, so it needs to
+ * be mirrored out to the client structure before all the
+ * edicts are wiped.
+ */
+func (G *qGame) saveClientData() {
+
+	for i := 0; i < G.game.maxclients; i++ {
+		ent := &G.g_edicts[1+i]
+
+		if !ent.inuse {
+			continue
+		}
+
+		G.game.clients[i].pers.health = ent.Health
+		G.game.clients[i].pers.max_health = ent.max_health
+		G.game.clients[i].pers.savedFlags =
+			(ent.flags & (FL_GODMODE | FL_NOTARGET | FL_POWER_ARMOR))
+
+		if G.coop.Bool() {
+			G.game.clients[i].pers.score = ent.client.resp.score
+		}
+	}
+}
+
 func (G *qGame) fetchClientEntData(ent *edict_t) {
 	if ent == nil {
 		return
@@ -701,6 +1455,43 @@ func (G *qGame) fetchClientEntData(ent *edict_t) {
 	}
 }
 
+/*
+ * A client has just connected to the server in
+ * deathmatch mode, so clear everything out before
+ * starting them.
+ */
+func (G *qGame) clientBeginDeathmatch(ent *edict_t) error {
+	if ent == nil {
+		return nil
+	}
+
+	G_InitEdict(ent, ent.index)
+
+	G.initClientResp(ent.client)
+
+	/* locate ent at a spawn point */
+	if err := G.putClientInServer(ent); err != nil {
+		return err
+	}
+
+	if G.level.intermissiontime != 0 {
+		G.moveClientToIntermission(ent)
+	} else {
+		/* send effect */
+		G.gi.WriteUByte(shared.SvcMuzzleflash)
+		G.gi.WriteShort(ent.index)
+		G.gi.WriteUByte(shared.MZ_LOGIN)
+		G.gi.Multicast(ent.s.Origin[:], shared.MULTICAST_PVS)
+	}
+
+	G.gi.Bprintf(shared.PRINT_HIGH, "%s entered the game\n",
+		ent.client.pers.netname)
+
+	/* make sure all view stuff is valid */
+	G.clientEndServerFrame(ent)
+	return nil
+}
+
 /*
  * called when a client has finished connecting, and is ready
  * to be placed into the game.  This will happen every level load.
@@ -715,10 +1506,9 @@ func (G *qGame) ClientBegin(sent shared.Edict_s) error {
 
 	ent.client = &G.game.clients[ent.index-1]
 
-	//  if (deathmatch->value) {
-	// 	 ClientBeginDeathmatch(ent);
-	// 	 return;
-	//  }
+	if G.deathmatch.Bool() {
+		return G.clientBeginDeathmatch(ent)
+	}
 
 	/* if there is already a body waiting for us (a loadgame),
 	just take it, otherwise spawn one from scratch */
@@ -727,10 +1517,10 @@ func (G *qGame) ClientBegin(sent shared.Edict_s) error {
 		connecting to the server, which is different than the
 		state when the game is saved, so we need to compensate
 		with deltaangles */
-		//  for i := 0; i < 3; i++ {
-		// 	 ent->client->ps.pmove.delta_angles[i] = ANGLE2SHORT(
-		// 			 ent->client->ps.viewangles[i]);
-		//  }
+		for i := 0; i < 3; i++ {
+			ent.client.ps.Pmove.Delta_angles[i] = shared.ANGLE2SHORT(
+				ent.client.ps.Viewangles[i])
+		}
 	} else {
 		/* a spawn point will completely reinitialize the entity
 		except for the persistant data that was initialized at
@@ -743,20 +1533,20 @@ func (G *qGame) ClientBegin(sent shared.Edict_s) error {
 		}
 	}
 
-	//  if (level.intermissiontime) {
-	// 	 MoveClientToIntermission(ent);
-	//  } else {
-	// 	 /* send effect if in a multiplayer game */
-	// 	 if (game.maxclients > 1) {
-	// 		 gi.WriteByte(svc_muzzleflash);
-	// 		 gi.WriteShort(ent - g_edicts);
-	// 		 gi.WriteByte(MZ_LOGIN);
-	// 		 gi.multicast(ent->s.origin, MULTICAST_PVS);
-
-	// 		 gi.bprintf(PRINT_HIGH, "%s entered the game\n",
-	// 				 ent->client->pers.netname);
-	// 	 }
-	//  }
+	if G.level.intermissiontime != 0 {
+		G.moveClientToIntermission(ent)
+	} else {
+		/* send effect if in a multiplayer game */
+		if G.game.maxclients > 1 {
+			G.gi.WriteUByte(shared.SvcMuzzleflash)
+			G.gi.WriteShort(ent.index)
+			G.gi.WriteUByte(shared.MZ_LOGIN)
+			G.gi.Multicast(ent.s.Origin[:], shared.MULTICAST_PVS)
+
+			G.gi.Bprintf(shared.PRINT_HIGH, "%s entered the game\n",
+				ent.client.pers.netname)
+		}
+	}
 
 	/* make sure all view stuff is valid */
 	G.clientEndServerFrame(ent)
@@ -768,33 +1558,33 @@ func (G *qGame) ClientBegin(sent shared.Edict_s) error {
  * The game can override any of the settings in place
  * (forcing skins or names, etc) before copying it off.
  */
-func (G *qGame) clientUserinfoChanged(ent *edict_t, userinfo string) {
+func (G *qGame) clientUserinfoChanged(ent *edict_t, userinfo *string) {
 
-	if ent == nil {
+	if ent == nil || userinfo == nil {
 		return
 	}
 
 	/* check for malformed or illegal info strings */
-	// if !Info_Validate(userinfo) {
-	// 	strcpy(userinfo, "\\name\\badinfo\\skin\\male/grunt")
-	// }
+	if !shared.Info_Validate(*userinfo) {
+		*userinfo = "\\name\\badinfo\\skin\\male/grunt"
+	}
 
 	/* set name */
-	s := shared.Info_ValueForKey(userinfo, "name")
+	s := shared.Info_ValueForKey(*userinfo, "name")
 	ent.client.pers.netname = s
 
 	/* set spectator */
-	//  s = shared.Info_ValueForKey(userinfo, "spectator");
+	s = shared.Info_ValueForKey(*userinfo, "spectator")
 
 	/* spectators are only supported in deathmatch */
-	//  if (deathmatch.value && *s && strcmp(s, "0")) {
-	// 	 ent->client->pers.spectator = true;
-	//  } else {
-	ent.client.pers.spectator = false
-	//  }
+	if G.deathmatch.Bool() && len(s) > 0 && s != "0" {
+		ent.client.pers.spectator = true
+	} else {
+		ent.client.pers.spectator = false
+	}
 
 	/* set skin */
-	s = shared.Info_ValueForKey(userinfo, "skin")
+	s = shared.Info_ValueForKey(*userinfo, "skin")
 
 	playernum := ent.index - 1
 
@@ -806,7 +1596,7 @@ func (G *qGame) clientUserinfoChanged(ent *edict_t, userinfo string) {
 	if G.deathmatch.Bool() && (G.dmflags.Int()&shared.DF_FIXED_FOV) != 0 {
 		ent.client.ps.Fov = 90
 	} else {
-		fov, _ := strconv.ParseInt(shared.Info_ValueForKey(userinfo, "fov"), 10, 32)
+		fov, _ := strconv.ParseInt(shared.Info_ValueForKey(*userinfo, "fov"), 10, 32)
 
 		ent.client.ps.Fov = float32(fov)
 		if ent.client.ps.Fov < 1 {
@@ -817,7 +1607,7 @@ func (G *qGame) clientUserinfoChanged(ent *edict_t, userinfo string) {
 	}
 
 	/* handedness */
-	s = shared.Info_ValueForKey(userinfo, "hand")
+	s = shared.Info_ValueForKey(*userinfo, "hand")
 
 	if len(s) > 0 {
 		h, _ := strconv.ParseInt(s, 10, 32)
@@ -825,7 +1615,35 @@ func (G *qGame) clientUserinfoChanged(ent *edict_t, userinfo string) {
 	}
 
 	/* save off the userinfo in case we want to check something later */
-	ent.client.pers.userinfo = userinfo
+	ent.client.pers.userinfo = *userinfo
+}
+
+/*
+ * called whenever the player updates a userinfo variable.
+ *
+ * The game can override any of the settings in place
+ * (forcing skins or names, etc) before copying it off.
+ */
+func (G *qGame) ClientUserinfoChanged(sent shared.Edict_s, userinfo *string) {
+	ent := sent.(*edict_t)
+	if ent == nil || ent.client == nil {
+		return
+	}
+
+	G.clientUserinfoChanged(ent, userinfo)
+}
+
+/*
+ * Sets a key in the userinfo string, complaining
+ * on the console if that isn't possible.
+ */
+func (G *qGame) setUserinfoValue(userinfo *string, key, value string) {
+	info, err := shared.Info_SetValueForKey(*userinfo, key, value)
+	if err != nil {
+		G.gi.Dprintf("%v\n", err)
+	}
+
+	*userinfo = info
 }
 
 /*
@@ -835,7 +1653,7 @@ func (G *qGame) clientUserinfoChanged(ent *edict_t, userinfo string) {
  * and eventually get to ClientBegin(). Changing levels will NOT
  * cause this to be called again, but loadgames will.
  */
-func (G *qGame) ClientConnect(sent shared.Edict_s, userinfo string) bool {
+func (G *qGame) ClientConnect(sent shared.Edict_s, userinfo *string) bool {
 
 	ent := sent.(*edict_t)
 	if ent == nil {
@@ -843,58 +1661,49 @@ func (G *qGame) ClientConnect(sent shared.Edict_s, userinfo string) bool {
 	}
 
 	/* check to see if they are on the banned IP list */
-	// value := shared.Info_ValueForKey(userinfo, "ip")
+	value := shared.Info_ValueForKey(*userinfo, "ip")
 
-	//  if (SV_FilterPacket(value)) {
-	// 	 Info_SetValueForKey(userinfo, "rejmsg", "Banned.");
-	// 	 return false;
-	//  }
+	if G.svFilterPacket(value) {
+		G.setUserinfoValue(userinfo, "rejmsg", "Banned.")
+		return false
+	}
 
-	//  /* check for a spectator */
-	//  value = Info_ValueForKey(userinfo, "spectator");
+	/* check for a spectator */
+	value = shared.Info_ValueForKey(*userinfo, "spectator")
 
-	//  if (deathmatch->value && *value && strcmp(value, "0"))
-	//  {
-	// 	 int i, numspec;
-
-	// 	 if (*spectator_password->string &&
-	// 		 strcmp(spectator_password->string, "none") &&
-	// 		 strcmp(spectator_password->string, value))
-	// 	 {
-	// 		 Info_SetValueForKey(userinfo, "rejmsg",
-	// 				 "Spectator password required or incorrect.");
-	// 		 return false;
-	// 	 }
+	if G.deathmatch.Bool() && len(value) > 0 && value != "0" {
+		if len(G.spectator_password.String) > 0 &&
+			G.spectator_password.String != "none" &&
+			G.spectator_password.String != value {
+			G.setUserinfoValue(userinfo, "rejmsg",
+				"Spectator password required or incorrect.")
+			return false
+		}
 
-	// 	 /* count spectators */
-	// 	 for (i = numspec = 0; i < maxclients->value; i++)
-	// 	 {
-	// 		 if (g_edicts[i + 1].inuse && g_edicts[i + 1].client->pers.spectator)
-	// 		 {
-	// 			 numspec++;
-	// 		 }
-	// 	 }
+		/* count spectators */
+		numspec := 0
+		for i := 0; i < G.maxclients.Int(); i++ {
+			if G.g_edicts[i+1].inuse && G.g_edicts[i+1].client.pers.spectator {
+				numspec++
+			}
+		}
 
-	// 	 if (numspec >= maxspectators->value)
-	// 	 {
-	// 		 Info_SetValueForKey(userinfo, "rejmsg",
-	// 				 "Server spectator limit is full.");
-	// 		 return false;
-	// 	 }
-	//  }
-	//  else
-	//  {
-	// 	 /* check for a password */
-	// 	 value = Info_ValueForKey(userinfo, "password");
-
-	// 	 if (*password->string && strcmp(password->string, "none") &&
-	// 		 strcmp(password->string, value))
-	// 	 {
-	// 		 Info_SetValueForKey(userinfo, "rejmsg",
-	// 				 "Password required or incorrect.");
-	// 		 return false;
-	// 	 }
-	//  }
+		if numspec >= G.maxspectators.Int() {
+			G.setUserinfoValue(userinfo, "rejmsg",
+				"Server spectator limit is full.")
+			return false
+		}
+	} else {
+		/* check for a password */
+		value = shared.Info_ValueForKey(*userinfo, "password")
+
+		if len(G.password.String) > 0 && G.password.String != "none" &&
+			G.password.String != value {
+			G.setUserinfoValue(userinfo, "rejmsg",
+				"Password required or incorrect.")
+			return false
+		}
+	}
 
 	/* they can connect */
 	ent.client = &G.game.clients[ent.index-1]
@@ -921,6 +1730,41 @@ func (G *qGame) ClientConnect(sent shared.Edict_s, userinfo string) bool {
 	return true
 }
 
+/*
+ * Called when a player drops from the server.
+ * Will not be called between levels.
+ */
+func (G *qGame) ClientDisconnect(sent shared.Edict_s) {
+	ent := sent.(*edict_t)
+	if ent == nil || ent.client == nil {
+		return
+	}
+
+	G.gi.Bprintf(shared.PRINT_HIGH, "%s disconnected\n", ent.client.pers.netname)
+
+	/* send effect */
+	if ent.inuse {
+		G.gi.WriteUByte(shared.SvcMuzzleflash)
+		G.gi.WriteShort(ent.index)
+		G.gi.WriteUByte(shared.MZ_LOGOUT)
+		G.gi.Multicast(ent.s.Origin[:], shared.MULTICAST_PVS)
+	}
+
+	G.gi.Unlinkentity(ent)
+	ent.s.Modelindex = 0
+	ent.s.Sound = 0
+	ent.s.Event = 0
+	ent.s.Effects = 0
+	ent.solid = shared.SOLID_NOT
+	ent.inuse = false
+	ent.Classname = "disconnected"
+	ent.client.pers.connected = false
+
+	/* FIXME: don't break skins on corpses, etc */
+	playernum := ent.index - 1
+	G.gi.Configstring(shared.CS_PLAYERSKINS+playernum, "")
+}
+
 /* ============================================================== */
 
 // edict_t *pm_passent;
@@ -964,11 +1808,11 @@ func (G *qGame) ClientThink(sent shared.Edict_s, ucmd *shared.Usercmd_t) {
 	if G.level.intermissiontime != 0 {
 		client.ps.Pmove.Pm_type = shared.PM_FREEZE
 
-		// 	 /* can exit intermission after five seconds */
-		// 	 if ((level.time > level.intermissiontime + 5.0) &&
-		// 		 (ucmd->buttons & BUTTON_ANY)) {
-		// 		 level.exitintermission = true;
-		// 	 }
+		/* can exit intermission after five seconds */
+		if (G.level.time > G.level.intermissiontime+5.0) &&
+			(ucmd.Buttons&shared.BUTTON_ANY) != 0 {
+			G.level.exitintermission = true
+		}
 
 		return
 	}
@@ -1034,12 +1878,12 @@ func (G *qGame) ClientThink(sent shared.Edict_s, ucmd *shared.Usercmd_t) {
 		client.resp.cmd_angles[1] = shared.SHORT2ANGLE(int(ucmd.Angles[1]))
 		client.resp.cmd_angles[2] = shared.SHORT2ANGLE(int(ucmd.Angles[2]))
 
-		// 	 if (ent->groundentity && !pm.groundentity && (pm.cmd.upmove >= 10) &&
-		// 		 (pm.waterlevel == 0)) {
-		// 		 gi.sound(ent, CHAN_VOICE, gi.soundindex(
-		// 						 "*jump1.wav"), 1, ATTN_NORM, 0);
-		// 		 PlayerNoise(ent, ent->s.origin, PNOISE_SELF);
-		// 	 }
+		if ent.groundentity != nil && pm.Groundentity == nil && (pm.Cmd.Upmove >= 10) &&
+			(pm.Waterlevel == 0) {
+			G.gi.Sound(ent, shared.CHAN_VOICE, G.gi.Soundindex(
+				"*jump1.wav"), 1, shared.ATTN_NORM, 0)
+			G.playerNoise(ent, ent.s.Origin[:], PNOISE_SELF)
+		}
 
 		ent.viewheight = int(pm.Viewheight)
 		ent.waterlevel = pm.Waterlevel
@@ -1095,53 +1939,53 @@ func (G *qGame) ClientThink(sent shared.Edict_s, ucmd *shared.Usercmd_t) {
 
 	client.oldbuttons = client.buttons
 	client.buttons = int(ucmd.Buttons)
-	//  client.latched_buttons |= client.buttons & ~client.oldbuttons;
+	client.latched_buttons |= client.buttons &^ client.oldbuttons
 
 	/* save light level the player is standing
 	on for monster sighting AI */
 	//  ent->light_level = ucmd->lightlevel;
 
 	/* fire weapon from final position if needed */
-	//  if (client->latched_buttons & BUTTON_ATTACK) != 0 {
-	// 	 if (client->resp.spectator) {
-	// 		 client->latched_buttons = 0;
-
-	// 		 if (client->chase_target) {
-	// 			 client->chase_target = NULL;
-	// 			 client->ps.pmove.pm_flags &= ~PMF_NO_PREDICTION;
-	// 		 } else {
-	// 			 GetChaseTarget(ent);
-	// 		 }
-	// 	 } else if (!client->weapon_thunk) {
-	// 		 client->weapon_thunk = true;
-	// 		 Think_Weapon(ent);
-	// 	 }
-	//  }
+	if (client.latched_buttons & int(shared.BUTTON_ATTACK)) != 0 {
+		if client.resp.spectator {
+			client.latched_buttons = 0
+
+			if client.chase_target != nil {
+				client.chase_target = nil
+				client.ps.Pmove.Pm_flags &^= shared.PMF_NO_PREDICTION
+			} else {
+				G.getChaseTarget(ent)
+			}
+		} else if !client.weapon_thunk {
+			client.weapon_thunk = true
+			G.thinkWeapon(ent)
+		}
+	}
 
-	//  if (client->resp.spectator) {
-	// 	 if (ucmd->upmove >= 10) {
-	// 		 if (!(client->ps.pmove.pm_flags & PMF_JUMP_HELD)) {
-	// 			 client->ps.pmove.pm_flags |= PMF_JUMP_HELD;
-
-	// 			 if (client->chase_target) {
-	// 				 ChaseNext(ent);
-	// 			 } else {
-	// 				 GetChaseTarget(ent);
-	// 			 }
-	// 		 }
-	// 	 } else {
-	// 		 client->ps.pmove.pm_flags &= ~PMF_JUMP_HELD;
-	// 	 }
-	//  }
+	if client.resp.spectator {
+		if ucmd.Upmove >= 10 {
+			if (client.ps.Pmove.Pm_flags & shared.PMF_JUMP_HELD) == 0 {
+				client.ps.Pmove.Pm_flags |= shared.PMF_JUMP_HELD
+
+				if client.chase_target != nil {
+					G.chaseNext(ent)
+				} else {
+					G.getChaseTarget(ent)
+				}
+			}
+		} else {
+			client.ps.Pmove.Pm_flags &^= shared.PMF_JUMP_HELD
+		}
+	}
 
 	/* update chase cam if being followed */
-	//  for i := 1; i <= maxclients->value; i++ {
-	// 	 other = g_edicts + i;
+	for i := 1; i <= G.maxclients.Int(); i++ {
+		other := &G.g_edicts[i]
 
-	// 	 if (other->inuse && (other->client->chase_target == ent)) {
-	// 		 UpdateChaseCam(other);
-	// 	 }
-	//  }
+		if other.inuse && (other.client.chase_target == ent) {
+			G.updateChaseCam(other)
+		}
+	}
 }
 
 /*
@@ -1149,25 +1993,23 @@ func (G *qGame) ClientThink(sent shared.Edict_s, ucmd *shared.Usercmd_t) {
  * frame, before running any other entities
  * in the world.
  */
-func (G *qGame) clientBeginServerFrame(ent *edict_t) {
+func (G *qGame) clientBeginServerFrame(ent *edict_t) error {
 
 	if ent == nil {
-		return
+		return nil
 	}
 
 	if G.level.intermissiontime != 0 {
-		return
+		return nil
 	}
 
 	client := ent.client
 
-	//  if (deathmatch->value &&
-	// 	 (client->pers.spectator != client->resp.spectator) &&
-	// 	 ((level.time - client->respawn_time) >= 5))
-	//  {
-	// 	 spectator_respawn(ent);
-	// 	 return;
-	//  }
+	if G.deathmatch.Bool() &&
+		(client.pers.spectator != client.resp.spectator) &&
+		((G.level.time - client.respawn_time) >= 5) {
+		return G.spectatorRespawn(ent)
+	}
 
 	/* run weapon animations if it hasn't been done by a ucmd_t */
 	if !client.weapon_thunk && !client.resp.spectator {
@@ -1177,28 +2019,24 @@ func (G *qGame) clientBeginServerFrame(ent *edict_t) {
 	}
 
 	if ent.deadflag != 0 {
-		// 	 /* wait for any button just going down */
-		// 	 if (level.time > client->respawn_time)
-		// 	 {
-		// 		 /* in deathmatch, only wait for attack button */
-		// 		 if (deathmatch->value)
-		// 		 {
-		// 			 buttonMask = BUTTON_ATTACK;
-		// 		 }
-		// 		 else
-		// 		 {
-		// 			 buttonMask = -1;
-		// 		 }
-
-		// 		 if ((client->latched_buttons & buttonMask) ||
-		// 			 (deathmatch->value && ((int)dmflags->value & DF_FORCE_RESPAWN)))
-		// 		 {
-		// 			 respawn(ent);
-		// 			 client->latched_buttons = 0;
-		// 		 }
-		// 	 }
+		/* wait for any button just going down */
+		if G.level.time > client.respawn_time {
+			/* in deathmatch, only wait for attack button */
+			buttonMask := -1
+			if G.deathmatch.Bool() {
+				buttonMask = int(shared.BUTTON_ATTACK)
+			}
 
-		return
+			if (client.latched_buttons&buttonMask) != 0 ||
+				(G.deathmatch.Bool() && (G.dmflags.Int()&shared.DF_FORCE_RESPAWN) != 0) {
+				if err := G.respawn(ent); err != nil {
+					return err
+				}
+				client.latched_buttons = 0
+			}
+		}
+
+		return nil
 	}
 
 	//  /* add player trail so monsters can follow */
@@ -1209,4 +2047,5 @@ func (G *qGame) clientBeginServerFrame(ent *edict_t) {
 	//  }
 
 	client.latched_buttons = 0
+	return nil
 }
@@ -0,0 +1,100 @@
+package game
+
+import (
+	"goquake2/shared"
+	"testing"
+)
+
+/*
+ * Just enough of the engine for a client to
+ * spawn, anything else panics on the nil
+ * embedded interface.
+ */
+type testImport struct {
+	shared.Game_import_t
+	cvars map[string]*shared.CvarT
+}
+
+func (gi *testImport) Cvar(name, value string, flags int) *shared.CvarT {
+	if v, ok := gi.cvars[name]; ok {
+		return v
+	}
+	v := &shared.CvarT{Name: name, String: value, Flags: flags, DefaultString: value}
+	gi.cvars[name] = v
+	return v
+}
+
+func (gi *testImport) Dprintf(format string, a ...interface{})                           {}
+func (gi *testImport) Bprintf(printlevel int, format string, a ...interface{})           {}
+func (gi *testImport) Cprintf(ent shared.Edict_s, level int, f string, a ...interface{}) {}
+func (gi *testImport) Configstring(num int, str string) error                            { return nil }
+func (gi *testImport) Modelindex(name string) int                                        { return 0 }
+func (gi *testImport) Soundindex(name string) int                                        { return 0 }
+func (gi *testImport) Imageindex(name string) int                                        { return 0 }
+func (gi *testImport) Linkentity(ent shared.Edict_s)                                     {}
+func (gi *testImport) Unlinkentity(ent shared.Edict_s)                                   {}
+func (gi *testImport) Multicast(origin []float32, to shared.Multicast_t)                 {}
+func (gi *testImport) Unicast(ent shared.Edict_s, reliable bool)                         {}
+func (gi *testImport) WriteUByte(c int)                                                  {}
+func (gi *testImport) WriteShort(c int)                                                  {}
+func (gi *testImport) WriteString(s string)                                              {}
+func (gi *testImport) Pointcontents(point []float32) int                                 { return 0 }
+
+func (gi *testImport) Sound(ent shared.Edict_s, channel, soundindex int, volume,
+	attenuation, timeofs float32) error {
+	return nil
+}
+
+func TestSpectatorToggleMidGame(t *testing.T) {
+	gi := &testImport{cvars: map[string]*shared.CvarT{
+		"deathmatch": {Name: "deathmatch", String: "1"},
+	}}
+	G := QGameCreate(gi).(*qGame)
+	G.Init()
+
+	spot, err := G.gSpawn()
+	if err != nil {
+		t.Fatal(err)
+	}
+	spot.Classname = "info_player_deathmatch"
+
+	ent := &G.g_edicts[1]
+	ent.inuse = true
+	ent.client = &G.game.clients[0]
+
+	userinfo := "\\name\\player\\skin\\male/grunt\\spectator\\0"
+	if !G.ClientConnect(ent, &userinfo) {
+		t.Fatal("ClientConnect refused the client")
+	}
+	if err := G.ClientBegin(ent); err != nil {
+		t.Fatal(err)
+	}
+	if ent.client.resp.spectator {
+		t.Fatal("client joined as a spectator")
+	}
+
+	/* "spectator 1" arrives as a clc_userinfo update */
+	for _, want := range []bool{true, false} {
+		value := "0"
+		if want {
+			value = "1"
+		}
+		userinfo = "\\name\\player\\skin\\male/grunt\\spectator\\" + value
+		G.ClientUserinfoChanged(ent, &userinfo)
+		if ent.client.pers.spectator != want {
+			t.Fatalf("spectator %v: pers.spectator = %v", value, ent.client.pers.spectator)
+		}
+
+		/* the switch waits five seconds after the last respawn */
+		G.level.time = ent.client.respawn_time + 5
+		if err := G.clientBeginServerFrame(ent); err != nil {
+			t.Fatal(err)
+		}
+		if ent.client.resp.spectator != want {
+			t.Fatalf("spectator %v: resp.spectator = %v", value, ent.client.resp.spectator)
+		}
+		if ((ent.svflags & shared.SVF_NOCLIENT) != 0) != want {
+			t.Fatalf("spectator %v: svflags = %#x", value, ent.svflags)
+		}
+	}
+}
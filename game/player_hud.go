@@ -25,15 +25,314 @@
  */
 package game
 
-import "goquake2/shared"
+import (
+	"fmt"
+	"goquake2/shared"
+	"strings"
+)
 
 /* ======================================================================= */
 
-func (G *qGame) gSetStats(ent *edict_t) {
-	// gitem_t *item;
-	// int index, cells = 0;
-	// int power_armor_type;
+func (G *qGame) moveClientToIntermission(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	if G.deathmatch.Bool() || G.coop.Bool() {
+		ent.client.showscores = true
+	}
+
+	copy(ent.s.Origin[:], G.level.intermission_origin[:])
+	ent.client.ps.Pmove.Origin[0] = int16(G.level.intermission_origin[0] * 8)
+	ent.client.ps.Pmove.Origin[1] = int16(G.level.intermission_origin[1] * 8)
+	ent.client.ps.Pmove.Origin[2] = int16(G.level.intermission_origin[2] * 8)
+	copy(ent.client.ps.Viewangles[:], G.level.intermission_angle[:])
+	ent.client.ps.Pmove.Pm_type = shared.PM_FREEZE
+	ent.client.ps.Gunindex = 0
+	ent.client.ps.Blend[3] = 0
+	ent.client.ps.Rdflags &^= shared.RDF_UNDERWATER
+
+	/* clean up powerup info */
+	ent.client.quad_framenum = 0
+	ent.client.invincible_framenum = 0
+	ent.client.breather_framenum = 0
+	ent.client.enviro_framenum = 0
+	ent.client.grenade_blew_up = false
+	ent.client.grenade_time = 0
+
+	ent.viewheight = 0
+	ent.s.Modelindex = 0
+	ent.s.Modelindex2 = 0
+	ent.s.Modelindex3 = 0
+	ent.s.Effects = 0
+	ent.s.Sound = 0
+	ent.solid = shared.SOLID_NOT
+
+	G.gi.Linkentity(ent)
+
+	/* add the layout */
+	if G.deathmatch.Bool() || G.coop.Bool() {
+		G.deathmatchScoreboardMessage(ent, nil)
+		G.gi.Unicast(ent, true)
+	}
+}
+
+func (G *qGame) beginIntermission(targ *edict_t) {
+	if targ == nil {
+		return
+	}
+
+	if G.level.intermissiontime != 0 {
+		return /* already activated */
+	}
+
+	G.game.autosaved = false
+
+	/* respawn any dead clients */
+	for i := 0; i < G.maxclients.Int(); i++ {
+		client := &G.g_edicts[1+i]
+
+		if !client.inuse {
+			continue
+		}
+
+		if client.Health <= 0 {
+			if err := G.respawn(client); err != nil {
+				G.gi.Dprintf("%s: %v\n", client.client.pers.netname, err)
+			}
+		}
+	}
+
+	G.level.intermissiontime = G.level.time
+	G.level.changemap = targ.Map
+
+	if strings.Contains(G.level.changemap, "*") {
+		if G.coop.Bool() {
+			for i := 0; i < G.maxclients.Int(); i++ {
+				client := &G.g_edicts[1+i]
+
+				if !client.inuse {
+					continue
+				}
+
+				/* strip players of all keys between units */
+				for n := range gameitemlist {
+					if (gameitemlist[n].flags & IT_KEY) != 0 {
+						client.client.pers.inventory[n] = 0
+					}
+				}
+			}
+		}
+	} else {
+		if !G.deathmatch.Bool() {
+			G.level.exitintermission = true /* go immediately to the next level */
+			return
+		}
+	}
+
+	G.level.exitintermission = false
+
+	/* find an intermission spot */
+	ent := G.gFind(nil, "Classname", "info_player_intermission")
+
+	if ent == nil {
+		/* the map creator forgot to put in an intermission point... */
+		ent = G.gFind(nil, "Classname", "info_player_start")
+
+		if ent == nil {
+			ent = G.gFind(nil, "Classname", "info_player_deathmatch")
+		}
+	} else {
+		/* chose one of four spots */
+		i := shared.Randk() & 3
+
+		for ; i > 0; i-- {
+			ent = G.gFind(ent, "Classname", "info_player_intermission")
+
+			if ent == nil { /* wrap around the list */
+				ent = G.gFind(ent, "Classname", "info_player_intermission")
+			}
+		}
+	}
+
+	if ent != nil {
+		copy(G.level.intermission_origin[:], ent.s.Origin[:])
+		copy(G.level.intermission_angle[:], ent.s.Angles[:])
+	}
+
+	/* move all clients to the intermission point */
+	for i := 0; i < G.maxclients.Int(); i++ {
+		client := &G.g_edicts[1+i]
+
+		if !client.inuse {
+			continue
+		}
+
+		G.moveClientToIntermission(client)
+	}
+}
+
+func (G *qGame) deathmatchScoreboardMessage(ent, killer *edict_t) {
+	if ent == nil { /* killer can be NULL */
+		return
+	}
+
+	var sorted [shared.MAX_CLIENTS]int
+	var sortedscores [shared.MAX_CLIENTS]int
+
+	/* sort the clients by score */
+	total := 0
+
+	for i := 0; i < G.game.maxclients; i++ {
+		cl_ent := &G.g_edicts[1+i]
+
+		if !cl_ent.inuse || G.game.clients[i].resp.spectator {
+			continue
+		}
+
+		score := G.game.clients[i].resp.score
+
+		j := 0
+		for ; j < total; j++ {
+			if score > sortedscores[j] {
+				break
+			}
+		}
+
+		for k := total; k > j; k-- {
+			sorted[k] = sorted[k-1]
+			sortedscores[k] = sortedscores[k-1]
+		}
+
+		sorted[j] = i
+		sortedscores[j] = score
+		total++
+	}
+
+	/* print level name and exit rules */
+	var str strings.Builder
+
+	/* add the clients in sorted order */
+	if total > 12 {
+		total = 12
+	}
+
+	for i := 0; i < total; i++ {
+		cl := &G.game.clients[sorted[i]]
+		cl_ent := &G.g_edicts[1+sorted[i]]
+
+		x := 0
+		if i >= 6 {
+			x = 160
+		}
+
+		y := 32 + 32*(i%6)
+
+		/* add a dogtag */
+		tag := ""
+		if cl_ent == ent {
+			tag = "tag1"
+		} else if cl_ent == killer {
+			tag = "tag2"
+		}
+
+		if len(tag) > 0 {
+			entry := fmt.Sprintf("xv %v yv %v picn %v ", x+32, y, tag)
 
+			if 1024-str.Len() < len(entry) {
+				break
+			}
+
+			str.WriteString(entry)
+		}
+
+		/* send the layout */
+		entry := fmt.Sprintf("client %v %v %v %v %v %v ",
+			x, y, sorted[i], cl.resp.score, cl.ping,
+			(G.level.framenum-cl.resp.enterframe)/600)
+
+		if 1024-str.Len() < len(entry) {
+			break
+		}
+
+		str.WriteString(entry)
+	}
+
+	G.gi.WriteUByte(shared.SvcLayout)
+	G.gi.WriteString(str.String())
+}
+
+/*
+ * Draw instead of help message.
+ * Note that it isn't that hard to
+ * overflow the 1400 byte message limit!
+ */
+func (G *qGame) deathmatchScoreboard(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	G.deathmatchScoreboardMessage(ent, ent.enemy)
+	G.gi.Unicast(ent, true)
+}
+
+/*
+ * Display the scoreboard
+ */
+func (G *qGame) cmd_Score_f(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	ent.client.showinventory = false
+	ent.client.showhelp = false
+
+	if !G.deathmatch.Bool() && !G.coop.Bool() {
+		return
+	}
+
+	if ent.client.showscores {
+		ent.client.showscores = false
+		return
+	}
+
+	ent.client.showscores = true
+	G.deathmatchScoreboard(ent)
+}
+
+/*
+ * Display the current help message
+ */
+func (G *qGame) cmd_Help_f(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	/* this is for backwards compatability */
+	if G.deathmatch.Bool() {
+		G.cmd_Score_f(ent)
+		return
+	}
+
+	ent.client.showinventory = false
+	ent.client.showscores = false
+
+	// if (ent->client->showhelp &&
+	// 	(ent->client->pers.game_helpchanged == game.helpchanged))
+	// {
+	// 	ent->client->showhelp = false;
+	// 	return;
+	// }
+
+	// ent->client->showhelp = true;
+	// ent->client->pers.helpchanged = 0;
+	// HelpComputerMessage(ent);
+	// gi.unicast(ent, true);
+}
+
+/* ======================================================================= */
+
+func (G *qGame) gSetStats(ent *edict_t) {
 	if ent == nil {
 		return
 	}
@@ -54,32 +353,28 @@ func (G *qGame) gSetStats(ent *edict_t) {
 	}
 
 	/* armor */
-	// power_armor_type = PowerArmorType(ent);
+	power_armor_type := G.powerArmorType(ent)
+	cells := 0
 
-	// if (power_armor_type)
-	// {
-	// 	cells = ent->client->pers.inventory[ITEM_INDEX(FindItem("cells"))];
+	if power_armor_type != POWER_ARMOR_NONE {
+		cells = ent.client.pers.inventory[G.findItemIndex("cells")]
 
-	// 	if (cells == 0)
-	// 	{
-	// 		/* ran out of cells for power armor */
-	// 		ent->flags &= ~FL_POWER_ARMOR;
-	// 		gi.sound(ent, CHAN_ITEM, gi.soundindex(
-	// 						"misc/power2.wav"), 1, ATTN_NORM, 0);
-	// 		power_armor_type = 0;
-	// 	}
-	// }
+		if cells == 0 {
+			/* ran out of cells for power armor */
+			ent.flags &^= FL_POWER_ARMOR
+			G.gi.Sound(ent, shared.CHAN_ITEM, G.gi.Soundindex(
+				"misc/power2.wav"), 1, shared.ATTN_NORM, 0)
+			power_armor_type = POWER_ARMOR_NONE
+		}
+	}
 
 	index := G.armorIndex(ent)
 
-	// if (power_armor_type && (!index || (level.framenum & 8)))
-	// {
-	// 	/* flash between power armor and other armor icon */
-	// 	ent->client->ps.stats[STAT_ARMOR_ICON] = gi.imageindex("i_powershield");
-	// 	ent->client->ps.stats[STAT_ARMOR] = cells;
-	// }
-	// else
-	if index != 0 {
+	if power_armor_type != POWER_ARMOR_NONE && (index == 0 || (G.level.framenum&8) != 0) {
+		/* flash between power armor and other armor icon */
+		ent.client.ps.Stats[shared.STAT_ARMOR_ICON] = int16(G.gi.Imageindex("i_powershield"))
+		ent.client.ps.Stats[shared.STAT_ARMOR] = int16(cells)
+	} else if index != 0 {
 		item := getItemByIndex(index)
 		ent.client.ps.Stats[shared.STAT_ARMOR_ICON] = int16(G.gi.Imageindex(item.icon))
 		ent.client.ps.Stats[shared.STAT_ARMOR] = int16(ent.client.pers.inventory[index])
@@ -88,55 +383,42 @@ func (G *qGame) gSetStats(ent *edict_t) {
 		ent.client.ps.Stats[shared.STAT_ARMOR] = 0
 	}
 
-	// /* pickup message */
-	// if (level.time > ent->client->pickup_msg_time)
-	// {
-	// ent->client->ps.stats[STAT_PICKUP_ICON] = 0;
-	// ent->client->ps.stats[STAT_PICKUP_STRING] = 0;
-	// }
+	/* pickup message */
+	if G.level.time > ent.client.pickup_msg_time {
+		ent.client.ps.Stats[shared.STAT_PICKUP_ICON] = 0
+		ent.client.ps.Stats[shared.STAT_PICKUP_STRING] = 0
+	}
 
-	// /* timers */
-	// if (ent->client->quad_framenum > level.framenum)
-	// {
-	// 	ent->client->ps.stats[STAT_TIMER_ICON] = gi.imageindex("p_quad");
-	// 	ent->client->ps.stats[STAT_TIMER] =
-	// 		(ent->client->quad_framenum - level.framenum) / 10;
-	// }
-	// else if (ent->client->invincible_framenum > level.framenum)
-	// {
-	// 	ent->client->ps.stats[STAT_TIMER_ICON] = gi.imageindex(
-	// 			"p_invulnerability");
-	// 	ent->client->ps.stats[STAT_TIMER] =
-	// 		(ent->client->invincible_framenum - level.framenum) / 10;
-	// }
-	// else if (ent->client->enviro_framenum > level.framenum)
-	// {
-	// 	ent->client->ps.stats[STAT_TIMER_ICON] = gi.imageindex("p_envirosuit");
-	// 	ent->client->ps.stats[STAT_TIMER] =
-	// 		(ent->client->enviro_framenum - level.framenum) / 10;
-	// }
-	// else if (ent->client->breather_framenum > level.framenum)
-	// {
-	// 	ent->client->ps.stats[STAT_TIMER_ICON] = gi.imageindex("p_rebreather");
-	// 	ent->client->ps.stats[STAT_TIMER] =
-	// 		(ent->client->breather_framenum - level.framenum) / 10;
-	// }
-	// else
-	// {
-	// 	ent->client->ps.stats[STAT_TIMER_ICON] = 0;
-	// 	ent->client->ps.stats[STAT_TIMER] = 0;
-	// }
+	/* timers */
+	if ent.client.quad_framenum > float32(G.level.framenum) {
+		ent.client.ps.Stats[shared.STAT_TIMER_ICON] = int16(G.gi.Imageindex("p_quad"))
+		ent.client.ps.Stats[shared.STAT_TIMER] =
+			int16((ent.client.quad_framenum - float32(G.level.framenum)) / 10)
+	} else if ent.client.invincible_framenum > float32(G.level.framenum) {
+		ent.client.ps.Stats[shared.STAT_TIMER_ICON] = int16(G.gi.Imageindex(
+			"p_invulnerability"))
+		ent.client.ps.Stats[shared.STAT_TIMER] =
+			int16((ent.client.invincible_framenum - float32(G.level.framenum)) / 10)
+	} else if ent.client.enviro_framenum > float32(G.level.framenum) {
+		ent.client.ps.Stats[shared.STAT_TIMER_ICON] = int16(G.gi.Imageindex("p_envirosuit"))
+		ent.client.ps.Stats[shared.STAT_TIMER] =
+			int16((ent.client.enviro_framenum - float32(G.level.framenum)) / 10)
+	} else if ent.client.breather_framenum > float32(G.level.framenum) {
+		ent.client.ps.Stats[shared.STAT_TIMER_ICON] = int16(G.gi.Imageindex("p_rebreather"))
+		ent.client.ps.Stats[shared.STAT_TIMER] =
+			int16((ent.client.breather_framenum - float32(G.level.framenum)) / 10)
+	} else {
+		ent.client.ps.Stats[shared.STAT_TIMER_ICON] = 0
+		ent.client.ps.Stats[shared.STAT_TIMER] = 0
+	}
 
-	// /* selected item */
-	// if (ent->client->pers.selected_item == -1)
-	// {
-	// 	ent->client->ps.stats[STAT_SELECTED_ICON] = 0;
-	// }
-	// else
-	// {
-	// 	ent->client->ps.stats[STAT_SELECTED_ICON] =
-	// 		gi.imageindex(itemlist[ent->client->pers.selected_item].icon);
-	// }
+	/* selected item */
+	if item := getItemByIndex(ent.client.pers.selected_item); item == nil {
+		ent.client.ps.Stats[shared.STAT_SELECTED_ICON] = 0
+	} else {
+		ent.client.ps.Stats[shared.STAT_SELECTED_ICON] =
+			int16(G.gi.Imageindex(item.icon))
+	}
 
 	ent.client.ps.Stats[shared.STAT_SELECTED_ITEM] = int16(ent.client.pers.selected_item)
 
@@ -144,16 +426,14 @@ func (G *qGame) gSetStats(ent *edict_t) {
 	ent.client.ps.Stats[shared.STAT_LAYOUTS] = 0
 
 	if G.deathmatch.Bool() {
-		// 	if ((ent->client->pers.health <= 0) || level.intermissiontime ||
-		// 		ent->client->showscores)
-		// 	{
-		// 		ent->client->ps.stats[STAT_LAYOUTS] |= 1;
-		// 	}
-
-		// 	if (ent->client->showinventory && (ent->client->pers.health > 0))
-		// 	{
-		// 		ent->client->ps.stats[STAT_LAYOUTS] |= 2;
-		// 	}
+		if (ent.client.pers.health <= 0) || G.level.intermissiontime != 0 ||
+			ent.client.showscores {
+			ent.client.ps.Stats[shared.STAT_LAYOUTS] |= 1
+		}
+
+		if ent.client.showinventory && (ent.client.pers.health > 0) {
+			ent.client.ps.Stats[shared.STAT_LAYOUTS] |= 2
+		}
 	} else {
 		if ent.client.showscores || ent.client.showhelp {
 			ent.client.ps.Stats[shared.STAT_LAYOUTS] |= 1
@@ -191,3 +471,64 @@ func (G *qGame) gSetStats(ent *edict_t) {
 
 	ent.client.ps.Stats[shared.STAT_SPECTATOR] = 0
 }
+
+func (G *qGame) gCheckChaseStats(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	for i := 1; i <= G.maxclients.Int(); i++ {
+		cl := G.g_edicts[i].client
+
+		if !G.g_edicts[i].inuse || (cl.chase_target != ent) {
+			continue
+		}
+
+		cl.ps.Stats = ent.client.ps.Stats
+		G.gSetSpectatorStats(&G.g_edicts[i])
+	}
+}
+
+func (G *qGame) gSetSpectatorStats(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	cl := ent.client
+
+	if cl.chase_target == nil {
+		G.gSetStats(ent)
+	}
+
+	cl.ps.Stats[shared.STAT_SPECTATOR] = 1
+
+	/* layouts are independant in spectator */
+	cl.ps.Stats[shared.STAT_LAYOUTS] = 0
+
+	if (cl.pers.health <= 0) || G.level.intermissiontime != 0 || cl.showscores {
+		cl.ps.Stats[shared.STAT_LAYOUTS] |= 1
+	}
+
+	if cl.showinventory && (cl.pers.health > 0) {
+		cl.ps.Stats[shared.STAT_LAYOUTS] |= 2
+	}
+
+	if cl.chase_target != nil && cl.chase_target.inuse {
+		cl.ps.Stats[shared.STAT_CHASE] = int16(shared.CS_PLAYERSKINS +
+			cl.chase_target.index - 1)
+	} else {
+		cl.ps.Stats[shared.STAT_CHASE] = 0
+	}
+}
+
+func (G *qGame) inventoryMessage(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	G.gi.WriteUByte(shared.SvcInventory)
+
+	for i := 0; i < shared.MAX_ITEMS; i++ {
+		G.gi.WriteShort(ent.client.pers.inventory[i])
+	}
+}
@@ -175,6 +175,367 @@ func (G *qGame) svCalcViewOffset(ent *edict_t) {
 	copy(ent.client.ps.Viewoffset[:], v)
 }
 
+func svAddBlend(r, g, b, a float32, v_blend []float32) {
+	if a <= 0 {
+		return
+	}
+
+	a2 := v_blend[3] + (1-v_blend[3])*a /* new total alpha */
+	a3 := v_blend[3] / a2               /* fraction of color from old */
+
+	v_blend[0] = v_blend[0]*a3 + r*(1-a3)
+	v_blend[1] = v_blend[1]*a3 + g*(1-a3)
+	v_blend[2] = v_blend[2]*a3 + b*(1-a3)
+	v_blend[3] = a2
+}
+
+func (G *qGame) svCalcBlend(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	ent.client.ps.Blend[0] = 0
+	ent.client.ps.Blend[1] = 0
+	ent.client.ps.Blend[2] = 0
+	ent.client.ps.Blend[3] = 0
+
+	/* add for contents */
+	vieworg := make([]float32, 3)
+	shared.VectorAdd(ent.s.Origin[:], ent.client.ps.Viewoffset[:], vieworg)
+	contents := G.gi.Pointcontents(vieworg)
+
+	if (contents & (shared.CONTENTS_LAVA | shared.CONTENTS_SLIME | shared.CONTENTS_WATER)) != 0 {
+		ent.client.ps.Rdflags |= shared.RDF_UNDERWATER
+	} else {
+		ent.client.ps.Rdflags &^= shared.RDF_UNDERWATER
+	}
+
+	if (contents & (shared.CONTENTS_SOLID | shared.CONTENTS_LAVA)) != 0 {
+		svAddBlend(1.0, 0.3, 0.0, 0.6, ent.client.ps.Blend[:])
+	} else if (contents & shared.CONTENTS_SLIME) != 0 {
+		svAddBlend(0.0, 0.1, 0.05, 0.6, ent.client.ps.Blend[:])
+	} else if (contents & shared.CONTENTS_WATER) != 0 {
+		svAddBlend(0.5, 0.3, 0.2, 0.4, ent.client.ps.Blend[:])
+	}
+
+	/* add for powerups */
+	if ent.client.quad_framenum > float32(G.level.framenum) {
+		remaining := int(ent.client.quad_framenum) - G.level.framenum
+
+		if remaining == 30 { /* beginning to fade */
+			G.gi.Sound(ent, shared.CHAN_ITEM, G.gi.Soundindex(
+				"items/damage2.wav"), 1, shared.ATTN_NORM, 0)
+		}
+
+		if (remaining > 30) || (remaining&4) != 0 {
+			svAddBlend(0, 0, 1, 0.08, ent.client.ps.Blend[:])
+		}
+	} else if ent.client.invincible_framenum > float32(G.level.framenum) {
+		remaining := int(ent.client.invincible_framenum) - G.level.framenum
+
+		if remaining == 30 { /* beginning to fade */
+			G.gi.Sound(ent, shared.CHAN_ITEM, G.gi.Soundindex(
+				"items/protect2.wav"), 1, shared.ATTN_NORM, 0)
+		}
+
+		if (remaining > 30) || (remaining&4) != 0 {
+			svAddBlend(1, 1, 0, 0.08, ent.client.ps.Blend[:])
+		}
+	} else if ent.client.enviro_framenum > float32(G.level.framenum) {
+		remaining := int(ent.client.enviro_framenum) - G.level.framenum
+
+		if remaining == 30 { /* beginning to fade */
+			G.gi.Sound(ent, shared.CHAN_ITEM, G.gi.Soundindex(
+				"items/airout.wav"), 1, shared.ATTN_NORM, 0)
+		}
+
+		if (remaining > 30) || (remaining&4) != 0 {
+			svAddBlend(0, 1, 0, 0.08, ent.client.ps.Blend[:])
+		}
+	} else if ent.client.breather_framenum > float32(G.level.framenum) {
+		remaining := int(ent.client.breather_framenum) - G.level.framenum
+
+		if remaining == 30 { /* beginning to fade */
+			G.gi.Sound(ent, shared.CHAN_ITEM, G.gi.Soundindex(
+				"items/airout.wav"), 1, shared.ATTN_NORM, 0)
+		}
+
+		if (remaining > 30) || (remaining&4) != 0 {
+			svAddBlend(0.4, 1, 0.4, 0.04, ent.client.ps.Blend[:])
+		}
+	}
+
+	/* add for damage */
+	if ent.client.damage_alpha > 0 {
+		svAddBlend(ent.client.damage_blend[0], ent.client.damage_blend[1],
+			ent.client.damage_blend[2], ent.client.damage_alpha, ent.client.ps.Blend[:])
+	}
+
+	if ent.client.bonus_alpha > 0 {
+		svAddBlend(0.85, 0.7, 0.3, ent.client.bonus_alpha, ent.client.ps.Blend[:])
+	}
+
+	/* drop the damage value */
+	ent.client.damage_alpha -= 0.06
+
+	if ent.client.damage_alpha < 0 {
+		ent.client.damage_alpha = 0
+	}
+
+	/* drop the bonus value */
+	ent.client.bonus_alpha -= 0.1
+
+	if ent.client.bonus_alpha < 0 {
+		ent.client.bonus_alpha = 0
+	}
+}
+
+func (G *qGame) pWorldEffects() {
+	current_player := G.current_player
+	current_client := G.current_client
+
+	if current_player.movetype == MOVETYPE_NOCLIP {
+		current_player.air_finished = G.level.time + 12 /* don't need air */
+		return
+	}
+
+	waterlevel := current_player.waterlevel
+	old_waterlevel := current_client.old_waterlevel
+	current_client.old_waterlevel = waterlevel
+
+	breather := current_client.breather_framenum > float32(G.level.framenum)
+	envirosuit := current_client.enviro_framenum > float32(G.level.framenum)
+
+	/* if just entered a water volume, play a sound */
+	if old_waterlevel == 0 && waterlevel != 0 {
+		G.playerNoise(current_player, current_player.s.Origin[:], PNOISE_SELF)
+
+		if (current_player.watertype & shared.CONTENTS_LAVA) != 0 {
+			G.gi.Sound(current_player, shared.CHAN_BODY,
+				G.gi.Soundindex("player/lava_in.wav"), 1, shared.ATTN_NORM, 0)
+		} else if (current_player.watertype & shared.CONTENTS_SLIME) != 0 {
+			G.gi.Sound(current_player, shared.CHAN_BODY,
+				G.gi.Soundindex("player/watr_in.wav"), 1, shared.ATTN_NORM, 0)
+		} else if (current_player.watertype & shared.CONTENTS_WATER) != 0 {
+			G.gi.Sound(current_player, shared.CHAN_BODY,
+				G.gi.Soundindex("player/watr_in.wav"), 1, shared.ATTN_NORM, 0)
+		}
+
+		current_player.flags |= FL_INWATER
+
+		/* clear damage_debounce, so the pain sound will play immediately */
+		current_player.damage_debounce_time = G.level.time - 1
+	}
+
+	/* if just completely exited a water volume, play a sound */
+	if old_waterlevel != 0 && waterlevel == 0 {
+		G.playerNoise(current_player, current_player.s.Origin[:], PNOISE_SELF)
+		G.gi.Sound(current_player, shared.CHAN_BODY,
+			G.gi.Soundindex("player/watr_out.wav"), 1, shared.ATTN_NORM, 0)
+		current_player.flags &^= FL_INWATER
+	}
+
+	/* check for head just going under water */
+	if (old_waterlevel != 3) && (waterlevel == 3) {
+		G.gi.Sound(current_player, shared.CHAN_BODY,
+			G.gi.Soundindex("player/watr_un.wav"), 1, shared.ATTN_NORM, 0)
+	}
+
+	/* check for head just coming out of water */
+	if (old_waterlevel == 3) && (waterlevel != 3) {
+		if current_player.air_finished < G.level.time {
+			/* gasp for air */
+			G.gi.Sound(current_player, shared.CHAN_VOICE,
+				G.gi.Soundindex("player/gasp1.wav"), 1, shared.ATTN_NORM, 0)
+			G.playerNoise(current_player, current_player.s.Origin[:], PNOISE_SELF)
+		} else if current_player.air_finished < G.level.time+11 {
+			/* just break surface */
+			G.gi.Sound(current_player, shared.CHAN_VOICE,
+				G.gi.Soundindex("player/gasp2.wav"), 1, shared.ATTN_NORM, 0)
+		}
+	}
+
+	/* check for drowning */
+	if waterlevel == 3 {
+		/* breather or envirosuit give air */
+		if breather || envirosuit {
+			current_player.air_finished = G.level.time + 10
+
+			if (int(current_client.breather_framenum)-G.level.framenum)%25 == 0 {
+				if current_client.breather_sound == 0 {
+					G.gi.Sound(current_player, shared.CHAN_AUTO,
+						G.gi.Soundindex("player/u_breath1.wav"), 1, shared.ATTN_NORM, 0)
+				} else {
+					G.gi.Sound(current_player, shared.CHAN_AUTO,
+						G.gi.Soundindex("player/u_breath2.wav"), 1, shared.ATTN_NORM, 0)
+				}
+
+				current_client.breather_sound ^= 1
+				G.playerNoise(current_player, current_player.s.Origin[:], PNOISE_SELF)
+			}
+		}
+
+		/* if out of air, start drowning */
+		if current_player.air_finished < G.level.time {
+			/* drown! */
+			if (current_player.client.next_drown_time < G.level.time) &&
+				(current_player.Health > 0) {
+				current_player.client.next_drown_time = G.level.time + 1
+
+				/* take more damage the longer underwater */
+				current_player.Dmg += 2
+
+				if current_player.Dmg > 15 {
+					current_player.Dmg = 15
+				}
+
+				/* play a gurp sound instead of a normal pain sound */
+				if current_player.Health <= current_player.Dmg {
+					G.gi.Sound(current_player, shared.CHAN_VOICE,
+						G.gi.Soundindex("player/drown1.wav"), 1, shared.ATTN_NORM, 0)
+				} else if (shared.Randk() & 1) != 0 {
+					G.gi.Sound(current_player, shared.CHAN_VOICE,
+						G.gi.Soundindex("*gurp1.wav"), 1, shared.ATTN_NORM, 0)
+				} else {
+					G.gi.Sound(current_player, shared.CHAN_VOICE,
+						G.gi.Soundindex("*gurp2.wav"), 1, shared.ATTN_NORM, 0)
+				}
+
+				current_player.pain_debounce_time = G.level.time
+
+				G.tDamage(current_player, &G.g_edicts[0], &G.g_edicts[0], []float32{0, 0, 0},
+					current_player.s.Origin[:], []float32{0, 0, 0}, current_player.Dmg,
+					0, DAMAGE_NO_ARMOR, MOD_WATER)
+			}
+		}
+	} else {
+		current_player.air_finished = G.level.time + 12
+		current_player.Dmg = 2
+	}
+
+	/* check for sizzle damage */
+	if waterlevel != 0 &&
+		(current_player.watertype&(shared.CONTENTS_LAVA|shared.CONTENTS_SLIME)) != 0 {
+		if (current_player.watertype & shared.CONTENTS_LAVA) != 0 {
+			if (current_player.Health > 0) &&
+				(current_player.pain_debounce_time <= G.level.time) &&
+				(current_client.invincible_framenum < float32(G.level.framenum)) {
+				if (shared.Randk() & 1) != 0 {
+					G.gi.Sound(current_player, shared.CHAN_VOICE,
+						G.gi.Soundindex("player/burn1.wav"), 1, shared.ATTN_NORM, 0)
+				} else {
+					G.gi.Sound(current_player, shared.CHAN_VOICE,
+						G.gi.Soundindex("player/burn2.wav"), 1, shared.ATTN_NORM, 0)
+				}
+
+				current_player.pain_debounce_time = G.level.time + 1
+			}
+
+			if envirosuit { /* take 1/3 damage with envirosuit */
+				G.tDamage(current_player, &G.g_edicts[0], &G.g_edicts[0], []float32{0, 0, 0},
+					current_player.s.Origin[:], []float32{0, 0, 0}, 1*waterlevel,
+					0, 0, MOD_LAVA)
+			} else {
+				G.tDamage(current_player, &G.g_edicts[0], &G.g_edicts[0], []float32{0, 0, 0},
+					current_player.s.Origin[:], []float32{0, 0, 0}, 3*waterlevel,
+					0, 0, MOD_LAVA)
+			}
+		}
+
+		if (current_player.watertype & shared.CONTENTS_SLIME) != 0 {
+			if !envirosuit { /* no damage from slime with envirosuit */
+				G.tDamage(current_player, &G.g_edicts[0], &G.g_edicts[0], []float32{0, 0, 0},
+					current_player.s.Origin[:], []float32{0, 0, 0}, 1*waterlevel,
+					0, 0, MOD_SLIME)
+			}
+		}
+	}
+}
+
+func (G *qGame) gSetClientEffects(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	ent.s.Effects = 0
+	ent.s.Renderfx = shared.RF_IR_VISIBLE
+
+	if (ent.Health <= 0) || G.level.intermissiontime != 0 {
+		return
+	}
+
+	if ent.powerarmor_time > G.level.time {
+		pa_type := G.powerArmorType(ent)
+
+		if pa_type == POWER_ARMOR_SCREEN {
+			ent.s.Effects |= shared.EF_POWERSCREEN
+		} else if pa_type == POWER_ARMOR_SHIELD {
+			ent.s.Effects |= shared.EF_COLOR_SHELL
+			ent.s.Renderfx |= shared.RF_SHELL_GREEN
+		}
+	}
+
+	if ent.client.quad_framenum > float32(G.level.framenum) {
+		remaining := int(ent.client.quad_framenum) - G.level.framenum
+
+		if (remaining > 30) || (remaining&4) != 0 {
+			ent.s.Effects |= shared.EF_QUAD
+		}
+	}
+
+	if ent.client.invincible_framenum > float32(G.level.framenum) {
+		remaining := int(ent.client.invincible_framenum) - G.level.framenum
+
+		if (remaining > 30) || (remaining&4) != 0 {
+			ent.s.Effects |= shared.EF_PENT
+		}
+	}
+
+	/* show cheaters!!! */
+	if (ent.flags & FL_GODMODE) != 0 {
+		ent.s.Effects |= shared.EF_COLOR_SHELL
+		ent.s.Renderfx |= (shared.RF_SHELL_RED | shared.RF_SHELL_GREEN | shared.RF_SHELL_BLUE)
+	}
+}
+
+func (G *qGame) gSetClientSound(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	// if (ent->client->pers.game_helpchanged != game.helpchanged)
+	// {
+	// 	ent->client->pers.game_helpchanged = game.helpchanged;
+	// 	ent->client->pers.helpchanged = 1;
+	// }
+
+	// /* help beep (no more than three times) */
+	// if (ent->client->pers.helpchanged &&
+	// 	(ent->client->pers.helpchanged <= 3) && !(level.framenum & 63))
+	// {
+	// 	ent->client->pers.helpchanged++;
+	// 	gi.sound(ent, CHAN_VOICE, gi.soundindex("misc/pc_up.wav"), 1, ATTN_STATIC, 0);
+	// }
+
+	weap := ""
+	if ent.client.pers.weapon != nil {
+		weap = ent.client.pers.weapon.classname
+	}
+
+	if ent.waterlevel != 0 &&
+		(ent.watertype&(shared.CONTENTS_LAVA|shared.CONTENTS_SLIME)) != 0 {
+		ent.s.Sound = G.snd_fry
+	} else if weap == "weapon_railgun" {
+		ent.s.Sound = G.gi.Soundindex("weapons/rg_hum.wav")
+	} else if weap == "weapon_bfg" {
+		ent.s.Sound = G.gi.Soundindex("weapons/bfg_hum.wav")
+	} else if ent.client.weapon_sound != 0 {
+		ent.s.Sound = ent.client.weapon_sound
+	} else {
+		ent.s.Sound = 0
+	}
+}
+
 /*
  * Called for each player at the end of
  * the server frame and right after spawning
@@ -202,17 +563,17 @@ func (G *qGame) clientEndServerFrame(ent *edict_t) {
 
 	/* If the end of unit layout is displayed, don't give
 	the player any normal movement attributes */
-	//  if (level.intermissiontime) {
-	// 	 current_client->ps.blend[3] = 0;
-	// 	 current_client->ps.fov = 90;
-	// 	 G_SetStats(ent);
-	// 	 return;
-	//  }
+	if G.level.intermissiontime != 0 {
+		G.current_client.ps.Blend[3] = 0
+		G.current_client.ps.Fov = 90
+		G.gSetStats(ent)
+		return
+	}
 
 	shared.AngleVectors(ent.client.v_angle[:], G.player_view_forward[:], G.player_view_right[:], G.player_view_up[:])
 
 	/* burn from lava, etc */
-	//  P_WorldEffects();
+	G.pWorldEffects()
 
 	/* set model angles from view angles so other things in
 	the world can tell which direction you are looking */
@@ -271,25 +632,22 @@ func (G *qGame) clientEndServerFrame(ent *edict_t) {
 	/* determine the full screen color blend
 	must be after viewoffset, so eye contents
 	can be accurately determined */
-	//  SV_CalcBlend(ent);
+	G.svCalcBlend(ent)
 
 	/* chase cam stuff */
-	//  if (ent->client->resp.spectator)
-	//  {
-	// 	 G_SetSpectatorStats(ent);
-	//  }
-	//  else
-	//  {
-	G.gSetStats(ent)
-	//  }
+	if ent.client.resp.spectator {
+		G.gSetSpectatorStats(ent)
+	} else {
+		G.gSetStats(ent)
+	}
 
-	//  G_CheckChaseStats(ent);
+	G.gCheckChaseStats(ent)
 
 	//  G_SetClientEvent(ent);
 
-	//  G_SetClientEffects(ent);
+	G.gSetClientEffects(ent)
 
-	//  G_SetClientSound(ent);
+	G.gSetClientSound(ent)
 
 	//  G_SetClientFrame(ent);
 
@@ -301,12 +659,11 @@ func (G *qGame) clientEndServerFrame(ent *edict_t) {
 	copy(ent.client.kick_angles[:], []float32{0, 0, 0})
 
 	if (G.level.framenum & 31) == 0 {
-		// 	 /* if the scoreboard is up, update it */
-		// 	 if (ent->client->showscores)
-		// 	 {
-		// 		 DeathmatchScoreboardMessage(ent, ent->enemy);
-		// 		 gi.unicast(ent, false);
-		// 	 }
+		/* if the scoreboard is up, update it */
+		if ent.client.showscores {
+			G.deathmatchScoreboardMessage(ent, ent.enemy)
+			G.gi.Unicast(ent, false)
+		}
 
 		// 	 /* if the help computer is up, update it */
 		// 	 if (ent->client->showhelp)
@@ -318,8 +675,8 @@ func (G *qGame) clientEndServerFrame(ent *edict_t) {
 	}
 
 	/* if the inventory is up, update it */
-	// if ent.client.showinventory {
-	// 	 InventoryMessage(ent);
-	// 	 gi.unicast(ent, false);
-	// }
+	if ent.client.showinventory {
+		G.inventoryMessage(ent)
+		G.gi.Unicast(ent, false)
+	}
 }
@@ -28,6 +28,7 @@ package game
 import (
 	"goquake2/game/misc"
 	"goquake2/shared"
+	"math"
 )
 
 func (G *qGame) projectSource(ent *edict_t, distance,
@@ -82,10 +83,10 @@ func (G *qGame) playerNoise(who *edict_t, where []float32, ntype int) {
 	}
 
 	if ntype == PNOISE_WEAPON {
-		// 	 if (who->client->silencer_shots) {
-		// 		 who->client->silencer_shots--;
-		// 		 return;
-		// 	 }
+		if who.client.silencer_shots != 0 {
+			who.client.silencer_shots--
+			return
+		}
 	}
 
 	//  if (deathmatch->value) {
@@ -140,6 +141,58 @@ func (G *qGame) playerNoise(who *edict_t, where []float32, ntype int) {
 	G.gi.Linkentity(noise)
 }
 
+func Pickup_Weapon(ent, other *edict_t, G *qGame) bool {
+	if ent == nil || other == nil || G == nil {
+		return false
+	}
+
+	index := itemIndex(ent.item)
+
+	if ((G.dmflags.Int()&shared.DF_WEAPONS_STAY) != 0 || G.coop.Bool()) &&
+		other.client.pers.inventory[index] > 0 {
+		if (ent.Spawnflags&(DROPPED_ITEM|DROPPED_PLAYER_ITEM)) == 0 &&
+			(!G.coop_pickup_weapons.Bool() || (ent.flags&FL_COOP_TAKEN) != 0) {
+			return false /* leave the weapon for others to pickup */
+		}
+	}
+
+	other.client.pers.inventory[index]++
+
+	if (ent.Spawnflags & DROPPED_ITEM) == 0 {
+		/* give them some ammo with it */
+		ammo := G.findItem(ent.item.ammo)
+
+		if (G.dmflags.Int() & shared.DF_INFINITE_AMMO) != 0 {
+			G.addAmmo(other, ammo, 1000)
+		} else {
+			G.addAmmo(other, ammo, ammo.quantity)
+		}
+
+		if (ent.Spawnflags & DROPPED_PLAYER_ITEM) == 0 {
+			if G.deathmatch.Bool() {
+				if (G.dmflags.Int() & shared.DF_WEAPONS_STAY) != 0 {
+					ent.flags |= FL_RESPAWN
+				} else {
+					G.setRespawn(ent, 30)
+				}
+			}
+
+			if G.coop.Bool() {
+				ent.flags |= FL_RESPAWN
+				ent.flags |= FL_COOP_TAKEN
+			}
+		}
+	}
+
+	if (other.client.pers.weapon != ent.item) &&
+		(other.client.pers.inventory[index] == 1) &&
+		(!G.deathmatch.Bool() || (other.client.pers.weapon == G.findItem("blaster"))) {
+		other.client.newweapon = ent.item
+	}
+
+	return true
+}
+
 /*
  * The old weapon has been dropped all
  * the way, so make the new one current
@@ -150,12 +203,12 @@ func (G *qGame) changeWeapon(ent *edict_t) {
 		return
 	}
 
-	//  if (ent.client.grenade_time) {
-	// 	 ent->client->grenade_time = level.time;
-	// 	 ent->client->weapon_sound = 0;
-	// 	 weapon_grenade_fire(ent, false);
-	// 	 ent->client->grenade_time = 0;
-	//  }
+	if ent.client.grenade_time != 0 {
+		ent.client.grenade_time = G.level.time
+		ent.client.weapon_sound = 0
+		G.weapon_grenade_fire(ent, false)
+		ent.client.grenade_time = 0
+	}
 
 	ent.client.pers.lastweapon = ent.client.pers.weapon
 	ent.client.pers.weapon = ent.client.newweapon
@@ -217,15 +270,13 @@ func (G *qGame) thinkWeapon(ent *edict_t) {
 
 	/* call active weapon think routine */
 	if ent.client.pers.weapon != nil && ent.client.pers.weapon.weaponthink != nil {
-		// 	 is_quad = (ent->client->quad_framenum > level.framenum);
+		G.is_quad = (ent.client.quad_framenum > float32(G.level.framenum))
 
-		// 	 if (ent->client->silencer_shots) {
-		// 		 is_silenced = MZ_SILENCED;
-		// 	 }
-		// 	 else
-		// 	 {
-		// 		 is_silenced = 0;
-		// 	 }
+		if ent.client.silencer_shots != 0 {
+			G.is_silenced = shared.MZ_SILENCED
+		} else {
+			G.is_silenced = 0
+		}
 
 		ent.client.pers.weapon.weaponthink(ent, G)
 	}
@@ -248,26 +299,94 @@ func use_Weapon(ent *edict_t, item *gitem_t, G *qGame) {
 	}
 
 	if len(item.ammo) > 0 && !G.g_select_empty.Bool() && (item.flags&IT_AMMO) == 0 {
-		// 	 ammo_item = FindItem(item->ammo);
-		// 	 ammo_index = ITEM_INDEX(ammo_item);
+		ammo_item := G.findItem(item.ammo)
+		ammo_index := itemIndex(ammo_item)
 
-		// 	 if (!ent->client->pers.inventory[ammo_index]) {
-		// 		 gi.cprintf(ent, PRINT_HIGH, "No %s for %s.\n",
-		// 				 ammo_item->pickup_name, item->pickup_name);
-		// 		 return;
-		// 	 }
+		if ent.client.pers.inventory[ammo_index] == 0 {
+			G.gi.Cprintf(ent, shared.PRINT_HIGH, "No %s for %s.\n",
+				ammo_item.pickup_name, item.pickup_name)
+			return
+		}
 
-		// 	 if (ent->client->pers.inventory[ammo_index] < item->quantity) {
-		// 		 gi.cprintf(ent, PRINT_HIGH, "Not enough %s for %s.\n",
-		// 				 ammo_item->pickup_name, item->pickup_name);
-		// 		 return;
-		// 	 }
+		if ent.client.pers.inventory[ammo_index] < item.quantity {
+			G.gi.Cprintf(ent, shared.PRINT_HIGH, "Not enough %s for %s.\n",
+				ammo_item.pickup_name, item.pickup_name)
+			return
+		}
 	}
 
 	/* change to this weapon when down */
 	ent.client.newweapon = item
 }
 
+func drop_Weapon(ent *edict_t, item *gitem_t, G *qGame) {
+	if ent == nil || item == nil || G == nil {
+		return
+	}
+
+	if (G.dmflags.Int() & shared.DF_WEAPONS_STAY) != 0 {
+		return
+	}
+
+	index := itemIndex(item)
+
+	/* see if we're already using it */
+	if ((item == ent.client.pers.weapon) || (item == ent.client.newweapon)) &&
+		(ent.client.pers.inventory[index] == 1) {
+		G.gi.Cprintf(ent, shared.PRINT_HIGH, "Can't drop current weapon\n")
+		return
+	}
+
+	G.dropItem(ent, item)
+	ent.client.pers.inventory[index]--
+}
+
+func (G *qGame) noAmmoWeaponChange(ent *edict_t) {
+	if ent == nil {
+		return
+	}
+
+	inventory := ent.client.pers.inventory[:]
+
+	if inventory[G.findItemIndex("Slugs")] != 0 &&
+		inventory[G.findItemIndex("Railgun")] != 0 {
+		ent.client.newweapon = G.findItem("Railgun")
+		return
+	}
+
+	if inventory[G.findItemIndex("Cells")] != 0 &&
+		inventory[G.findItemIndex("HyperBlaster")] != 0 {
+		ent.client.newweapon = G.findItem("HyperBlaster")
+		return
+	}
+
+	if inventory[G.findItemIndex("Bullets")] != 0 &&
+		inventory[G.findItemIndex("Chaingun")] != 0 {
+		ent.client.newweapon = G.findItem("Chaingun")
+		return
+	}
+
+	if inventory[G.findItemIndex("Bullets")] != 0 &&
+		inventory[G.findItemIndex("Machinegun")] != 0 {
+		ent.client.newweapon = G.findItem("Machinegun")
+		return
+	}
+
+	if inventory[G.findItemIndex("Shells")] > 1 &&
+		inventory[G.findItemIndex("Super Shotgun")] != 0 {
+		ent.client.newweapon = G.findItem("Super Shotgun")
+		return
+	}
+
+	if inventory[G.findItemIndex("Shells")] != 0 &&
+		inventory[G.findItemIndex("Shotgun")] != 0 {
+		ent.client.newweapon = G.findItem("Shotgun")
+		return
+	}
+
+	ent.client.newweapon = G.findItem("Blaster")
+}
+
 /*
  * A generic function to handle
  * the basics of weapon thinking
@@ -275,7 +394,6 @@ func use_Weapon(ent *edict_t, item *gitem_t, G *qGame) {
 func (G *qGame) weapon_Generic(ent *edict_t, FRAME_ACTIVATE_LAST, FRAME_FIRE_LAST,
 	FRAME_IDLE_LAST, FRAME_DEACTIVATE_LAST int, pause_frames,
 	fire_frames []int, fire func(*edict_t, *qGame)) {
-	//  int n;
 
 	FRAME_FIRE_FIRST := (FRAME_ACTIVATE_LAST + 1)
 	FRAME_IDLE_FIRST := (FRAME_FIRE_LAST + 1)
@@ -290,20 +408,20 @@ func (G *qGame) weapon_Generic(ent *edict_t, FRAME_ACTIVATE_LAST, FRAME_FIRE_LAS
 	}
 
 	if ent.client.weaponstate == WEAPON_DROPPING {
-		// 	 if (ent->client->ps.gunframe == FRAME_DEACTIVATE_LAST) {
-		// 		 ChangeWeapon(ent);
-		// 		 return;
-		// 	 } else if ((FRAME_DEACTIVATE_LAST - ent->client->ps.gunframe) == 4) {
-		// 		 ent->client->anim_priority = ANIM_REVERSE;
-
-		// 		 if (ent->client->ps.pmove.pm_flags & PMF_DUCKED) {
-		// 			 ent->s.frame = FRAME_crpain4 + 1;
-		// 			 ent->client->anim_end = FRAME_crpain1;
-		// 		 } else {
-		// 			 ent->s.frame = FRAME_pain304 + 1;
-		// 			 ent->client->anim_end = FRAME_pain301;
-		// 		 }
-		// 	 }
+		if ent.client.ps.Gunframe == FRAME_DEACTIVATE_LAST {
+			G.changeWeapon(ent)
+			return
+		} else if (FRAME_DEACTIVATE_LAST - ent.client.ps.Gunframe) == 4 {
+			ent.client.anim_priority = ANIM_REVERSE
+
+			if (ent.client.ps.Pmove.Pm_flags & shared.PMF_DUCKED) != 0 {
+				ent.s.Frame = misc.FRAME_crpain4 + 1
+				ent.client.anim_end = misc.FRAME_crpain1
+			} else {
+				ent.s.Frame = misc.FRAME_pain304 + 1
+				ent.client.anim_end = misc.FRAME_pain301
+			}
+		}
 
 		ent.client.ps.Gunframe++
 		return
@@ -360,14 +478,13 @@ func (G *qGame) weapon_Generic(ent *edict_t, FRAME_ACTIVATE_LAST, FRAME_FIRE_LAS
 					ent.client.anim_end = misc.FRAME_attack8
 				}
 			} else {
-				// 			 if (level.time >= ent->pain_debounce_time)
-				// 			 {
-				// 				 gi.sound(ent, CHAN_VOICE, gi.soundindex(
-				// 							 "weapons/noammo.wav"), 1, ATTN_NORM, 0);
-				// 				 ent->pain_debounce_time = level.time + 1;
-				// 			 }
-
-				// 			 NoAmmoWeaponChange(ent);
+				if G.level.time >= ent.pain_debounce_time {
+					G.gi.Sound(ent, shared.CHAN_VOICE, G.gi.Soundindex(
+						"weapons/noammo.wav"), 1, shared.ATTN_NORM, 0)
+					ent.pain_debounce_time = G.level.time + 1
+				}
+
+				G.noAmmoWeaponChange(ent)
 			}
 		} else {
 			if ent.client.ps.Gunframe == FRAME_IDLE_LAST {
@@ -375,19 +492,15 @@ func (G *qGame) weapon_Generic(ent *edict_t, FRAME_ACTIVATE_LAST, FRAME_FIRE_LAS
 				return
 			}
 
-			// 		 if (pause_frames)
-			// 		 {
-			// 			 for (n = 0; pause_frames[n]; n++)
-			// 			 {
-			// 				 if (ent->client->ps.gunframe == pause_frames[n])
-			// 				 {
-			// 					 if (randk() & 15)
-			// 					 {
-			// 						 return;
-			// 					 }
-			// 				 }
-			// 			 }
-			// 		 }
+			if pause_frames != nil {
+				for n := 0; pause_frames[n] != 0; n++ {
+					if ent.client.ps.Gunframe == pause_frames[n] {
+						if (shared.Randk() & 15) != 0 {
+							return
+						}
+					}
+				}
+			}
 
 			ent.client.ps.Gunframe++
 			return
@@ -398,11 +511,10 @@ func (G *qGame) weapon_Generic(ent *edict_t, FRAME_ACTIVATE_LAST, FRAME_FIRE_LAS
 		n := 0
 		for n = 0; fire_frames[n] != 0; n++ {
 			if ent.client.ps.Gunframe == fire_frames[n] {
-				// 			 if (ent->client->quad_framenum > level.framenum)
-				// 			 {
-				// 				 gi.sound(ent, CHAN_ITEM, gi.soundindex(
-				// 							 "items/damage3.wav"), 1, ATTN_NORM, 0);
-				// 			 }
+				if ent.client.quad_framenum > float32(G.level.framenum) {
+					G.gi.Sound(ent, shared.CHAN_ITEM, G.gi.Soundindex(
+						"items/damage3.wav"), 1, shared.ATTN_NORM, 0)
+				}
 
 				fire(ent, G)
 				break
@@ -421,73 +533,897 @@ func (G *qGame) weapon_Generic(ent *edict_t, FRAME_ACTIVATE_LAST, FRAME_FIRE_LAS
 
 /* ====================================================================== */
 
-/* BLASTER / HYPERBLASTER */
+/* GRENADE */
 
-func (G *qGame) blaster_Fire(ent *edict_t, g_offset []float32, damage int,
-	hyper bool, effect int) {
+const (
+	GRENADE_TIMER    = 3.0
+	GRENADE_MINSPEED = 400
+	GRENADE_MAXSPEED = 800
+)
 
+func (G *qGame) weapon_grenade_fire(ent *edict_t, held bool) {
 	if ent == nil {
 		return
 	}
 
-	// if is_quad {
-	// 	damage *= 4
-	// }
+	damage := 125
+	radius := float32(damage + 40)
+
+	if G.is_quad {
+		damage *= 4
+	}
 
+	offset := []float32{8, 8, float32(ent.viewheight) - 8}
+	forward := make([]float32, 3)
+	right := make([]float32, 3)
+	shared.AngleVectors(ent.client.v_angle[:], forward, right, nil)
+	start := make([]float32, 3)
+	G.projectSource(ent, offset, forward, right, start)
+
+	timer := ent.client.grenade_time - G.level.time
+	speed := int(GRENADE_MINSPEED + (GRENADE_TIMER-timer)*
+		((GRENADE_MAXSPEED-GRENADE_MINSPEED)/GRENADE_TIMER))
+	G.fire_grenade2(ent, start, forward, damage, speed, timer, radius, held)
+
+	if (G.dmflags.Int() & shared.DF_INFINITE_AMMO) == 0 {
+		ent.client.pers.inventory[ent.client.ammo_index]--
+	}
+
+	ent.client.grenade_time = G.level.time + 1.0
+
+	if ent.deadflag != 0 || (ent.s.Modelindex != 255) { /* VWep animations screw up corpses */
+		return
+	}
+
+	if ent.Health <= 0 {
+		return
+	}
+
+	if (ent.client.ps.Pmove.Pm_flags & shared.PMF_DUCKED) != 0 {
+		ent.client.anim_priority = ANIM_ATTACK
+		ent.s.Frame = misc.FRAME_crattak1 - 1
+		ent.client.anim_end = misc.FRAME_crattak3
+	} else {
+		ent.client.anim_priority = ANIM_REVERSE
+		ent.s.Frame = misc.FRAME_wave08
+		ent.client.anim_end = misc.FRAME_wave01
+	}
+}
+
+func weapon_Grenade(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	if (ent.client.newweapon != nil) && (ent.client.weaponstate == WEAPON_READY) {
+		G.changeWeapon(ent)
+		return
+	}
+
+	if ent.client.weaponstate == WEAPON_ACTIVATING {
+		ent.client.weaponstate = WEAPON_READY
+		ent.client.ps.Gunframe = 16
+		return
+	}
+
+	if ent.client.weaponstate == WEAPON_READY {
+		if ((ent.client.latched_buttons |
+			ent.client.buttons) & int(shared.BUTTON_ATTACK)) != 0 {
+			ent.client.latched_buttons &^= int(shared.BUTTON_ATTACK)
+
+			if ent.client.pers.inventory[ent.client.ammo_index] != 0 {
+				ent.client.ps.Gunframe = 1
+				ent.client.weaponstate = WEAPON_FIRING
+				ent.client.grenade_time = 0
+			} else {
+				if G.level.time >= ent.pain_debounce_time {
+					G.gi.Sound(ent, shared.CHAN_VOICE, G.gi.Soundindex(
+						"weapons/noammo.wav"), 1, shared.ATTN_NORM, 0)
+					ent.pain_debounce_time = G.level.time + 1
+				}
+
+				G.noAmmoWeaponChange(ent)
+			}
+
+			return
+		}
+
+		if (ent.client.ps.Gunframe == 29) || (ent.client.ps.Gunframe == 34) ||
+			(ent.client.ps.Gunframe == 39) || (ent.client.ps.Gunframe == 48) {
+			if (shared.Randk() & 15) != 0 {
+				return
+			}
+		}
+
+		ent.client.ps.Gunframe++
+		if ent.client.ps.Gunframe > 48 {
+			ent.client.ps.Gunframe = 16
+		}
+
+		return
+	}
+
+	if ent.client.weaponstate == WEAPON_FIRING {
+		if ent.client.ps.Gunframe == 5 {
+			G.gi.Sound(ent, shared.CHAN_WEAPON, G.gi.Soundindex(
+				"weapons/hgrena1b.wav"), 1, shared.ATTN_NORM, 0)
+		}
+
+		if ent.client.ps.Gunframe == 11 {
+			if ent.client.grenade_time == 0 {
+				ent.client.grenade_time = G.level.time + GRENADE_TIMER + 0.2
+				ent.client.weapon_sound = G.gi.Soundindex("weapons/hgrenc1b.wav")
+			}
+
+			/* they waited too long, detonate it in their hand */
+			if !ent.client.grenade_blew_up && (G.level.time >= ent.client.grenade_time) {
+				ent.client.weapon_sound = 0
+				G.weapon_grenade_fire(ent, true)
+				ent.client.grenade_blew_up = true
+			}
+
+			if (ent.client.buttons & int(shared.BUTTON_ATTACK)) != 0 {
+				return
+			}
+
+			if ent.client.grenade_blew_up {
+				if G.level.time >= ent.client.grenade_time {
+					ent.client.ps.Gunframe = 15
+					ent.client.grenade_blew_up = false
+				} else {
+					return
+				}
+			}
+		}
+
+		if ent.client.ps.Gunframe == 12 {
+			ent.client.weapon_sound = 0
+			G.weapon_grenade_fire(ent, false)
+		}
+
+		if (ent.client.ps.Gunframe == 15) && (G.level.time < ent.client.grenade_time) {
+			return
+		}
+
+		ent.client.ps.Gunframe++
+
+		if ent.client.ps.Gunframe == 16 {
+			ent.client.grenade_time = 0
+			ent.client.weaponstate = WEAPON_READY
+		}
+	}
+}
+
+/* ====================================================================== */
+
+/* GRENADE LAUNCHER */
+
+func weapon_grenadelauncher_fire(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	damage := 120
+	radius := float32(damage + 40)
+
+	if G.is_quad {
+		damage *= 4
+	}
+
+	offset := []float32{8, 8, float32(ent.viewheight) - 8}
 	forward := make([]float32, 3)
 	right := make([]float32, 3)
 	shared.AngleVectors(ent.client.v_angle[:], forward, right, nil)
-	offset := []float32{24, 8, float32(ent.viewheight) - 8}
-	shared.VectorAdd(offset, g_offset, offset)
 	start := make([]float32, 3)
 	G.projectSource(ent, offset, forward, right, start)
 
 	shared.VectorScale(forward, -2, ent.client.kick_origin[:])
 	ent.client.kick_angles[0] = -1
 
-	G.fire_blaster(ent, start, forward, damage, 1000, effect, hyper)
-
-	/* send muzzle flash */
-	// G.gi.WriteByte(shared.SvcMuzzleflash)
-	// G.gi.WriteShort(ent.index)
+	G.fire_grenade(ent, start, forward, damage, 600, 2.5, radius)
 
-	// if (hyper)
-	// {
-	// 	gi.WriteByte(MZ_HYPERBLASTER | is_silenced);
-	// }
-	// else
-	// {
-	// 	gi.WriteByte(MZ_BLASTER | is_silenced);
-	// }
+	G.gi.WriteUByte(shared.SvcMuzzleflash)
+	G.gi.WriteShort(ent.index)
+	G.gi.WriteUByte(shared.MZ_GRENADE | G.is_silenced)
+	G.gi.Multicast(ent.s.Origin[:], shared.MULTICAST_PVS)
 
-	// gi.multicast(ent->s.origin, MULTICAST_PVS);
+	ent.client.ps.Gunframe++
 
 	G.playerNoise(ent, start, PNOISE_WEAPON)
+
+	if (G.dmflags.Int() & shared.DF_INFINITE_AMMO) == 0 {
+		ent.client.pers.inventory[ent.client.ammo_index]--
+	}
 }
 
-func weapon_Blaster_Fire(ent *edict_t, G *qGame) {
+func weapon_GrenadeLauncher(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	G.weapon_Generic(ent, 5, 16, 59, 64, []int{34, 51, 59, 0},
+		[]int{6, 0}, weapon_grenadelauncher_fire)
+}
+
+/* ====================================================================== */
 
+/* ROCKET */
+
+func weapon_RocketLauncher_Fire(ent *edict_t, G *qGame) {
 	if ent == nil || G == nil {
 		return
 	}
 
-	damage := 10
-	if G.deathmatch.Bool() {
-		damage = 15
+	damage := 100 + int(shared.Frandk()*20.0)
+	radius_damage := 120
+	damage_radius := float32(120)
+
+	if G.is_quad {
+		damage *= 4
+		radius_damage *= 4
 	}
 
-	G.blaster_Fire(ent, []float32{0, 0, 0}, damage, false, shared.EF_BLASTER)
+	forward := make([]float32, 3)
+	right := make([]float32, 3)
+	shared.AngleVectors(ent.client.v_angle[:], forward, right, nil)
+
+	shared.VectorScale(forward, -2, ent.client.kick_origin[:])
+	ent.client.kick_angles[0] = -1
+
+	offset := []float32{8, 8, float32(ent.viewheight) - 8}
+	start := make([]float32, 3)
+	G.projectSource(ent, offset, forward, right, start)
+	G.fire_rocket(ent, start, forward, damage, 650, damage_radius, radius_damage)
+
+	/* send muzzle flash */
+	G.gi.WriteUByte(shared.SvcMuzzleflash)
+	G.gi.WriteShort(ent.index)
+	G.gi.WriteUByte(shared.MZ_ROCKET | G.is_silenced)
+	G.gi.Multicast(ent.s.Origin[:], shared.MULTICAST_PVS)
+
 	ent.client.ps.Gunframe++
-}
 
-func weapon_Blaster(ent *edict_t, G *qGame) {
-	// static int pause_frames[] = {19, 32, 0};
-	// static int fire_frames[] = {5, 0};
+	G.playerNoise(ent, start, PNOISE_WEAPON)
+
+	if (G.dmflags.Int() & shared.DF_INFINITE_AMMO) == 0 {
+		ent.client.pers.inventory[ent.client.ammo_index]--
+	}
+}
 
+func weapon_RocketLauncher(ent *edict_t, G *qGame) {
 	if ent == nil || G == nil {
 		return
 	}
 
-	G.weapon_Generic(ent, 4, 8, 52, 55, []int{19, 32, 0},
-		[]int{5, 0}, weapon_Blaster_Fire)
+	G.weapon_Generic(ent, 4, 12, 50, 54, []int{25, 33, 42, 50, 0},
+		[]int{5, 0}, weapon_RocketLauncher_Fire)
+}
+
+/* ====================================================================== */
+
+/* BLASTER / HYPERBLASTER */
+
+func (G *qGame) blaster_Fire(ent *edict_t, g_offset []float32, damage int,
+	hyper bool, effect int) {
+
+	if ent == nil {
+		return
+	}
+
+	if G.is_quad {
+		damage *= 4
+	}
+
+	forward := make([]float32, 3)
+	right := make([]float32, 3)
+	shared.AngleVectors(ent.client.v_angle[:], forward, right, nil)
+	offset := []float32{24, 8, float32(ent.viewheight) - 8}
+	shared.VectorAdd(offset, g_offset, offset)
+	start := make([]float32, 3)
+	G.projectSource(ent, offset, forward, right, start)
+
+	shared.VectorScale(forward, -2, ent.client.kick_origin[:])
+	ent.client.kick_angles[0] = -1
+
+	G.fire_blaster(ent, start, forward, damage, 1000, effect, hyper)
+
+	/* send muzzle flash */
+	G.gi.WriteUByte(shared.SvcMuzzleflash)
+	G.gi.WriteShort(ent.index)
+
+	if hyper {
+		G.gi.WriteUByte(shared.MZ_HYPERBLASTER | G.is_silenced)
+	} else {
+		G.gi.WriteUByte(shared.MZ_BLASTER | G.is_silenced)
+	}
+
+	G.gi.Multicast(ent.s.Origin[:], shared.MULTICAST_PVS)
+
+	G.playerNoise(ent, start, PNOISE_WEAPON)
+}
+
+func weapon_Blaster_Fire(ent *edict_t, G *qGame) {
+
+	if ent == nil || G == nil {
+		return
+	}
+
+	damage := 10
+	if G.deathmatch.Bool() {
+		damage = 15
+	}
+
+	G.blaster_Fire(ent, []float32{0, 0, 0}, damage, false, shared.EF_BLASTER)
+	ent.client.ps.Gunframe++
+}
+
+func weapon_Blaster(ent *edict_t, G *qGame) {
+	// static int pause_frames[] = {19, 32, 0};
+	// static int fire_frames[] = {5, 0};
+
+	if ent == nil || G == nil {
+		return
+	}
+
+	G.weapon_Generic(ent, 4, 8, 52, 55, []int{19, 32, 0},
+		[]int{5, 0}, weapon_Blaster_Fire)
+}
+
+func weapon_HyperBlaster_Fire(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	ent.client.weapon_sound = G.gi.Soundindex("weapons/hyprbl1a.wav")
+
+	if (ent.client.buttons & int(shared.BUTTON_ATTACK)) == 0 {
+		ent.client.ps.Gunframe++
+	} else {
+		if ent.client.pers.inventory[ent.client.ammo_index] == 0 {
+			if G.level.time >= ent.pain_debounce_time {
+				G.gi.Sound(ent, shared.CHAN_VOICE, G.gi.Soundindex(
+					"weapons/noammo.wav"), 1, shared.ATTN_NORM, 0)
+				ent.pain_debounce_time = G.level.time + 1
+			}
+
+			G.noAmmoWeaponChange(ent)
+		} else {
+			rotation := float64(ent.client.ps.Gunframe-5) * 2 * math.Pi / 6
+			offset := []float32{
+				float32(-4 * math.Sin(rotation)),
+				0,
+				float32(4 * math.Cos(rotation)),
+			}
+
+			effect := 0
+			if (ent.client.ps.Gunframe == 6) || (ent.client.ps.Gunframe == 9) {
+				effect = shared.EF_HYPERBLASTER
+			}
+
+			damage := 20
+			if G.deathmatch.Bool() {
+				damage = 15
+			}
+
+			G.blaster_Fire(ent, offset, damage, true, effect)
+
+			if (G.dmflags.Int() & shared.DF_INFINITE_AMMO) == 0 {
+				ent.client.pers.inventory[ent.client.ammo_index]--
+			}
+
+			ent.client.anim_priority = ANIM_ATTACK
+
+			if (ent.client.ps.Pmove.Pm_flags & shared.PMF_DUCKED) != 0 {
+				ent.s.Frame = misc.FRAME_crattak1 - 1
+				ent.client.anim_end = misc.FRAME_crattak9
+			} else {
+				ent.s.Frame = misc.FRAME_attack1 - 1
+				ent.client.anim_end = misc.FRAME_attack8
+			}
+		}
+
+		ent.client.ps.Gunframe++
+
+		if (ent.client.ps.Gunframe == 12) &&
+			ent.client.pers.inventory[ent.client.ammo_index] != 0 {
+			ent.client.ps.Gunframe = 6
+		}
+	}
+
+	if ent.client.ps.Gunframe == 12 {
+		G.gi.Sound(ent, shared.CHAN_AUTO, G.gi.Soundindex(
+			"weapons/hyprbd1a.wav"), 1, shared.ATTN_NORM, 0)
+		ent.client.weapon_sound = 0
+	}
+}
+
+func weapon_HyperBlaster(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	G.weapon_Generic(ent, 5, 20, 49, 53, []int{0},
+		[]int{6, 7, 8, 9, 10, 11, 0}, weapon_HyperBlaster_Fire)
+}
+
+/* ====================================================================== */
+
+/* MACHINEGUN / CHAINGUN */
+
+func machinegun_Fire(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	damage := 8
+	kick := 2
+
+	if (ent.client.buttons & int(shared.BUTTON_ATTACK)) == 0 {
+		ent.client.machinegun_shots = 0
+		ent.client.ps.Gunframe++
+		return
+	}
+
+	if ent.client.ps.Gunframe == 5 {
+		ent.client.ps.Gunframe = 4
+	} else {
+		ent.client.ps.Gunframe = 5
+	}
+
+	if ent.client.pers.inventory[ent.client.ammo_index] < 1 {
+		ent.client.ps.Gunframe = 6
+
+		if G.level.time >= ent.pain_debounce_time {
+			G.gi.Sound(ent, shared.CHAN_VOICE, G.gi.Soundindex(
+				"weapons/noammo.wav"), 1, shared.ATTN_NORM, 0)
+			ent.pain_debounce_time = G.level.time + 1
+		}
+
+		G.noAmmoWeaponChange(ent)
+		return
+	}
+
+	if G.is_quad {
+		damage *= 4
+		kick *= 4
+	}
+
+	for i := 1; i < 3; i++ {
+		ent.client.kick_origin[i] = shared.Crandk() * 0.35
+		ent.client.kick_angles[i] = shared.Crandk() * 0.7
+	}
+
+	ent.client.kick_origin[0] = shared.Crandk() * 0.35
+	ent.client.kick_angles[0] = float32(ent.client.machinegun_shots) * -1.5
+
+	/* raise the gun as it is firing */
+	if !G.deathmatch.Bool() {
+		ent.client.machinegun_shots++
+
+		if ent.client.machinegun_shots > 9 {
+			ent.client.machinegun_shots = 9
+		}
+	}
+
+	/* get start / end positions */
+	angles := make([]float32, 3)
+	shared.VectorAdd(ent.client.v_angle[:], ent.client.kick_angles[:], angles)
+	forward := make([]float32, 3)
+	right := make([]float32, 3)
+	shared.AngleVectors(angles, forward, right, nil)
+	offset := []float32{0, 8, float32(ent.viewheight) - 8}
+	start := make([]float32, 3)
+	G.projectSource(ent, offset, forward, right, start)
+	G.fire_bullet(ent, start, forward, damage, kick, DEFAULT_BULLET_HSPREAD,
+		DEFAULT_BULLET_VSPREAD, MOD_MACHINEGUN)
+
+	G.gi.WriteUByte(shared.SvcMuzzleflash)
+	G.gi.WriteShort(ent.index)
+	G.gi.WriteUByte(shared.MZ_MACHINEGUN | G.is_silenced)
+	G.gi.Multicast(ent.s.Origin[:], shared.MULTICAST_PVS)
+
+	G.playerNoise(ent, start, PNOISE_WEAPON)
+
+	if (G.dmflags.Int() & shared.DF_INFINITE_AMMO) == 0 {
+		ent.client.pers.inventory[ent.client.ammo_index]--
+	}
+
+	ent.client.anim_priority = ANIM_ATTACK
+
+	if (ent.client.ps.Pmove.Pm_flags & shared.PMF_DUCKED) != 0 {
+		ent.s.Frame = misc.FRAME_crattak1 - int(shared.Frandk()+0.25)
+		ent.client.anim_end = misc.FRAME_crattak9
+	} else {
+		ent.s.Frame = misc.FRAME_attack1 - int(shared.Frandk()+0.25)
+		ent.client.anim_end = misc.FRAME_attack8
+	}
+}
+
+func weapon_Machinegun(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	G.weapon_Generic(ent, 3, 5, 45, 49, []int{23, 45, 0},
+		[]int{4, 5, 0}, machinegun_Fire)
+}
+
+func chaingun_Fire(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	kick := 2
+	damage := 8
+	if G.deathmatch.Bool() {
+		damage = 6
+	}
+
+	if ent.client.ps.Gunframe == 5 {
+		G.gi.Sound(ent, shared.CHAN_AUTO, G.gi.Soundindex(
+			"weapons/chngnu1a.wav"), 1, shared.ATTN_IDLE, 0)
+	}
+
+	if (ent.client.ps.Gunframe == 14) &&
+		(ent.client.buttons&int(shared.BUTTON_ATTACK)) == 0 {
+		ent.client.ps.Gunframe = 32
+		ent.client.weapon_sound = 0
+		return
+	} else if (ent.client.ps.Gunframe == 21) &&
+		(ent.client.buttons&int(shared.BUTTON_ATTACK)) != 0 &&
+		ent.client.pers.inventory[ent.client.ammo_index] != 0 {
+		ent.client.ps.Gunframe = 15
+	} else {
+		ent.client.ps.Gunframe++
+	}
+
+	if ent.client.ps.Gunframe == 22 {
+		ent.client.weapon_sound = 0
+		G.gi.Sound(ent, shared.CHAN_AUTO, G.gi.Soundindex(
+			"weapons/chngnd1a.wav"), 1, shared.ATTN_IDLE, 0)
+	} else {
+		ent.client.weapon_sound = G.gi.Soundindex("weapons/chngnl1a.wav")
+	}
+
+	ent.client.anim_priority = ANIM_ATTACK
+
+	if (ent.client.ps.Pmove.Pm_flags & shared.PMF_DUCKED) != 0 {
+		ent.s.Frame = misc.FRAME_crattak1 - (ent.client.ps.Gunframe & 1)
+		ent.client.anim_end = misc.FRAME_crattak9
+	} else {
+		ent.s.Frame = misc.FRAME_attack1 - (ent.client.ps.Gunframe & 1)
+		ent.client.anim_end = misc.FRAME_attack8
+	}
+
+	var shots int
+	if ent.client.ps.Gunframe <= 9 {
+		shots = 1
+	} else if ent.client.ps.Gunframe <= 14 {
+		if (ent.client.buttons & int(shared.BUTTON_ATTACK)) != 0 {
+			shots = 2
+		} else {
+			shots = 1
+		}
+	} else {
+		shots = 3
+	}
+
+	if ent.client.pers.inventory[ent.client.ammo_index] < shots {
+		shots = ent.client.pers.inventory[ent.client.ammo_index]
+	}
+
+	if shots == 0 {
+		if G.level.time >= ent.pain_debounce_time {
+			G.gi.Sound(ent, shared.CHAN_VOICE, G.gi.Soundindex(
+				"weapons/noammo.wav"), 1, shared.ATTN_NORM, 0)
+			ent.pain_debounce_time = G.level.time + 1
+		}
+
+		G.noAmmoWeaponChange(ent)
+		return
+	}
+
+	if G.is_quad {
+		damage *= 4
+		kick *= 4
+	}
+
+	for i := 0; i < 3; i++ {
+		ent.client.kick_origin[i] = shared.Crandk() * 0.35
+		ent.client.kick_angles[i] = shared.Crandk() * 0.7
+	}
+
+	forward := make([]float32, 3)
+	right := make([]float32, 3)
+	up := make([]float32, 3)
+	start := make([]float32, 3)
+
+	for i := 0; i < shots; i++ {
+		/* get start / end positions */
+		shared.AngleVectors(ent.client.v_angle[:], forward, right, up)
+		r := 7 + shared.Crandk()*4
+		u := shared.Crandk() * 4
+		offset := []float32{0, r, u + float32(ent.viewheight) - 8}
+		G.projectSource(ent, offset, forward, right, start)
+
+		G.fire_bullet(ent, start, forward, damage, kick, DEFAULT_BULLET_HSPREAD,
+			DEFAULT_BULLET_VSPREAD, MOD_CHAINGUN)
+	}
+
+	/* send muzzle flash */
+	G.gi.WriteUByte(shared.SvcMuzzleflash)
+	G.gi.WriteShort(ent.index)
+	G.gi.WriteUByte((shared.MZ_CHAINGUN1 + shots - 1) | G.is_silenced)
+	G.gi.Multicast(ent.s.Origin[:], shared.MULTICAST_PVS)
+
+	G.playerNoise(ent, start, PNOISE_WEAPON)
+
+	if (G.dmflags.Int() & shared.DF_INFINITE_AMMO) == 0 {
+		ent.client.pers.inventory[ent.client.ammo_index] -= shots
+	}
+}
+
+func weapon_Chaingun(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	G.weapon_Generic(ent, 4, 31, 61, 64, []int{38, 43, 51, 61, 0},
+		[]int{5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 0},
+		chaingun_Fire)
+}
+
+/* ====================================================================== */
+
+/* SHOTGUN / SUPERSHOTGUN */
+
+func weapon_shotgun_fire(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	damage := 4
+	kick := 8
+
+	if ent.client.ps.Gunframe == 9 {
+		ent.client.ps.Gunframe++
+		return
+	}
+
+	forward := make([]float32, 3)
+	right := make([]float32, 3)
+	shared.AngleVectors(ent.client.v_angle[:], forward, right, nil)
+
+	shared.VectorScale(forward, -2, ent.client.kick_origin[:])
+	ent.client.kick_angles[0] = -2
+
+	offset := []float32{0, 8, float32(ent.viewheight) - 8}
+	start := make([]float32, 3)
+	G.projectSource(ent, offset, forward, right, start)
+
+	if G.is_quad {
+		damage *= 4
+		kick *= 4
+	}
+
+	if G.deathmatch.Bool() {
+		G.fire_shotgun(ent, start, forward, damage, kick, 500, 500,
+			DEFAULT_DEATHMATCH_SHOTGUN_COUNT, MOD_SHOTGUN)
+	} else {
+		G.fire_shotgun(ent, start, forward, damage, kick, 500, 500,
+			DEFAULT_SHOTGUN_COUNT, MOD_SHOTGUN)
+	}
+
+	/* send muzzle flash */
+	G.gi.WriteUByte(shared.SvcMuzzleflash)
+	G.gi.WriteShort(ent.index)
+	G.gi.WriteUByte(shared.MZ_SHOTGUN | G.is_silenced)
+	G.gi.Multicast(ent.s.Origin[:], shared.MULTICAST_PVS)
+
+	ent.client.ps.Gunframe++
+	G.playerNoise(ent, start, PNOISE_WEAPON)
+
+	if (G.dmflags.Int() & shared.DF_INFINITE_AMMO) == 0 {
+		ent.client.pers.inventory[ent.client.ammo_index]--
+	}
+}
+
+func weapon_Shotgun(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	G.weapon_Generic(ent, 7, 18, 36, 39, []int{22, 28, 34, 0},
+		[]int{8, 9, 0}, weapon_shotgun_fire)
+}
+
+func weapon_supershotgun_fire(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	damage := 6
+	kick := 12
+
+	forward := make([]float32, 3)
+	right := make([]float32, 3)
+	shared.AngleVectors(ent.client.v_angle[:], forward, right, nil)
+
+	shared.VectorScale(forward, -2, ent.client.kick_origin[:])
+	ent.client.kick_angles[0] = -2
+
+	offset := []float32{0, 8, float32(ent.viewheight) - 8}
+	start := make([]float32, 3)
+	G.projectSource(ent, offset, forward, right, start)
+
+	if G.is_quad {
+		damage *= 4
+		kick *= 4
+	}
+
+	v := make([]float32, 3)
+	v[shared.PITCH] = ent.client.v_angle[shared.PITCH]
+	v[shared.YAW] = ent.client.v_angle[shared.YAW] - 5
+	v[shared.ROLL] = ent.client.v_angle[shared.ROLL]
+	shared.AngleVectors(v, forward, nil, nil)
+	G.fire_shotgun(ent, start, forward, damage, kick, DEFAULT_SHOTGUN_HSPREAD,
+		DEFAULT_SHOTGUN_VSPREAD, DEFAULT_SSHOTGUN_COUNT/2, MOD_SSHOTGUN)
+	v[shared.YAW] = ent.client.v_angle[shared.YAW] + 5
+	shared.AngleVectors(v, forward, nil, nil)
+	G.fire_shotgun(ent, start, forward, damage, kick, DEFAULT_SHOTGUN_HSPREAD,
+		DEFAULT_SHOTGUN_VSPREAD, DEFAULT_SSHOTGUN_COUNT/2, MOD_SSHOTGUN)
+
+	/* send muzzle flash */
+	G.gi.WriteUByte(shared.SvcMuzzleflash)
+	G.gi.WriteShort(ent.index)
+	G.gi.WriteUByte(shared.MZ_SSHOTGUN | G.is_silenced)
+	G.gi.Multicast(ent.s.Origin[:], shared.MULTICAST_PVS)
+
+	ent.client.ps.Gunframe++
+	G.playerNoise(ent, start, PNOISE_WEAPON)
+
+	if (G.dmflags.Int() & shared.DF_INFINITE_AMMO) == 0 {
+		ent.client.pers.inventory[ent.client.ammo_index] -= 2
+	}
+}
+
+func weapon_SuperShotgun(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	G.weapon_Generic(ent, 6, 17, 57, 61, []int{29, 42, 57, 0},
+		[]int{7, 0}, weapon_supershotgun_fire)
+}
+
+/* ====================================================================== */
+
+/* RAILGUN */
+
+func weapon_railgun_fire(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	var damage, kick int
+	if G.deathmatch.Bool() {
+		/* normal damage is too extreme in dm */
+		damage = 100
+		kick = 200
+	} else {
+		damage = 150
+		kick = 250
+	}
+
+	if G.is_quad {
+		damage *= 4
+		kick *= 4
+	}
+
+	forward := make([]float32, 3)
+	right := make([]float32, 3)
+	shared.AngleVectors(ent.client.v_angle[:], forward, right, nil)
+
+	shared.VectorScale(forward, -3, ent.client.kick_origin[:])
+	ent.client.kick_angles[0] = -3
+
+	offset := []float32{0, 7, float32(ent.viewheight) - 8}
+	start := make([]float32, 3)
+	G.projectSource(ent, offset, forward, right, start)
+	G.fire_rail(ent, start, forward, damage, kick)
+
+	/* send muzzle flash */
+	G.gi.WriteUByte(shared.SvcMuzzleflash)
+	G.gi.WriteShort(ent.index)
+	G.gi.WriteUByte(shared.MZ_RAILGUN | G.is_silenced)
+	G.gi.Multicast(ent.s.Origin[:], shared.MULTICAST_PVS)
+
+	ent.client.ps.Gunframe++
+	G.playerNoise(ent, start, PNOISE_WEAPON)
+
+	if (G.dmflags.Int() & shared.DF_INFINITE_AMMO) == 0 {
+		ent.client.pers.inventory[ent.client.ammo_index]--
+	}
+}
+
+func weapon_Railgun(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	G.weapon_Generic(ent, 3, 18, 56, 61, []int{56, 0},
+		[]int{4, 0}, weapon_railgun_fire)
+}
+
+/* ====================================================================== */
+
+/* BFG10K */
+
+func weapon_bfg_fire(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	damage_radius := float32(1000)
+	damage := 500
+	if G.deathmatch.Bool() {
+		damage = 200
+	}
+
+	if ent.client.ps.Gunframe == 9 {
+		/* send muzzle flash */
+		G.gi.WriteUByte(shared.SvcMuzzleflash)
+		G.gi.WriteShort(ent.index)
+		G.gi.WriteUByte(shared.MZ_BFG | G.is_silenced)
+		G.gi.Multicast(ent.s.Origin[:], shared.MULTICAST_PVS)
+
+		ent.client.ps.Gunframe++
+
+		G.playerNoise(ent, ent.s.Origin[:], PNOISE_WEAPON)
+		return
+	}
+
+	/* cells can go down during windup (from power armor hits), so
+	   check again and abort firing if we don't have enough now */
+	if ent.client.pers.inventory[ent.client.ammo_index] < 50 {
+		ent.client.ps.Gunframe++
+		return
+	}
+
+	if G.is_quad {
+		damage *= 4
+	}
+
+	forward := make([]float32, 3)
+	right := make([]float32, 3)
+	shared.AngleVectors(ent.client.v_angle[:], forward, right, nil)
+
+	shared.VectorScale(forward, -2, ent.client.kick_origin[:])
+
+	/* make a big pitch kick with an inverse fall */
+	ent.client.v_dmg_pitch = -40
+	ent.client.v_dmg_roll = shared.Crandk() * 8
+	ent.client.v_dmg_time = G.level.time + DAMAGE_TIME
+
+	offset := []float32{8, 8, float32(ent.viewheight) - 8}
+	start := make([]float32, 3)
+	G.projectSource(ent, offset, forward, right, start)
+	G.fire_bfg(ent, start, forward, damage, 400, damage_radius)
+
+	ent.client.ps.Gunframe++
+
+	G.playerNoise(ent, start, PNOISE_WEAPON)
+
+	if (G.dmflags.Int() & shared.DF_INFINITE_AMMO) == 0 {
+		ent.client.pers.inventory[ent.client.ammo_index] -= 50
+	}
+}
+
+func weapon_BFG(ent *edict_t, G *qGame) {
+	if ent == nil || G == nil {
+		return
+	}
+
+	G.weapon_Generic(ent, 8, 32, 55, 58, []int{39, 45, 50, 55, 0},
+		[]int{9, 17, 0}, weapon_bfg_fire)
 }
@@ -27,7 +27,20 @@
  */
 package game
 
-import "goquake2/shared"
+import (
+	"encoding/json"
+	"goquake2/shared"
+	"os"
+	"reflect"
+	"unsafe"
+)
+
+/*
+ * Increase this whenever the layout of
+ * the savegame files changes in a way
+ * that old savegames can't be read.
+ */
+const SAVEGAMEVER = "GQ2-1"
 
 /*
  * This is the Quake 2 savegame system, fixed by Yamagi
@@ -221,7 +234,7 @@ var fields = []field_t{
 	// {"endfunc", FOFS(moveinfo.endfunc), F_FUNCTION, FFL_NOSPAWN},
 	{"lip", "Lip", F_INT, FFL_SPAWNTEMP},
 	// {"distance", STOFS(distance), F_INT, FFL_SPAWNTEMP},
-	// {"height", STOFS(height), F_INT, FFL_SPAWNTEMP},
+	{"height", "Height", F_INT, FFL_SPAWNTEMP},
 	{"noise", "Noise", F_LSTRING, FFL_SPAWNTEMP},
 	// {"pausetime", STOFS(pausetime), F_FLOAT, FFL_SPAWNTEMP},
 	// {"item", STOFS(item), F_LSTRING, FFL_SPAWNTEMP},
@@ -236,3 +249,562 @@ var fields = []field_t{
 	// {"maxpitch", STOFS(maxpitch), F_FLOAT, FFL_SPAWNTEMP},
 	{"nextmap", "Nextmap", F_LSTRING, FFL_SPAWNTEMP},
 }
+
+/* ========================================================= */
+
+/*
+ * Helper function to get
+ * the human readable function
+ * definition by an address.
+ * Called by writeField.
+ */
+func getFunctionByAddress(adr reflect.Value) *functionList_t {
+	for i := range functionList {
+		if reflect.ValueOf(functionList[i].funcPtr).Pointer() == adr.Pointer() {
+			return &functionList[i]
+		}
+	}
+
+	return nil
+}
+
+/*
+ * Helper function to get the
+ * pointer to a function by
+ * it's human readable name.
+ * Called by readField.
+ */
+func findFunctionByName(name string) interface{} {
+	for _, f := range functionList {
+		if f.funcStr == name {
+			return f.funcPtr
+		}
+	}
+
+	return nil
+}
+
+/*
+ * Helper function to get the
+ * human readable definition of
+ * a mmove_t struct by a pointer.
+ */
+func getMmoveByAddress(adr *mmove_t) *mmoveList_t {
+	for i := range mmoveList {
+		if mmoveList[i].mmovePtr == adr {
+			return &mmoveList[i]
+		}
+	}
+
+	return nil
+}
+
+/*
+ * Helper function to get a
+ * pointer to a mmove_t struct
+ * by a human readable definition.
+ */
+func findMmoveByName(name string) *mmove_t {
+	for _, m := range mmoveList {
+		if m.mmoveStr == name {
+			return m.mmovePtr
+		}
+	}
+
+	return nil
+}
+
+/* ========================================================= */
+
+var (
+	edictPtrType  = reflect.TypeOf((*edict_t)(nil))
+	clientPtrType = reflect.TypeOf((*gclient_t)(nil))
+	itemPtrType   = reflect.TypeOf((*gitem_t)(nil))
+	mmovePtrType  = reflect.TypeOf((*mmove_t)(nil))
+	linkType      = reflect.TypeOf(shared.Link_t{})
+)
+
+/*
+ * Returns the i-th field of an addressable struct
+ * value. Most fields of the game structs aren't
+ * exported, so the field is accessed through its
+ * address to make it readable and writable.
+ */
+func structField(v reflect.Value, i int) reflect.Value {
+	f := v.Field(i)
+	return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+}
+
+/*
+ * Converts a struct into a map from the field
+ * names to their savegame representation.
+ */
+func (G *qGame) writeStruct(v reflect.Value) (map[string]interface{}, error) {
+	out := make(map[string]interface{})
+
+	for i := 0; i < v.NumField(); i++ {
+		data, err := G.writeField(v.Type().Field(i).Name, structField(v, i))
+		if err != nil {
+			return nil, err
+		}
+
+		if data != nil {
+			out[v.Type().Field(i).Name] = data
+		}
+	}
+
+	return out, nil
+}
+
+/*
+ * Converts a single field into its savegame
+ * representation. Pointers to edicts and clients
+ * are stored as indices, items by their classname
+ * and functions and mmove_t structs by the names
+ * registered in the tables. Returns nil for fields
+ * that are not saved and are zero after loading.
+ */
+func (G *qGame) writeField(name string, v reflect.Value) (interface{}, error) {
+	switch v.Type() {
+	case edictPtrType:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return v.Interface().(*edict_t).index, nil
+	case clientPtrType:
+		if v.IsNil() {
+			return nil, nil
+		}
+		for i := range G.game.clients {
+			if &G.game.clients[i] == v.Interface().(*gclient_t) {
+				return i, nil
+			}
+		}
+		return nil, nil
+	case itemPtrType:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return v.Interface().(*gitem_t).classname, nil
+	case mmovePtrType:
+		if v.IsNil() {
+			return nil, nil
+		}
+		mmove := getMmoveByAddress(v.Interface().(*mmove_t))
+		if mmove == nil {
+			return nil, G.gi.Error("WriteField: mmove_t %s not in list, can't save game", name)
+		}
+		return mmove.mmoveStr, nil
+	case linkType:
+		/* rebuilt by the server when the
+		   entity is linked after loading */
+		return nil, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Func:
+		if v.IsNil() {
+			return nil, nil
+		}
+		fn := getFunctionByAddress(v)
+		if fn == nil {
+			return nil, G.gi.Error("WriteField: function %s not in list, can't save game", name)
+		}
+		return fn.funcStr, nil
+	case reflect.Struct:
+		return G.writeStruct(v)
+	case reflect.Array:
+		out := make([]interface{}, v.Len())
+		for i := range out {
+			data, err := G.writeField(name, v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			out[i] = data
+		}
+		return out, nil
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return v.Interface(), nil
+	}
+
+	/* slices, interfaces and other pointers
+	   are never part of the savegame */
+	return nil, nil
+}
+
+/*
+ * Restores a struct from the map written
+ * by writeStruct. Fields not found in the
+ * map are left untouched.
+ */
+func (G *qGame) readStruct(v reflect.Value, data map[string]interface{}) error {
+	for i := 0; i < v.NumField(); i++ {
+		d, ok := data[v.Type().Field(i).Name]
+		if !ok || d == nil {
+			continue
+		}
+
+		if err := G.readField(v.Type().Field(i).Name, structField(v, i), d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+ * Restores a single field from its savegame
+ * representation, converting indices and names
+ * back into pointers.
+ */
+func (G *qGame) readField(name string, v reflect.Value, data interface{}) error {
+	switch v.Type() {
+	case edictPtrType:
+		n, ok := data.(float64)
+		if !ok || (int(n) < 0) || (int(n) >= len(G.g_edicts)) {
+			return G.gi.Error("ReadField: bad edict in %s", name)
+		}
+		v.Set(reflect.ValueOf(&G.g_edicts[int(n)]))
+		return nil
+	case clientPtrType:
+		n, ok := data.(float64)
+		if !ok || (int(n) < 0) || (int(n) >= len(G.game.clients)) {
+			return G.gi.Error("ReadField: bad client in %s", name)
+		}
+		v.Set(reflect.ValueOf(&G.game.clients[int(n)]))
+		return nil
+	case itemPtrType:
+		classname, _ := data.(string)
+		item := findItemByClassname(classname)
+		if item == nil {
+			return G.gi.Error("ReadField: item %s in %s not found", classname, name)
+		}
+		v.Set(reflect.ValueOf(item))
+		return nil
+	case mmovePtrType:
+		mmoveStr, _ := data.(string)
+		mmove := findMmoveByName(mmoveStr)
+		if mmove == nil {
+			return G.gi.Error("ReadField: mmove_t %s not found in table, can't load game", mmoveStr)
+		}
+		v.Set(reflect.ValueOf(mmove))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Func:
+		funcStr, _ := data.(string)
+		fn := findFunctionByName(funcStr)
+		if fn == nil {
+			return G.gi.Error("ReadField: function %s not found in table, can't load game", funcStr)
+		}
+		if reflect.TypeOf(fn) != v.Type() {
+			return G.gi.Error("ReadField: function %s doesn't match %s, can't load game", funcStr, name)
+		}
+		v.Set(reflect.ValueOf(fn))
+		return nil
+	case reflect.Struct:
+		m, ok := data.(map[string]interface{})
+		if !ok {
+			return G.gi.Error("ReadField: %s is not a struct", name)
+		}
+		return G.readStruct(v, m)
+	case reflect.Array:
+		a, ok := data.([]interface{})
+		if !ok {
+			return G.gi.Error("ReadField: %s is not an array", name)
+		}
+		for i := 0; i < len(a) && i < v.Len(); i++ {
+			if a[i] == nil {
+				continue
+			}
+			if err := G.readField(name, v.Index(i), a[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Bool:
+		b, ok := data.(bool)
+		if !ok {
+			return G.gi.Error("ReadField: %s is not a bool", name)
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.String:
+		str, ok := data.(string)
+		if !ok {
+			return G.gi.Error("ReadField: %s is not a string", name)
+		}
+		v.SetString(str)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := data.(float64)
+		if !ok {
+			return G.gi.Error("ReadField: %s is not a number", name)
+		}
+		v.SetInt(int64(n))
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := data.(float64)
+		if !ok {
+			return G.gi.Error("ReadField: %s is not a number", name)
+		}
+		v.SetUint(uint64(n))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		n, ok := data.(float64)
+		if !ok {
+			return G.gi.Error("ReadField: %s is not a number", name)
+		}
+		v.SetFloat(n)
+		return nil
+	}
+
+	return nil
+}
+
+/* ========================================================= */
+
+/*
+ * Layout of the game.ssv file.
+ */
+type savegameGame_t struct {
+	Version string
+	Game    map[string]interface{}
+	Clients []map[string]interface{}
+}
+
+/*
+ * Layout of the <mapname>.sav files.
+ */
+type savegameEdict_t struct {
+	Number int
+	Fields map[string]interface{}
+}
+
+type savegameLevel_t struct {
+	Version string
+	Level   map[string]interface{}
+	Edicts  []savegameEdict_t
+}
+
+/*
+ * This will be called whenever the game goes to a new level,
+ * and when the user explicitly saves the game.
+ *
+ * Game information include cross level data, like multi level
+ * triggers, help computer info, and all client states.
+ *
+ * A single player death will automatically restore from the
+ * last save position.
+ */
+func (G *qGame) WriteGame(filename string, autosave bool) error {
+
+	if !autosave {
+		G.saveClientData()
+	}
+
+	save := savegameGame_t{Version: SAVEGAMEVER}
+
+	G.game.autosaved = autosave
+	data, err := G.writeStruct(reflect.ValueOf(&G.game).Elem())
+	G.game.autosaved = false
+	if err != nil {
+		return err
+	}
+	save.Game = data
+
+	for i := range G.game.clients {
+		data, err := G.writeStruct(reflect.ValueOf(&G.game.clients[i]).Elem())
+		if err != nil {
+			return err
+		}
+		save.Clients = append(save.Clients, data)
+	}
+
+	buf, err := json.Marshal(save)
+	if err != nil {
+		return G.gi.Error("WriteGame: %v", err)
+	}
+
+	if err := os.WriteFile(filename, buf, 0644); err != nil {
+		return G.gi.Error("Couldn't open %s", filename)
+	}
+
+	return nil
+}
+
+/*
+ * Read the game structs from
+ * a file. Called when ever a
+ * savegames is loaded.
+ */
+func (G *qGame) ReadGame(filename string) error {
+
+	buf, err := os.ReadFile(filename)
+	if err != nil {
+		return G.gi.Error("Couldn't open %s", filename)
+	}
+
+	var save savegameGame_t
+	if err := json.Unmarshal(buf, &save); err != nil {
+		return G.gi.Error("Savegame %s is corrupt: %v", filename, err)
+	}
+
+	if save.Version != SAVEGAMEVER {
+		return G.gi.Error("Savegame from an incompatible version.\n")
+	}
+
+	G.game = game_locals_t{}
+	if err := G.readStruct(reflect.ValueOf(&G.game).Elem(), save.Game); err != nil {
+		return err
+	}
+
+	G.g_edicts = make([]edict_t, G.game.maxentities)
+	for i := range G.g_edicts {
+		G.g_edicts[i].index = i
+		G.g_edicts[i].area.Self = &G.g_edicts[i]
+	}
+
+	G.game.clients = make([]gclient_t, G.game.maxclients)
+	for i := 0; i < G.game.maxclients && i < len(save.Clients); i++ {
+		if err := G.readStruct(reflect.ValueOf(&G.game.clients[i]).Elem(), save.Clients[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/* ========================================================== */
+
+/*
+ * Writes the current level
+ * into a file.
+ */
+func (G *qGame) WriteLevel(filename string) error {
+
+	save := savegameLevel_t{Version: SAVEGAMEVER}
+
+	/* write out level_locals_t */
+	data, err := G.writeStruct(reflect.ValueOf(&G.level).Elem())
+	if err != nil {
+		return err
+	}
+	save.Level = data
+
+	/* write out all the entities */
+	for i := 0; i < G.num_edicts; i++ {
+		ent := &G.g_edicts[i]
+		if !ent.inuse {
+			continue
+		}
+
+		data, err := G.writeStruct(reflect.ValueOf(ent).Elem())
+		if err != nil {
+			return err
+		}
+		save.Edicts = append(save.Edicts, savegameEdict_t{i, data})
+	}
+
+	buf, err := json.Marshal(save)
+	if err != nil {
+		return G.gi.Error("WriteLevel: %v", err)
+	}
+
+	if err := os.WriteFile(filename, buf, 0644); err != nil {
+		return G.gi.Error("Couldn't open %s", filename)
+	}
+
+	return nil
+}
+
+/*
+ * SpawnEntities will allready have been called on the
+ * level the same way it was when the level was saved.
+ *
+ * That is necessary to get the baselines set up identically.
+ *
+ * The server will have cleared all of the world links before
+ * calling ReadLevel.
+ *
+ * No clients are connected yet.
+ */
+func (G *qGame) ReadLevel(filename string) error {
+
+	buf, err := os.ReadFile(filename)
+	if err != nil {
+		return G.gi.Error("Couldn't open %s", filename)
+	}
+
+	var save savegameLevel_t
+	if err := json.Unmarshal(buf, &save); err != nil {
+		return G.gi.Error("Savegame %s is corrupt: %v", filename, err)
+	}
+
+	if save.Version != SAVEGAMEVER {
+		return G.gi.Error("ReadLevel: savegame from an incompatible version")
+	}
+
+	/* wipe all the entities */
+	for i := range G.g_edicts {
+		G.g_edicts[i] = edict_t{}
+		G.g_edicts[i].index = i
+	}
+
+	G.num_edicts = G.maxclients.Int() + 1
+
+	/* load the level locals */
+	G.level = level_locals_t{}
+	if err := G.readStruct(reflect.ValueOf(&G.level).Elem(), save.Level); err != nil {
+		return err
+	}
+
+	/* load all the entities */
+	for _, e := range save.Edicts {
+		entnum := e.Number
+		if (entnum < 0) || (entnum >= len(G.g_edicts)) {
+			return G.gi.Error("ReadLevel: bad entity number %v", entnum)
+		}
+
+		if entnum >= G.num_edicts {
+			G.num_edicts = entnum + 1
+		}
+
+		ent := &G.g_edicts[entnum]
+		if err := G.readStruct(reflect.ValueOf(ent).Elem(), e.Fields); err != nil {
+			return err
+		}
+		ent.index = entnum
+
+		/* let the server rebuild world links for this ent */
+		ent.area = shared.Link_t{}
+		G.gi.Linkentity(ent)
+	}
+
+	/* mark all clients as unconnected */
+	for i := 0; i < G.maxclients.Int(); i++ {
+		ent := &G.g_edicts[i+1]
+		ent.client = &G.game.clients[i]
+		ent.client.pers.connected = false
+	}
+
+	/* do any load time things at this point */
+	for i := 0; i < G.num_edicts; i++ {
+		ent := &G.g_edicts[i]
+
+		if !ent.inuse {
+			continue
+		}
+
+		/* fire any cross-level triggers */
+		if ent.Classname == "target_crosslevel_target" {
+			ent.nextthink = G.level.time + ent.Delay
+		}
+	}
+
+	return nil
+}
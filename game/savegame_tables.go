@@ -0,0 +1,142 @@
+/*
+ * Copyright (C) 1997-2001 Id Software, Inc.
+ * Copyright (C) 2011 Knightmare
+ * Copyright (C) 2011 Yamagi Burmeister
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or (at
+ * your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ *
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 59 Temple Place - Suite 330, Boston, MA
+ * 02111-1307, USA.
+ *
+ * =======================================================================
+ *
+ * Function and mmove_t tables for the savegame system.
+ *
+ * =======================================================================
+ */
+package game
+
+/*
+ * Every function that can be stored in a function
+ * pointer of a savegame relevant struct (think,
+ * touch, use, pain, die, monsterinfo and moveinfo
+ * callbacks) must be listed here. Otherwise the
+ * savegame can't be written.
+ */
+type functionList_t struct {
+	funcStr string
+	funcPtr interface{}
+}
+
+var functionList = []functionList_t{
+	{"angleMove_Begin", angleMove_Begin},
+	{"angleMove_Done", angleMove_Done},
+	{"angleMove_Final", angleMove_Final},
+	{"bfg_explode", bfg_explode},
+	{"bfg_think", bfg_think},
+	{"bfg_touch", bfg_touch},
+	{"blaster_touch", blaster_touch},
+	{"body_die", body_die},
+	{"door_go_down", door_go_down},
+	{"door_hit_bottom", door_hit_bottom},
+	{"door_hit_top", door_hit_top},
+	{"doRespawn", doRespawn},
+	{"door_use", door_use},
+	{"drop_make_touchable", drop_make_touchable},
+	{"drop_temp_touch", drop_temp_touch},
+	{"droptofloor", droptofloor},
+	{"func_timer_think", func_timer_think},
+	{"gFreeEdictFunc", gFreeEdictFunc},
+	{"grenade_Explode", grenade_Explode},
+	{"grenade_Touch", grenade_Touch},
+	{"mCheckAttack", mCheckAttack},
+	{"megaHealth_think", megaHealth_think},
+	{"monster_think", monster_think},
+	{"move_Begin", move_Begin},
+	{"move_Done", move_Done},
+	{"move_Final", move_Final},
+	{"multi_wait", multi_wait},
+	{"path_corner_touch", path_corner_touch},
+	{"plat_blocked", plat_blocked},
+	{"plat_go_down", plat_go_down},
+	{"plat_go_up", plat_go_up},
+	{"plat_hit_bottom", plat_hit_bottom},
+	{"plat_hit_top", plat_hit_top},
+	{"player_die", player_die},
+	{"player_pain", player_pain},
+	{"point_combat_touch", point_combat_touch},
+	{"rocket_touch", rocket_touch},
+	{"soldier_cock", soldier_cock},
+	{"soldier_dead", soldier_dead},
+	{"soldier_die", soldier_die},
+	{"soldier_fire6", soldier_fire6},
+	{"soldier_fire7", soldier_fire7},
+	{"soldier_idle", soldier_idle},
+	{"soldier_pain", soldier_pain},
+	{"soldier_run", soldier_run},
+	{"soldier_sight", soldier_sight},
+	{"soldier_stand", soldier_stand},
+	{"soldier_walk", soldier_walk},
+	{"soldier_walk1_random", soldier_walk1_random},
+	{"spCreateCoopSpots", spCreateCoopSpots},
+	{"spCreateUnnamedSpawn", spCreateUnnamedSpawn},
+	{"spFixCoopSpots", spFixCoopSpots},
+	{"target_explosion_explode", target_explosion_explode},
+	{"think_AccelMove", think_AccelMove},
+	{"think_CalcMoveSpeed", think_CalcMoveSpeed},
+	{"think_Delay", think_Delay},
+	{"think_SpawnDoorTrigger", think_SpawnDoorTrigger},
+	{"touch_DoorTrigger", touch_DoorTrigger},
+	{"touch_Item", touch_Item},
+	{"touch_Multi", touch_Multi},
+	{"touch_Plat_Center", touch_Plat_Center},
+	{"trigger_enable", trigger_enable},
+	{"trigger_relay_use", trigger_relay_use},
+	{"use_Areaportal", use_Areaportal},
+	{"use_Item", use_Item},
+	{"use_Multi", use_Multi},
+	{"use_Plat", use_Plat},
+	{"use_target_changelevel", use_target_changelevel},
+	{"use_target_explosion", use_target_explosion},
+	{"wait_and_change_think", wait_and_change_think},
+	{"walkmonster_start_go", walkmonster_start_go},
+}
+
+/*
+ * All mmove_t structs that can be
+ * referenced by monsterinfo.currentmove.
+ */
+type mmoveList_t struct {
+	mmoveStr string
+	mmovePtr *mmove_t
+}
+
+var mmoveList = []mmoveList_t{
+	{"soldier_move_death1", &soldier_move_death1},
+	{"soldier_move_death2", &soldier_move_death2},
+	{"soldier_move_death3", &soldier_move_death3},
+	{"soldier_move_death4", &soldier_move_death4},
+	{"soldier_move_death5", &soldier_move_death5},
+	{"soldier_move_death6", &soldier_move_death6},
+	{"soldier_move_pain1", &soldier_move_pain1},
+	{"soldier_move_pain2", &soldier_move_pain2},
+	{"soldier_move_pain3", &soldier_move_pain3},
+	{"soldier_move_pain4", &soldier_move_pain4},
+	{"soldier_move_run", &soldier_move_run},
+	{"soldier_move_stand1", &soldier_move_stand1},
+	{"soldier_move_stand3", &soldier_move_stand3},
+	{"soldier_move_start_run", &soldier_move_start_run},
+	{"soldier_move_walk1", &soldier_move_walk1},
+	{"soldier_move_walk2", &soldier_move_walk2},
+}
@@ -25,13 +25,28 @@
  */
 package server
 
-import "goquake2/shared"
+import (
+	"goquake2/shared"
+	"os"
+)
 
 /* MAX_CHALLENGES is made large to prevent a denial
    of service attack that could cycle all of them
    out before legitimate users connected */
 const MAX_CHALLENGES = 1024
 
+type redirect_t int
+
+const (
+	RD_NONE   redirect_t = 0
+	RD_CLIENT redirect_t = 1
+	RD_PACKET redirect_t = 2
+)
+
+const SV_OUTPUTBUF_LENGTH = shared.MAX_MSGLEN - 16
+
+const LATENCY_COUNTS = 16
+
 type server_state_t int
 
 const (
@@ -98,8 +113,8 @@ type client_t struct {
 	commandMsec int /* every seconds this is reset, if user */
 	/* commands exhaust it, assume time cheating */
 
-	// int frame_latency[LATENCY_COUNTS];
-	ping int
+	frame_latency [LATENCY_COUNTS]int
+	ping          int
 
 	// int message_size[RATE_MESSAGES];    /* used to rate drop packets */
 	rate          int
@@ -111,8 +126,7 @@ type client_t struct {
 
 	/* The datagram is written to by sound calls, prints,
 	   temp ents, etc. It can be harmlessly overflowed. */
-	// sizebuf_t datagram;
-	// byte datagram_buf[MAX_MSGLEN];
+	datagram *shared.QWritebuf
 
 	frames [shared.UPDATE_BACKUP]client_frame_t /* updates can be delta'd from here */
 
@@ -152,10 +166,9 @@ type server_static_t struct {
 
 	challenges [MAX_CHALLENGES]challenge_t /* to prevent invalid IPs from connecting */
 
-	// /* serverrecord values */
-	// FILE *demofile;
-	// sizebuf_t demo_multicast;
-	// byte demo_multicast_buf[MAX_MSGLEN];
+	/* serverrecord values */
+	demofile       *os.File
+	demo_multicast *shared.QWritebuf
 }
 
 type qServer struct {
@@ -199,6 +212,8 @@ type qServer struct {
 	area_type                 int
 
 	sv_player shared.Edict_s
+
+	game_args []string /* command line for ClientCommand and ServerCommand */
 }
 
 func CreateServer() shared.QServer {
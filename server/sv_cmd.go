@@ -27,6 +27,200 @@
  */
 package server
 
+import (
+	"fmt"
+	"goquake2/shared"
+	"os"
+	"strconv"
+	"strings"
+)
+
+/*
+ * Sets sv_client and sv_player to the player with idnum Cmd_Argv(1)
+ */
+func (T *qServer) svSetPlayer(args []string) bool {
+
+	if len(args) < 2 {
+		return false
+	}
+
+	s := args[1]
+
+	if len(s) == 0 {
+		T.common.Com_Printf("Usage: %v <userid>\n", args[0])
+		return false
+	}
+
+	/* numeric values are just slot numbers */
+	if (s[0] >= '0') && (s[0] <= '9') {
+		idnum, err := strconv.Atoi(s)
+		if err != nil || (idnum < 0) || (idnum >= len(T.svs.clients)) {
+			T.common.Com_Printf("Bad client slot: %v\n", s)
+			return false
+		}
+
+		T.sv_client = &T.svs.clients[idnum]
+		T.sv_player = T.sv_client.edict
+
+		if T.sv_client.state == cs_free {
+			T.common.Com_Printf("Client %v is not active\n", idnum)
+			return false
+		}
+
+		return true
+	}
+
+	/* check for a name match */
+	for i, cl := range T.svs.clients {
+		if cl.state == cs_free {
+			continue
+		}
+
+		if cl.name == s {
+			T.sv_client = &T.svs.clients[i]
+			T.sv_player = T.sv_client.edict
+			return true
+		}
+	}
+
+	T.common.Com_Printf("Userid %s is not on the server\n", s)
+	return false
+}
+
+/*
+ * Kick a user off of the server
+ */
+func sv_Kick_f(args []string, arg interface{}) error {
+
+	T := arg.(*qServer)
+	if !T.svs.initialized {
+		T.common.Com_Printf("No server running.\n")
+		return nil
+	}
+
+	if len(args) != 2 {
+		T.common.Com_Printf("Usage: kick <userid>\n")
+		return nil
+	}
+
+	if !T.svSetPlayer(args) {
+		return nil
+	}
+
+	if (T.sv_client.state == cs_spawned) && len(T.sv_client.name) > 0 {
+		T.svBroadcastPrintf(shared.PRINT_HIGH, "%s was kicked\n", T.sv_client.name)
+	}
+
+	/* print directly, because the dropped client
+	   won't get the svBroadcastPrintf message */
+	T.svClientPrintf(T.sv_client, shared.PRINT_HIGH, "You were kicked from the game\n")
+	T.svDropClient(T.sv_client)
+	T.sv_client.lastmessage = T.svs.realtime /* min case there is a funny zombie */
+	return nil
+}
+
+func sv_Status_f(args []string, arg interface{}) error {
+
+	T := arg.(*qServer)
+	if T.svs.clients == nil {
+		T.common.Com_Printf("No server running.\n")
+		return nil
+	}
+
+	T.common.Com_Printf("map              : %s\n", T.sv.name)
+
+	T.common.Com_Printf("num score ping name            lastmsg address               qport \n")
+	T.common.Com_Printf("--- ----- ---- --------------- ------- --------------------- ------\n")
+
+	for i, cl := range T.svs.clients {
+		if cl.state == cs_free {
+			continue
+		}
+
+		T.common.Com_Printf("%3v ", i)
+		T.common.Com_Printf("%5v ", cl.edict.Client().Ps().Stats[shared.STAT_FRAGS])
+
+		if cl.state == cs_connected {
+			T.common.Com_Printf("CNCT ")
+		} else if cl.state == cs_zombie {
+			T.common.Com_Printf("ZMBI ")
+		} else {
+			ping := cl.ping
+			if ping > 9999 {
+				ping = 9999
+			}
+			T.common.Com_Printf("%4v ", ping)
+		}
+
+		T.common.Com_Printf("%-16s", cl.name)
+		T.common.Com_Printf("%7v ", T.svs.realtime-cl.lastmessage)
+		T.common.Com_Printf("%-22s", shared.NET_AdrToString(cl.netchan.Remote_address))
+		T.common.Com_Printf("%5v", cl.netchan.Qport)
+		T.common.Com_Printf("\n")
+	}
+
+	T.common.Com_Printf("\n")
+	return nil
+}
+
+func sv_ConSay_f(args []string, arg interface{}) error {
+
+	T := arg.(*qServer)
+	if len(args) < 2 {
+		return nil
+	}
+
+	if !T.svs.initialized {
+		T.common.Com_Printf("No server running.\n")
+		return nil
+	}
+
+	text := "console: " + strings.Join(args[1:], " ")
+
+	for i, cl := range T.svs.clients {
+		if cl.state != cs_spawned {
+			continue
+		}
+
+		T.svClientPrintf(&T.svs.clients[i], shared.PRINT_CHAT, "%s\n", text)
+	}
+	return nil
+}
+
+func sv_Serverinfo_f(args []string, arg interface{}) error {
+
+	T := arg.(*qServer)
+	T.common.Com_Printf("Server info settings:\n")
+	T.common.Info_Print(T.common.Cvar_Serverinfo())
+	return nil
+}
+
+/*
+ * Examine all a users info strings
+ */
+func sv_DumpUser_f(args []string, arg interface{}) error {
+
+	T := arg.(*qServer)
+	if !T.svs.initialized {
+		T.common.Com_Printf("No server running.\n")
+		return nil
+	}
+
+	if len(args) != 2 {
+		T.common.Com_Printf("Usage: dumpuser <userid>\n")
+		return nil
+	}
+
+	if !T.svSetPlayer(args) {
+		return nil
+	}
+
+	T.common.Com_Printf("userinfo\n")
+	T.common.Com_Printf("--------\n")
+	T.common.Info_Print(T.sv_client.userinfo)
+	return nil
+}
+
 /*
  * Puts the server in demo mode on a specific map/cinematic
  */
@@ -68,45 +262,39 @@ func sv_GameMap_f(args []string, arg interface{}) error {
 
 	T.common.Com_DPrintf("SV_GameMap(%s)\n", args[1])
 
-	//  FS_CreatePath(va("%s/save/current/", FS_Gamedir()));
+	T.common.FS_CreatePath(fmt.Sprintf("%s/save/current/", T.common.FS_Gamedir()))
 
 	/* check for clearing the current savegame */
 	mmap := args[1]
 
-	//  if (map[0] == '*')
-	//  {
-	// 	 /* wipe all the *.sav files */
-	// 	 SV_WipeSavegame("current");
-	//  }
-	//  else
-	//  {
-	// 	 /* save the map just exited */
-	// 	 if (sv.state == ss_game)
-	// 	 {
-	// 		 /* clear all the client inuse flags before saving so that
-	// 			when the level is re-entered, the clients will spawn
-	// 			at spawn points instead of occupying body shells */
-	// 		 savedInuse = malloc(maxclients->value * sizeof(qboolean));
-
-	// 		 YQ2_COM_CHECK_OOM(savedInuse, "malloc()", maxclients->value * sizeof(qboolean))
-
-	// 		 for (i = 0, cl = svs.clients; i < maxclients->value; i++, cl++)
-	// 		 {
-	// 			 savedInuse[i] = cl->edict->inuse;
-	// 			 cl->edict->inuse = false;
-	// 		 }
-
-	// 		 SV_WriteLevelFile();
-
-	// 		 /* we must restore these for clients to transfer over correctly */
-	// 		 for (i = 0, cl = svs.clients; i < maxclients->value; i++, cl++)
-	// 		 {
-	// 			 cl->edict->inuse = savedInuse[i];
-	// 		 }
-
-	// 		 free(savedInuse);
-	// 	 }
-	//  }
+	if len(mmap) > 0 && mmap[0] == '*' {
+		/* wipe all the *.sav files */
+		T.svWipeSavegame("current")
+	} else {
+		/* save the map just exited */
+		if T.sv.state == ss_game {
+			/* clear all the client inuse flags before saving so that
+			   when the level is re-entered, the clients will spawn
+			   at spawn points instead of occupying body shells */
+			savedInuse := make([]bool, len(T.svs.clients))
+
+			for i, cl := range T.svs.clients {
+				savedInuse[i] = cl.edict.Inuse()
+				cl.edict.SetInuse(false)
+			}
+
+			err := T.svWriteLevelFile()
+
+			/* we must restore these for clients to transfer over correctly */
+			for i, cl := range T.svs.clients {
+				cl.edict.SetInuse(savedInuse[i])
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+	}
 
 	// it's possible to start a map with the wrong case, e.g. "/map BASE1"
 	// (even though the mapfile is maps/base1.bsp)
@@ -147,11 +335,13 @@ func sv_GameMap_f(args []string, arg interface{}) error {
 	/* archive server state */
 	T.svs.mapcmd = mmap
 
-	//  /* copy off the level to the autosave slot */
-	//  if (!dedicated->value) {
-	// 	 SV_WriteServerFile(true);
-	// 	 SV_CopySaveGame("current", "save0");
-	//  }
+	/* copy off the level to the autosave slot */
+	if !T.common.IsDedicated() {
+		if err := T.svWriteServerFile(true); err != nil {
+			return err
+		}
+		T.svCopySaveGame("current", "save0")
+	}
 	return nil
 }
 
@@ -183,10 +373,108 @@ func sv_Map_f(args []string, arg interface{}) error {
 	//  }
 
 	T.sv.state = ss_dead /* don't save current level when changing */
-	//  SV_WipeSavegame("current");
+	T.svWipeSavegame("current")
 	return sv_GameMap_f(args, T)
 }
 
+/*
+ * Begins server demo recording. Every entity and
+ * every message will be recorded, but no playerinfo
+ * will be stored. Primarily for demo merging.
+ *
+ * The client can't play these demos back yet. They
+ * are written with a playernum of -1, which the
+ * client's parseServerData takes for a cinematic.
+ */
+func sv_ServerRecord_f(args []string, arg interface{}) error {
+
+	T := arg.(*qServer)
+	if len(args) != 2 {
+		T.common.Com_Printf("serverrecord <demoname>\n")
+		return nil
+	}
+
+	if T.svs.demofile != nil {
+		T.common.Com_Printf("Already recording.\n")
+		return nil
+	}
+
+	if T.sv.state != ss_game {
+		T.common.Com_Printf("You must be in a level to record.\n")
+		return nil
+	}
+
+	if strings.Contains(args[1], "..") || strings.ContainsAny(args[1], "/\\") {
+		T.common.Com_Printf("Illegal filename.\n")
+		return nil
+	}
+
+	/* open the demo file */
+	name := fmt.Sprintf("%s/demos/%s.dm2", T.common.FS_Gamedir(), args[1])
+
+	T.common.Com_Printf("recording to %s.\n", name)
+	T.common.FS_CreatePath(name)
+	f, err := os.Create(name)
+	if err != nil {
+		T.common.Com_Printf("ERROR: couldn't open.\n")
+		return nil
+	}
+	T.svs.demofile = f
+
+	/* setup a buffer to catch all multicasts */
+	T.svs.demo_multicast = shared.QWritebufCreate(shared.MAX_MSGLEN)
+	T.svs.demo_multicast.Allowoverflow = true
+
+	/* write a single giant fake message with all the startup info */
+	buf := shared.QWritebufCreate(32768)
+
+	/* serverdata needs to go over for all types of servers
+	   to make sure the protocol is right, and to set the gamedir */
+	buf.WriteByte(shared.SvcServerdata)
+	buf.WriteLong(shared.PROTOCOL_VERSION)
+	buf.WriteLong(T.svs.spawncount)
+
+	/* 2 means server demo */
+	buf.WriteByte(2) /* demos are always attract loops */
+	buf.WriteString(T.common.Cvar_VariableString("gamedir"))
+	buf.WriteShort(-1)
+
+	/* send full levelname */
+	buf.WriteString(T.sv.configstrings[shared.CS_NAME])
+
+	for i, cs := range T.sv.configstrings {
+		if len(cs) > 0 {
+			buf.WriteByte(shared.SvcConfigstring)
+			buf.WriteShort(i)
+			buf.WriteString(cs)
+		}
+	}
+
+	/* write it to the demo file */
+	T.common.Com_DPrintf("signon message length: %v\n", buf.Cursize)
+	T.svWriteDemoBlock(buf.Data())
+
+	/* the rest of the demo file will be individual frames */
+	return nil
+}
+
+/*
+ * Ends server demo recording
+ */
+func sv_ServerStop_f(args []string, arg interface{}) error {
+
+	T := arg.(*qServer)
+	if T.svs.demofile == nil {
+		T.common.Com_Printf("Not doing a serverrecord.\n")
+		return nil
+	}
+
+	T.svs.demofile.Close()
+	T.svs.demofile = nil
+	T.common.Com_Printf("Recording completed.\n")
+	return nil
+}
+
 /*
  * Kick everyone off, possibly in preparation for a new game
  */
@@ -202,12 +490,27 @@ func sv_KillServer_f(args []string, arg interface{}) error {
 	return nil
 }
 
+/*
+ * Let the game dll handle a command
+ */
+func sv_ServerCommand_f(args []string, arg interface{}) error {
+	T := arg.(*qServer)
+	if T.ge == nil {
+		T.common.Com_Printf("No game loaded.\n")
+		return nil
+	}
+
+	T.game_args = args
+	T.ge.ServerCommand()
+	return nil
+}
+
 func (T *qServer) initOperatorCommands() {
 	// Cmd_AddCommand("heartbeat", SV_Heartbeat_f);
-	// Cmd_AddCommand("kick", SV_Kick_f);
-	// Cmd_AddCommand("status", SV_Status_f);
-	// Cmd_AddCommand("serverinfo", SV_Serverinfo_f);
-	// Cmd_AddCommand("dumpuser", SV_DumpUser_f);
+	T.common.Cmd_AddCommand("kick", sv_Kick_f, T)
+	T.common.Cmd_AddCommand("status", sv_Status_f, T)
+	T.common.Cmd_AddCommand("serverinfo", sv_Serverinfo_f, T)
+	T.common.Cmd_AddCommand("dumpuser", sv_DumpUser_f, T)
 
 	T.common.Cmd_AddCommand("map", sv_Map_f, T)
 	// Cmd_AddCommand("listmaps", SV_ListMaps_f);
@@ -215,18 +518,17 @@ func (T *qServer) initOperatorCommands() {
 	T.common.Cmd_AddCommand("gamemap", sv_GameMap_f, T)
 	// Cmd_AddCommand("setmaster", SV_SetMaster_f);
 
-	// if (dedicated->value)
-	// {
-	// 	Cmd_AddCommand("say", SV_ConSay_f);
-	// }
+	if T.common.IsDedicated() {
+		T.common.Cmd_AddCommand("say", sv_ConSay_f, T)
+	}
 
-	// Cmd_AddCommand("serverrecord", SV_ServerRecord_f);
-	// Cmd_AddCommand("serverstop", SV_ServerStop_f);
+	T.common.Cmd_AddCommand("serverrecord", sv_ServerRecord_f, T)
+	T.common.Cmd_AddCommand("serverstop", sv_ServerStop_f, T)
 
-	// Cmd_AddCommand("save", SV_Savegame_f);
-	// Cmd_AddCommand("load", SV_Loadgame_f);
+	T.common.Cmd_AddCommand("save", sv_Savegame_f, T)
+	T.common.Cmd_AddCommand("load", sv_Loadgame_f, T)
 
 	T.common.Cmd_AddCommand("killserver", sv_KillServer_f, T)
 
-	// Cmd_AddCommand("sv", SV_ServerCommand_f);
+	T.common.Cmd_AddCommand("sv", sv_ServerCommand_f, T)
 }
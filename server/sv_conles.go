@@ -29,6 +29,7 @@ import (
 	"fmt"
 	"goquake2/shared"
 	"strconv"
+	"strings"
 )
 
 /*
@@ -78,10 +79,10 @@ func (T *qServer) getChallenge(args []string, adr shared.Netadr_t) error {
 
 	/* see if we already have a challenge for this ip */
 	for i, clg := range T.svs.challenges {
-		// 	 if (NET_CompareBaseAdr(net_from, svs.challenges[i].adr))
-		// 	 {
-		// 		 break;
-		// 	 }
+		if shared.NET_CompareBaseAdr(adr, clg.adr) {
+			index = i
+			break
+		}
 
 		if clg.time < oldestTime {
 			oldestTime = clg.time
@@ -135,86 +136,77 @@ func (T *qServer) directConnect(args []string, adr shared.Netadr_t) error {
 
 	userinfo := args[4]
 
-	// 	 /* force the IP key/value pair so the game can filter based on ip */
-	// 	 Info_SetValueForKey(userinfo, "ip", NET_AdrToString(net_from));
+	/* force the IP key/value pair so the game can filter based on ip */
+	userinfo, err := shared.Info_SetValueForKey(userinfo, "ip", shared.NET_AdrToString(adr))
+	if err != nil {
+		T.common.Com_Printf("%v\n", err)
+	}
 
-	// 	 /* attractloop servers are ONLY for local clients */
-	// 	 if (sv.attractloop)
-	// 	 {
-	// 		 if (!NET_IsLocalAddress(adr))
-	// 		 {
-	// 			 Com_Printf("Remote connect in attract loop.  Ignored.\n");
-	// 			 Netchan_OutOfBandPrint(NS_SERVER, adr,
-	// 					 "print\nConnection refused.\n");
-	// 			 return;
-	// 		 }
-	// 	 }
+	/* attractloop servers are ONLY for local clients */
+	if T.sv.attractloop {
+		if !shared.NET_IsLocalAddress(adr) {
+			T.common.Com_Printf("Remote connect in attract loop.  Ignored.\n")
+			T.common.Netchan_OutOfBandPrint(shared.NS_SERVER, adr,
+				"print\nConnection refused.\n")
+			return nil
+		}
+	}
 
-	// 	 /* see if the challenge is valid */
-	// 	 if (!NET_IsLocalAddress(adr))
-	// 	 {
-	// 		 for (i = 0; i < MAX_CHALLENGES; i++)
-	// 		 {
-	// 			 if (NET_CompareBaseAdr(net_from, svs.challenges[i].adr))
-	// 			 {
-	// 				 if (challenge == svs.challenges[i].challenge)
-	// 				 {
-	// 					 break; /* good */
-	// 				 }
-
-	// 				 Netchan_OutOfBandPrint(NS_SERVER, adr,
-	// 						 "print\nBad challenge.\n");
-	// 				 return;
-	// 			 }
-	// 		 }
-
-	// 		 if (i == MAX_CHALLENGES)
-	// 		 {
-	// 			 Netchan_OutOfBandPrint(NS_SERVER, adr,
-	// 					 "print\nNo challenge for address.\n");
-	// 			 return;
-	// 		 }
-	// 	 }
+	/* see if the challenge is valid */
+	if !shared.NET_IsLocalAddress(adr) {
+		found := false
+		for _, clg := range T.svs.challenges {
+			if shared.NET_CompareBaseAdr(adr, clg.adr) {
+				if int(challenge) == clg.challenge {
+					found = true
+					break /* good */
+				}
+
+				T.common.Netchan_OutOfBandPrint(shared.NS_SERVER, adr,
+					"print\nBad challenge.\n")
+				return nil
+			}
+		}
+
+		if !found {
+			T.common.Netchan_OutOfBandPrint(shared.NS_SERVER, adr,
+				"print\nNo challenge for address.\n")
+			return nil
+		}
+	}
 
 	index := -1
-	// 	 newcl = &temp;
-	// 	 memset(newcl, 0, sizeof(client_t));
 
-	// 	 /* if there is already a slot for this ip, reuse it */
-	// 	 for (i = 0, cl = svs.clients; i < maxclients->value; i++, cl++)
-	// 	 {
-	// 		 if (cl->state < cs_connected)
-	// 		 {
-	// 			 continue;
-	// 		 }
-
-	// 		 if (NET_CompareBaseAdr(adr, cl->netchan.remote_address) &&
-	// 			 ((cl->netchan.qport == qport) ||
-	// 			  (adr.port == cl->netchan.remote_address.port)))
-	// 		 {
-	// 			 if (!NET_IsLocalAddress(adr))
-	// 			 {
-	// 				 Com_DPrintf("%s:reconnect rejected : too soon\n",
-	// 						 NET_AdrToString(adr));
-	// 				 return;
-	// 			 }
-
-	// 			 Com_Printf("%s:reconnect\n", NET_AdrToString(adr));
-	// 			 newcl = cl;
-	// 			 goto gotnewcl;
-	// 		 }
-	// 	 }
+	/* if there is already a slot for this ip, reuse it */
+	for i, cl := range T.svs.clients {
+		if cl.state < cs_connected {
+			continue
+		}
 
-	// 	 /* find a client slot */
-	// 	 newcl = NULL;
+		if shared.NET_CompareBaseAdr(adr, cl.netchan.Remote_address) &&
+			((cl.netchan.Qport == int(qport)) ||
+				(adr.Port == cl.netchan.Remote_address.Port)) {
+			if !shared.NET_IsLocalAddress(adr) {
+				T.common.Com_DPrintf("%s:reconnect rejected : too soon\n",
+					shared.NET_AdrToString(adr))
+				return nil
+			}
 
-	for i, cl := range T.svs.clients {
-		if cl.state == cs_free {
+			T.common.Com_Printf("%s:reconnect\n", shared.NET_AdrToString(adr))
 			index = i
 			break
 		}
 	}
 
+	/* find a client slot */
+	if index < 0 {
+		for i, cl := range T.svs.clients {
+			if cl.state == cs_free {
+				index = i
+				break
+			}
+		}
+	}
 	if index < 0 {
 		T.common.Netchan_OutOfBandPrint(shared.NS_SERVER, adr, "print\nServer is full.\n")
 		T.common.Com_DPrintf("Rejected a connection.\n")
@@ -234,18 +226,15 @@ func (T *qServer) directConnect(args []string, adr shared.Netadr_t) error {
 	T.svs.clients[index].challenge = int(challenge) /* save challenge for checksumming */
 
 	// 	 /* get the game a chance to reject this connection or modify the userinfo */
-	if !(T.ge.ClientConnect(ent, userinfo)) {
-		// 		 if (*Info_ValueForKey(userinfo, "rejmsg"))
-		// 		 {
-		// 			 Netchan_OutOfBandPrint(NS_SERVER, adr,
-		// 					 "print\n%s\nConnection refused.\n",
-		// 					 Info_ValueForKey(userinfo, "rejmsg"));
-		// 		 }
-		// 		 else
-		// 		 {
-		// 			 Netchan_OutOfBandPrint(NS_SERVER, adr,
-		// 					 "print\nConnection refused.\n");
-		// 		 }
+	if !(T.ge.ClientConnect(ent, &userinfo)) {
+		if len(shared.Info_ValueForKey(userinfo, "rejmsg")) > 0 {
+			T.common.Netchan_OutOfBandPrint(shared.NS_SERVER, adr,
+				"print\n%s\nConnection refused.\n",
+				shared.Info_ValueForKey(userinfo, "rejmsg"))
+		} else {
+			T.common.Netchan_OutOfBandPrint(shared.NS_SERVER, adr,
+				"print\nConnection refused.\n")
+		}
 
 		T.common.Com_DPrintf("Game rejected a connection.\n")
 		return nil
@@ -269,13 +258,61 @@ func (T *qServer) directConnect(args []string, adr shared.Netadr_t) error {
 
 	T.svs.clients[index].state = cs_connected
 
-	// 	 SZ_Init(&newcl->datagram, newcl->datagram_buf, sizeof(newcl->datagram_buf));
-	// 	 newcl->datagram.allowoverflow = true;
+	T.svs.clients[index].datagram = shared.QWritebufCreate(shared.MAX_MSGLEN)
+	T.svs.clients[index].datagram.Allowoverflow = true
 	T.svs.clients[index].lastmessage = T.svs.realtime /* don't timeout */
 	T.svs.clients[index].lastconnect = T.svs.realtime
 	return nil
 }
 
+func (T *qServer) rconValidate(args []string) bool {
+	if len(T.rcon_password.String) == 0 {
+		return false
+	}
+
+	if len(args) < 2 || args[1] != T.rcon_password.String {
+		return false
+	}
+
+	return true
+}
+
+/*
+ * A client issued an rcon command.
+ * Shift down the remaining args
+ * Redirect all printfs
+ */
+func (T *qServer) remoteCommand(args []string, adr shared.Netadr_t) error {
+
+	var remaining strings.Builder
+	for i := 2; i < len(args); i++ {
+		if strings.ContainsAny(args[i], " ;") {
+			remaining.WriteString("\"" + args[i] + "\"")
+		} else {
+			remaining.WriteString(args[i])
+		}
+		remaining.WriteString(" ")
+	}
+
+	if !T.rconValidate(args) {
+		T.common.Com_Printf("Bad rcon from %s:\n%s\n", shared.NET_AdrToString(adr), remaining.String())
+	} else {
+		T.common.Com_Printf("Rcon from %s:\n%s\n", shared.NET_AdrToString(adr), remaining.String())
+	}
+
+	T.common.Com_BeginRedirect(int(RD_PACKET), SV_OUTPUTBUF_LENGTH, func(target int, buffer string) {
+		T.flushRedirect(redirect_t(target), buffer, adr)
+	})
+	defer T.common.Com_EndRedirect()
+
+	if !T.rconValidate(args) {
+		T.common.Com_Printf("Bad rcon_password.\n")
+		return nil
+	}
+
+	return T.common.Cmd_ExecuteString(remaining.String())
+}
+
 /*
  * A connectionless packet has four leading 0xff
  * characters to distinguish it from a game channel.
@@ -293,7 +330,7 @@ func (T *qServer) connectionlessPacket(msg *shared.QReadbuf, from *shared.Netadr
 
 	args := T.common.Cmd_TokenizeString(s, false)
 
-	T.common.Com_DPrintf("Packet %v : %v\n", from, args[0])
+	T.common.Com_DPrintf("Packet %s : %v\n", shared.NET_AdrToString(*from), args[0])
 
 	switch args[0] {
 	//  if (!strcmp(c, "ping"))
@@ -314,12 +351,10 @@ func (T *qServer) connectionlessPacket(msg *shared.QReadbuf, from *shared.Netadr
 		return T.getChallenge(args, *from)
 	case "connect":
 		return T.directConnect(args, *from)
-	//  else if (!strcmp(c, "rcon"))
-	//  {
-	// 	 SVC_RemoteCommand();
-	//  }
+	case "rcon":
+		return T.remoteCommand(args, *from)
 	default:
-		T.common.Com_Printf("bad connectionless packet from %v:\n%v\n", from, s)
+		T.common.Com_Printf("bad connectionless packet from %s:\n%v\n", shared.NET_AdrToString(*from), s)
 	}
 	return nil
 }
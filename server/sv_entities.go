@@ -27,7 +27,10 @@
  */
 package server
 
-import "goquake2/shared"
+import (
+	"encoding/binary"
+	"goquake2/shared"
+)
 
 /*
  * Writes a delta update of an entity_state_t list to the message.
@@ -466,7 +469,7 @@ func (T *qServer) svBuildClientFrame(client *client_t) {
 				/* doors can legally straddle two areas,
 				so we may need to check another one */
 				if ent.Areanum2() == 0 ||
-					!T.common.CMAreasConnected(clientarea, ent.Areanum()) {
+					!T.common.CMAreasConnected(clientarea, ent.Areanum2()) {
 					continue /* blocked by a door */
 				}
 			}
@@ -538,3 +541,68 @@ func (T *qServer) svBuildClientFrame(client *client_t) {
 		frame.num_entities++
 	}
 }
+
+/*
+ * Writes a length prefixed block to the server demo file.
+ * A failed write ends the recording, a demo with a hole
+ * in it is of no use.
+ */
+func (T *qServer) svWriteDemoBlock(data []byte) {
+	var swlen [4]byte
+	binary.LittleEndian.PutUint32(swlen[:], uint32(len(data)))
+
+	_, err := T.svs.demofile.Write(swlen[:])
+	if err == nil {
+		_, err = T.svs.demofile.Write(data)
+	}
+
+	if err != nil {
+		T.common.Com_Printf("serverrecord: %v\n", err)
+		T.svs.demofile.Close()
+		T.svs.demofile = nil
+		T.common.Com_Printf("Recording stopped.\n")
+	}
+}
+
+/*
+ * Save everything in the world out without deltas.
+ * Used for recording footage for merged or assembled demos
+ */
+func (T *qServer) svRecordDemoMessage() {
+	if T.svs.demofile == nil {
+		return
+	}
+
+	nostate := shared.Entity_state_t{}
+	buf := shared.QWritebufCreate(32768)
+
+	/* write a frame message that doesn't contain a player_state_t */
+	buf.WriteByte(shared.SvcFrame)
+	buf.WriteLong(T.sv.framenum)
+
+	buf.WriteByte(shared.SvcPacketentities)
+
+	for e := 1; e < T.ge.NumEdicts(); e++ {
+		ent := T.ge.Edict(e)
+
+		/* ignore ents without visible models unless they have an effect */
+		if ent.Inuse() && ent.S().Number != 0 &&
+			(ent.S().Modelindex != 0 || ent.S().Effects != 0 ||
+				ent.S().Sound != 0 || ent.S().Event != 0) &&
+			(ent.Svflags()&shared.SVF_NOCLIENT) == 0 {
+			buf.WriteDeltaEntity(&nostate, ent.S(), false, true)
+		}
+	}
+
+	buf.WriteShort(0) /* end of packetentities */
+
+	/* now add the accumulated multicast information */
+	if T.svs.demo_multicast.Overflowed {
+		T.common.Com_DPrintf("serverrecord: multicast overflowed, messages lost\n")
+	}
+	buf.Write(T.svs.demo_multicast.Data())
+	T.svs.demo_multicast.Clear()
+
+	/* now write the entire message to the file, prefixed by the length */
+	T.svWriteDemoBlock(buf.Data())
+}
@@ -29,12 +29,45 @@ import (
 	"fmt"
 	"goquake2/game"
 	"goquake2/shared"
+	"strings"
 )
 
 type qGameImp struct {
 	T *qServer
 }
 
+/*
+ * Sends the contents of the multicast buffer to a single client
+ */
+func (G *qGameImp) Unicast(ent shared.Edict_s, reliable bool) {
+	if ent == nil {
+		return
+	}
+
+	p := ent.S().Number
+	if (p < 1) || (p > G.T.maxclients.Int()) {
+		return
+	}
+
+	client := &G.T.svs.clients[p-1]
+
+	if reliable {
+		client.netchan.Message.Write(G.T.sv.multicast.Data())
+	} else {
+		client.datagram.Write(G.T.sv.multicast.Data())
+	}
+
+	G.T.sv.multicast.Clear()
+}
+
+func (G *qGameImp) Multicast(origin []float32, to shared.Multicast_t) {
+	G.T.svMulticast(origin, to)
+}
+
+func (G *qGameImp) Bprintf(printlevel int, format string, a ...interface{}) {
+	G.T.svBroadcastPrintf(printlevel, format, a...)
+}
+
 /*
  * Debug print to server console
  */
@@ -42,10 +75,69 @@ func (G *qGameImp) Dprintf(format string, a ...interface{}) {
 	G.T.common.Com_Printf(format, a...)
 }
 
+/*
+ * Print to a single client if the level passes
+ */
+func (G *qGameImp) Cprintf(ent shared.Edict_s, printlevel int, format string, a ...interface{}) {
+	if ent == nil {
+		G.T.common.Com_Printf(format, a...)
+		return
+	}
+
+	n := ent.S().Number
+	if (n < 1) || (n > G.T.maxclients.Int()) {
+		G.T.common.Com_Error(shared.ERR_DROP, "cprintf to a non-client")
+		return
+	}
+
+	G.T.svClientPrintf(&G.T.svs.clients[n-1], printlevel, format, a...)
+}
+
+/*
+ * centerprint to a single client
+ */
+func (G *qGameImp) Centerprintf(ent shared.Edict_s, format string, a ...interface{}) {
+	n := ent.S().Number
+	if (n < 1) || (n > G.T.maxclients.Int()) {
+		return
+	}
+
+	G.T.sv.multicast.WriteByte(shared.SvcCenterprint)
+	G.T.sv.multicast.WriteString(fmt.Sprintf(format, a...))
+	G.Unicast(ent, true)
+}
+
 func (G *qGameImp) Cvar(var_name, value string, flags int) *shared.CvarT {
 	return G.T.common.Cvar_Get(var_name, value, flags)
 }
 
+func (G *qGameImp) Argc() int {
+	return len(G.T.game_args)
+}
+
+func (G *qGameImp) Argv(n int) string {
+	if (n < 0) || (n >= len(G.T.game_args)) {
+		return ""
+	}
+
+	return G.T.game_args[n]
+}
+
+/*
+ * Concatenation of all argv >= 1
+ */
+func (G *qGameImp) Args() string {
+	if len(G.T.game_args) < 2 {
+		return ""
+	}
+
+	return strings.Join(G.T.game_args[1:], " ")
+}
+
+func (G *qGameImp) AddCommandString(text string) {
+	G.T.common.Cbuf_AddText(text)
+}
+
 func (G *qGameImp) Error(format string, a ...interface{}) error {
 	return G.T.common.Com_Error(shared.ERR_DROP, "Game Error: %s", fmt.Sprintf(format, a...))
 }
@@ -69,6 +161,20 @@ func (G *qGameImp) Configstring(num int, str string) error {
 	return nil
 }
 
+func (G *qGameImp) Sound(ent shared.Edict_s, channel, soundindex int, volume,
+	attenuation, timeofs float32) error {
+	if ent == nil {
+		return nil
+	}
+
+	return G.T.svStartSound(nil, ent, channel, soundindex, volume, attenuation, timeofs)
+}
+
+func (G *qGameImp) PositionedSound(origin []float32, ent shared.Edict_s, channel,
+	soundindex int, volume, attenuation, timeofs float32) error {
+	return G.T.svStartSound(origin, ent, channel, soundindex, volume, attenuation, timeofs)
+}
+
 func (G *qGameImp) Modelindex(name string) int {
 	return G.T.svFindIndex(name, shared.CS_MODELS, shared.MAX_MODELS, true)
 }
@@ -105,6 +211,42 @@ func (G *qGameImp) BoxEdicts(mins, maxs []float32, edicts []shared.Edict_s, maxc
 	return G.T.svAreaEdicts(mins, maxs, edicts, maxcount, areatype)
 }
 
+func (G *qGameImp) WriteChar(c int) {
+	G.T.sv.multicast.WriteChar(c)
+}
+
+func (G *qGameImp) WriteUByte(c int) {
+	G.T.sv.multicast.WriteByte(c)
+}
+
+func (G *qGameImp) WriteShort(c int) {
+	G.T.sv.multicast.WriteShort(c)
+}
+
+func (G *qGameImp) WriteLong(c int) {
+	G.T.sv.multicast.WriteLong(c)
+}
+
+func (G *qGameImp) WriteFloat(f float32) {
+	G.T.sv.multicast.WriteFloat(f)
+}
+
+func (G *qGameImp) WriteString(s string) {
+	G.T.sv.multicast.WriteString(s)
+}
+
+func (G *qGameImp) WritePosition(pos []float32) {
+	G.T.sv.multicast.WritePos(pos)
+}
+
+func (G *qGameImp) WriteDir(dir []float32) {
+	G.T.sv.multicast.WriteDir(dir)
+}
+
+func (G *qGameImp) WriteAngle(f float32) {
+	G.T.sv.multicast.WriteAngle(f)
+}
+
 /*
  * Also sets mins and maxs for inline bmodels
  */
@@ -189,6 +331,14 @@ func (G *qGameImp) InPHS(p1, p2 []float32) bool {
 	return true
 }
 
+func (G *qGameImp) SetAreaPortalState(portalnum int, open bool) error {
+	return G.T.common.CMSetAreaPortalState(portalnum, open)
+}
+
+func (G *qGameImp) AreasConnected(area1, area2 int) bool {
+	return G.T.common.CMAreasConnected(area1, area2)
+}
+
 /*
  * Called when either the entire server is being killed, or
  * it is changing to a different game directory.
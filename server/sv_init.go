@@ -113,9 +113,9 @@ func (T *qServer) spawnServer(server, spawnpoint string, serverstate server_stat
 	T.common.Com_Printf("------- server initialization ------\n")
 	T.common.Com_DPrintf("SpawnServer: %s\n", server)
 
-	//  if (sv.demofile) {
-	// 	 FS_FCloseFile(sv.demofile);
-	//  }
+	if T.sv.demofile != nil {
+		T.sv.demofile.Close()
+	}
 
 	T.svs.spawncount++ /* any partially connected client will be restarted */
 	T.sv.state = ss_dead
@@ -211,8 +211,10 @@ func (T *qServer) spawnServer(server, spawnpoint string, serverstate server_stat
 	/* create a baseline for more efficient communications */
 	T.createBaseline()
 
-	//  /* check for a savegame */
-	//  SV_CheckForSavegame();
+	/* check for a savegame */
+	if err := T.svCheckForSavegame(); err != nil {
+		return err
+	}
 
 	/* set serverinfo variable */
 	T.common.Cvar_FullSet("mapname", T.sv.name, shared.CVAR_SERVERINFO|shared.CVAR_NOSET)
@@ -379,7 +381,7 @@ func (T *qServer) svMap(attractloop bool, levelstring string, loadgame bool) err
 	// 	/* skip the end-of-unit flag if necessary */
 	// 	l = strlen(level);
 
-	if level[0] == '*' {
+	if len(level) > 0 && level[0] == '*' {
 		level = level[1:]
 	}
 
@@ -32,6 +32,110 @@ import (
 	"time"
 )
 
+/*
+ * Called when the player is totally leaving the server, either willingly
+ * or unwillingly. This is NOT called if the entire server is quiting
+ * or crashing.
+ */
+func (T *qServer) svDropClient(drop *client_t) {
+	/* add the disconnect */
+	drop.netchan.Message.WriteByte(shared.SvcDisconnect)
+
+	if drop.state == cs_spawned {
+		/* call the prog function for removing a client
+		   this will remove the body, among other things */
+		T.ge.ClientDisconnect(drop.edict)
+	}
+
+	drop.state = cs_zombie /* become free in a few seconds */
+	drop.name = ""
+}
+
+/*
+ * Updates the cl->ping variables
+ */
+func (T *qServer) svCalcPings() {
+	for i, cl := range T.svs.clients {
+		if cl.state != cs_spawned {
+			continue
+		}
+
+		total := 0
+		count := 0
+
+		for _, l := range cl.frame_latency {
+			if l > 0 {
+				count++
+				total += l
+			}
+		}
+
+		if count == 0 {
+			T.svs.clients[i].ping = 0
+		} else {
+			T.svs.clients[i].ping = total / count
+		}
+
+		/* let the game dll know about the ping */
+		if cl.edict != nil && cl.edict.Client() != nil {
+			cl.edict.Client().SetPing(T.svs.clients[i].ping)
+		}
+	}
+}
+
+/*
+ * Every few frames, gives all clients an allotment of milliseconds
+ * for their command moves. If they exceed it, assume cheating.
+ */
+func (T *qServer) svGiveMsec() {
+	if (T.sv.framenum & 15) != 0 {
+		return
+	}
+
+	for i, cl := range T.svs.clients {
+		if cl.state == cs_free {
+			continue
+		}
+
+		T.svs.clients[i].commandMsec = 1800 /* 1600 + some slop */
+	}
+}
+
+/*
+ * If a packet has not been received from a client for timeout->value
+ * seconds, drop the conneciton.  Server frames are used instead of
+ * realtime to avoid dropping the local client while debugging.
+ *
+ * When a client is normally dropped, the client_t goes into a zombie state
+ * for a few seconds to make sure any final reliable message gets resent
+ * if necessary
+ */
+func (T *qServer) svCheckTimeouts() {
+	droppoint := T.svs.realtime - int(1000*T.timeout.Float())
+	zombiepoint := T.svs.realtime - int(1000*T.zombietime.Float())
+
+	for i := range T.svs.clients {
+		cl := &T.svs.clients[i]
+
+		/* message times may be wrong across a changelevel */
+		if cl.lastmessage > T.svs.realtime {
+			cl.lastmessage = T.svs.realtime
+		}
+
+		if (cl.state == cs_zombie) && (cl.lastmessage < zombiepoint) {
+			cl.state = cs_free /* can now be reused */
+			continue
+		}
+
+		if ((cl.state == cs_connected) || (cl.state == cs_spawned)) &&
+			(cl.lastmessage < droppoint) {
+			T.svBroadcastPrintf(shared.PRINT_HIGH, "%s timed out\n", cl.name)
+			T.svDropClient(cl)
+			cl.state = cs_free /* don't bother with zombie state */
+		}
+	}
+}
+
 /*
  * Pull specific info from a newly changed userinfo string
  * into a more C freindly form.
@@ -40,8 +144,9 @@ func (T *qServer) userinfoChanged(cl *client_t) {
 	//  char *val;
 	//  int i;
 
-	//  /* call prog code to allow overrides */
-	//  ge->ClientUserinfoChanged(cl->edict, cl->userinfo);
+	/* call prog code to allow overrides, the
+	   game may rewrite cl.userinfo in place */
+	T.ge.ClientUserinfoChanged(cl.edict, &cl.userinfo)
 
 	/* name for C code */
 	cl.name = shared.Info_ValueForKey(cl.userinfo, "name")
@@ -67,12 +172,13 @@ func (T *qServer) userinfoChanged(cl *client_t) {
 	}
 
 	/* msg command */
-	//  val = Info_ValueForKey(cl->userinfo, "msg");
+	v = shared.Info_ValueForKey(cl.userinfo, "msg")
 
-	//  if (strlen(val))
-	//  {
-	// 	 cl->messagelevel = (int)strtol(val, (char **)NULL, 10);
-	//  }
+	if len(v) > 0 {
+		if i, err := strconv.ParseInt(v, 10, 32); err == nil {
+			cl.messagelevel = int(i)
+		}
+	}
 }
 
 /*
@@ -147,26 +253,23 @@ func (T *qServer) readPackets() error {
 				continue
 			}
 
-			// 		if (!NET_CompareBaseAdr(net_from, cl->netchan.remote_address))
-			// 		{
-			// 			continue;
-			// 		}
+			if !shared.NET_CompareBaseAdr(*from, cl.netchan.Remote_address) {
+				continue
+			}
 
 			if cl.netchan.Qport != qport {
-				println("Port does not match")
 				continue
 			}
 
-			// 		if (cl->netchan.remote_address.port != net_from.port)
-			// 		{
-			// 			Com_Printf("SV_ReadPackets: fixing up a translated port\n");
-			// 			cl->netchan.remote_address.port = net_from.port;
-			// 		}
+			if cl.netchan.Remote_address.Port != from.Port {
+				T.common.Com_Printf("SV_ReadPackets: fixing up a translated port\n")
+				T.svs.clients[i].netchan.Remote_address.Port = from.Port
+			}
 
 			if T.svs.clients[i].netchan.Process(msg) {
 				/* this is a valid, sequenced packet, so process it */
 				if cl.state != cs_zombie {
-					cl.lastmessage = T.svs.realtime /* don't timeout */
+					T.svs.clients[i].lastmessage = T.svs.realtime /* don't timeout */
 
 					if !(T.sv.demofile != nil && (T.sv.state == ss_demo)) {
 						if err := T.executeClientMessage(&T.svs.clients[i], msg); err != nil {
@@ -235,7 +338,7 @@ func (T *qServer) Frame(usec int) error {
 	shared.Randk()
 
 	/* check timeouts */
-	// SV_CheckTimeouts();
+	T.svCheckTimeouts()
 
 	/* get packets from clients */
 	if err := T.readPackets(); err != nil {
@@ -258,10 +361,10 @@ func (T *qServer) Frame(usec int) error {
 	}
 
 	/* update ping based on the last known frame from all clients */
-	// SV_CalcPings();
+	T.svCalcPings()
 
 	/* give the clients some timeslices */
-	// SV_GiveMsec();
+	T.svGiveMsec()
 
 	/* let everything in the world think and move */
 	if err := T.runGameFrame(); err != nil {
@@ -272,7 +375,7 @@ func (T *qServer) Frame(usec int) error {
 	T.svSendClientMessages()
 
 	/* save the entire world state if recording a serverdemo */
-	// SV_RecordDemoMessage();
+	T.svRecordDemoMessage()
 
 	/* send a heartbeat to the master if needed */
 	// Master_Heartbeat();
@@ -350,9 +453,9 @@ func (T *qServer) Shutdown(finalmsg string, reconnect bool) {
 	T.svs.clients = nil
 	T.svs.client_entities = nil
 
-	// if T.svs.demofile {
-	// 	fclose(svs.demofile)
-	// }
+	if T.svs.demofile != nil {
+		T.svs.demofile.Close()
+	}
 
 	T.svs = server_static_t{}
 }
@@ -0,0 +1,395 @@
+/*
+ * Copyright (C) 1997-2001 Id Software, Inc.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or (at
+ * your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but
+ * WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ *
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 59 Temple Place - Suite 330, Boston, MA
+ * 02111-1307, USA.
+ *
+ * =======================================================================
+ *
+ * Serverside savegame code.
+ *
+ * =======================================================================
+ */
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"goquake2/shared"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+/* sizes of the fixed length fields in server.ssv */
+const (
+	SAVE_COMMENT_LENGTH = 32
+	SAVE_MAPCMD_LENGTH  = 1024
+	SAVE_CVAR_LENGTH    = 128
+)
+
+func writeFixedString(buf *bytes.Buffer, s string, length int) {
+	b := make([]byte, length)
+	copy(b[:length-1], s)
+	buf.Write(b)
+}
+
+func readFixedString(data []byte, length int) (string, []byte) {
+	s := data[:length]
+	if i := bytes.IndexByte(s, 0); i >= 0 {
+		s = s[:i]
+	}
+	return string(s), data[length:]
+}
+
+func copyFile(src, dst string) {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return
+	}
+
+	os.WriteFile(dst, data, 0644)
+}
+
+/*
+ * Delete save/<XXX>/
+ */
+func (T *qServer) svWipeSavegame(savename string) {
+
+	T.common.Com_DPrintf("SV_WipeSaveGame(%s)\n", savename)
+
+	dir := fmt.Sprintf("%s/save/%s", T.common.FS_Gamedir(), savename)
+
+	os.Remove(dir + "/server.ssv")
+	os.Remove(dir + "/game.ssv")
+
+	for _, pattern := range []string{"/*.sav", "/*.sv2"} {
+		found, _ := filepath.Glob(dir + pattern)
+		for _, name := range found {
+			os.Remove(name)
+		}
+	}
+}
+
+func (T *qServer) svCopySaveGame(src, dst string) {
+
+	T.common.Com_DPrintf("SV_CopySaveGame(%s, %s)\n", src, dst)
+
+	T.svWipeSavegame(dst)
+
+	/* copy the savegame over */
+	srcdir := fmt.Sprintf("%s/save/%s", T.common.FS_Gamedir(), src)
+	dstdir := fmt.Sprintf("%s/save/%s", T.common.FS_Gamedir(), dst)
+	T.common.FS_CreatePath(dstdir + "/server.ssv")
+	copyFile(srcdir+"/server.ssv", dstdir+"/server.ssv")
+	copyFile(srcdir+"/game.ssv", dstdir+"/game.ssv")
+
+	found, _ := filepath.Glob(srcdir + "/*.sav")
+	for _, name := range found {
+		name = filepath.Base(name)
+		copyFile(srcdir+"/"+name, dstdir+"/"+name)
+
+		/* change sav to sv2 */
+		name = strings.TrimSuffix(name, ".sav") + ".sv2"
+		copyFile(srcdir+"/"+name, dstdir+"/"+name)
+	}
+}
+
+func (T *qServer) svWriteLevelFile() error {
+
+	T.common.Com_DPrintf("SV_WriteLevelFile()\n")
+
+	name := fmt.Sprintf("%s/save/current/%s.sv2", T.common.FS_Gamedir(), T.sv.name)
+
+	var buf bytes.Buffer
+	for _, cs := range T.sv.configstrings {
+		buf.WriteString(cs)
+		buf.WriteByte(0)
+	}
+
+	buf.Write(T.common.CMWritePortalState())
+
+	if err := os.WriteFile(name, buf.Bytes(), 0644); err != nil {
+		T.common.Com_Printf("Failed to open %s\n", name)
+		return nil
+	}
+
+	name = fmt.Sprintf("%s/save/current/%s.sav", T.common.FS_Gamedir(), T.sv.name)
+	return T.ge.WriteLevel(name)
+}
+
+func (T *qServer) svReadLevelFile() error {
+
+	T.common.Com_DPrintf("SV_ReadLevelFile()\n")
+
+	name := fmt.Sprintf("%s/save/current/%s.sv2", T.common.FS_Gamedir(), T.sv.name)
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		T.common.Com_Printf("Failed to open %s\n", name)
+		return nil
+	}
+
+	for i := range T.sv.configstrings {
+		end := bytes.IndexByte(data, 0)
+		if end < 0 {
+			break
+		}
+
+		T.sv.configstrings[i] = string(data[:end])
+		data = data[end+1:]
+	}
+
+	T.common.CMReadPortalState(data)
+
+	name = fmt.Sprintf("%s/save/current/%s.sav", T.common.FS_Gamedir(), T.sv.name)
+	return T.ge.ReadLevel(name)
+}
+
+func (T *qServer) svWriteServerFile(autosave bool) error {
+
+	T.common.Com_DPrintf("SV_WriteServerFile(%v)\n", autosave)
+
+	filename := fmt.Sprintf("%s/save/current/server.ssv", T.common.FS_Gamedir())
+
+	var buf bytes.Buffer
+
+	/* write the comment field */
+	var comment string
+	if !autosave {
+		now := time.Now()
+		comment = fmt.Sprintf("%2d:%d%d %2d/%2d  ", now.Hour(), now.Minute()/10,
+			now.Minute()%10, int(now.Month()), now.Day())
+		comment += T.sv.configstrings[shared.CS_NAME]
+	} else {
+		/* autosaved */
+		comment = fmt.Sprintf("ENTERING %s", T.sv.configstrings[shared.CS_NAME])
+	}
+
+	writeFixedString(&buf, comment, SAVE_COMMENT_LENGTH)
+
+	/* write the mapcmd */
+	writeFixedString(&buf, T.svs.mapcmd, SAVE_MAPCMD_LENGTH)
+
+	/* write all CVAR_LATCH cvars
+	   these will be things like coop,
+	   skill, deathmatch, etc */
+	for name, value := range T.common.Cvar_LatchedVars() {
+		if (len(name) >= SAVE_CVAR_LENGTH-1) || (len(value) >= SAVE_CVAR_LENGTH-1) {
+			T.common.Com_Printf("Cvar too long: %s = %s\n", name, value)
+			continue
+		}
+
+		writeFixedString(&buf, name, SAVE_CVAR_LENGTH)
+		writeFixedString(&buf, value, SAVE_CVAR_LENGTH)
+	}
+
+	if err := os.WriteFile(filename, buf.Bytes(), 0644); err != nil {
+		T.common.Com_Printf("Couldn't write %s\n", filename)
+		return nil
+	}
+
+	/* write game state */
+	filename = fmt.Sprintf("%s/save/current/game.ssv", T.common.FS_Gamedir())
+	return T.ge.WriteGame(filename, autosave)
+}
+
+func (T *qServer) svReadServerFile() error {
+
+	T.common.Com_DPrintf("SV_ReadServerFile()\n")
+
+	filename := fmt.Sprintf("%s/save/current/server.ssv", T.common.FS_Gamedir())
+
+	data, err := os.ReadFile(filename)
+	if err != nil || len(data) < SAVE_COMMENT_LENGTH+SAVE_MAPCMD_LENGTH {
+		T.common.Com_Printf("Couldn't read %s\n", filename)
+		return nil
+	}
+
+	/* read the comment field */
+	_, data = readFixedString(data, SAVE_COMMENT_LENGTH)
+
+	/* read the mapcmd */
+	var mapcmd string
+	mapcmd, data = readFixedString(data, SAVE_MAPCMD_LENGTH)
+
+	/* read all CVAR_LATCH cvars
+	   these will be things like coop,
+	   skill, deathmatch, etc */
+	for len(data) >= 2*SAVE_CVAR_LENGTH {
+		var name, value string
+		name, data = readFixedString(data, SAVE_CVAR_LENGTH)
+		value, data = readFixedString(data, SAVE_CVAR_LENGTH)
+
+		T.common.Com_DPrintf("Set %s = %s\n", name, value)
+		T.common.Cvar_ForceSet(name, value)
+	}
+
+	/* start a new game fresh with new cvars */
+	if err := T.initGame(); err != nil {
+		return err
+	}
+
+	T.svs.mapcmd = mapcmd
+
+	/* read game state */
+	filename = fmt.Sprintf("%s/save/current/game.ssv", T.common.FS_Gamedir())
+	if err := T.ge.ReadGame(filename); err != nil {
+		return err
+	}
+
+	/* the game has allocated new edicts */
+	for i := range T.svs.clients {
+		T.svs.clients[i].edict = T.ge.Edict(i + 1)
+	}
+
+	return nil
+}
+
+/*
+ * Called by spawnServer. If the level was visited
+ * before, its state is restored from save/current.
+ */
+func (T *qServer) svCheckForSavegame() error {
+
+	if T.sv_noreload.Bool() {
+		return nil
+	}
+
+	if T.common.Cvar_VariableBool("deathmatch") {
+		return nil
+	}
+
+	name := fmt.Sprintf("%s/save/current/%s.sav", T.common.FS_Gamedir(), T.sv.name)
+	if _, err := os.Stat(name); err != nil {
+		return nil /* no savegame */
+	}
+
+	T.svClearWorld()
+
+	/* get configstrings and areaportals */
+	if err := T.svReadLevelFile(); err != nil {
+		return err
+	}
+
+	if !T.sv.loadgame {
+		/* coming back to a level after being in a different
+		   level, so run it for ten seconds */
+		previousState := T.sv.state
+		T.sv.state = ss_loading
+
+		for i := 0; i < 100; i++ {
+			if err := T.ge.RunFrame(); err != nil {
+				return err
+			}
+		}
+
+		T.sv.state = previousState
+	}
+
+	return nil
+}
+
+func sv_Loadgame_f(args []string, arg interface{}) error {
+
+	T := arg.(*qServer)
+	if len(args) != 2 {
+		T.common.Com_Printf("USAGE: load <directory>\n")
+		return nil
+	}
+
+	T.common.Com_Printf("Loading game...\n")
+
+	dir := args[1]
+	if strings.Contains(dir, "..") || strings.ContainsAny(dir, "/\\") {
+		T.common.Com_Printf("Bad savedir.\n")
+		return nil
+	}
+
+	/* make sure the server.ssv file exists */
+	name := fmt.Sprintf("%s/save/%s/server.ssv", T.common.FS_Gamedir(), dir)
+	if _, err := os.Stat(name); err != nil {
+		T.common.Com_Printf("No such savegame: %s\n", name)
+		return nil
+	}
+
+	T.svCopySaveGame(dir, "current")
+	if err := T.svReadServerFile(); err != nil {
+		return err
+	}
+
+	/* go to the map */
+	T.sv.state = ss_dead /* don't save current level when changing */
+	return T.svMap(false, T.svs.mapcmd, true)
+}
+
+func sv_Savegame_f(args []string, arg interface{}) error {
+
+	T := arg.(*qServer)
+	if T.sv.state != ss_game {
+		T.common.Com_Printf("You must be in a game to save.\n")
+		return nil
+	}
+
+	if len(args) != 2 {
+		T.common.Com_Printf("USAGE: save <directory>\n")
+		return nil
+	}
+
+	if T.common.Cvar_VariableBool("deathmatch") {
+		T.common.Com_Printf("Can't savegame in a deathmatch\n")
+		return nil
+	}
+
+	if args[1] == "current" {
+		T.common.Com_Printf("Can't save to 'current'\n")
+		return nil
+	}
+
+	if (T.maxclients.Int() == 1) &&
+		(T.svs.clients[0].edict.Client().Ps().Stats[shared.STAT_HEALTH] <= 0) {
+		T.common.Com_Printf("\nCan't savegame while dead!\n")
+		return nil
+	}
+
+	dir := args[1]
+	if strings.Contains(dir, "..") || strings.ContainsAny(dir, "/\\") {
+		T.common.Com_Printf("Bad savedir.\n")
+		return nil
+	}
+
+	T.common.Com_Printf("Saving game \"%s\"...\n", dir)
+
+	/* archive current level, including all client edicts.
+	   when the level is reloaded, they will be shells awaiting
+	   a connecting client */
+	T.common.FS_CreatePath(fmt.Sprintf("%s/save/current/", T.common.FS_Gamedir()))
+	if err := T.svWriteLevelFile(); err != nil {
+		return err
+	}
+
+	/* save server state */
+	if err := T.svWriteServerFile(false); err != nil {
+		return err
+	}
+
+	/* copy it off */
+	T.svCopySaveGame("current", dir)
+
+	T.common.Com_Printf("Done.\n")
+	return nil
+}
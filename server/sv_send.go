@@ -31,6 +31,62 @@ import (
 	"log"
 )
 
+func (T *qServer) flushRedirect(sv_redirected redirect_t, outputbuf string, adr shared.Netadr_t) {
+	if sv_redirected == RD_PACKET {
+		T.common.Netchan_OutOfBandPrint(shared.NS_SERVER, adr, "print\n%s", outputbuf)
+	} else if sv_redirected == RD_CLIENT {
+		T.sv_client.netchan.Message.WriteByte(shared.SvcPrint)
+		T.sv_client.netchan.Message.WriteByte(shared.PRINT_HIGH)
+		T.sv_client.netchan.Message.WriteString(outputbuf)
+	}
+}
+
+/*
+ * Sends text across to be displayed if the level passes
+ */
+func (T *qServer) svClientPrintf(cl *client_t, level int, format string, a ...interface{}) {
+
+	if level < cl.messagelevel {
+		return
+	}
+
+	cl.netchan.Message.WriteByte(shared.SvcPrint)
+	cl.netchan.Message.WriteByte(level)
+	cl.netchan.Message.WriteString(fmt.Sprintf(format, a...))
+}
+
+/*
+ * Sends text to all active clients
+ */
+func (T *qServer) svBroadcastPrintf(level int, format string, a ...interface{}) {
+
+	str := fmt.Sprintf(format, a...)
+
+	/* echo to console */
+	if T.common.IsDedicated() {
+		/* mask off high bits */
+		copy := []byte(str)
+		for i := range copy {
+			copy[i] &= 127
+		}
+		T.common.Com_Printf("%s", string(copy))
+	}
+
+	for i, cl := range T.svs.clients {
+		if level < cl.messagelevel {
+			continue
+		}
+
+		if cl.state != cs_spawned {
+			continue
+		}
+
+		T.svs.clients[i].netchan.Message.WriteByte(shared.SvcPrint)
+		T.svs.clients[i].netchan.Message.WriteByte(level)
+		T.svs.clients[i].netchan.Message.WriteString(str)
+	}
+}
+
 func (T *qServer) svSendClientDatagram(client *client_t) bool {
 	// byte msg_buf[MAX_MSGLEN];
 	// sizebuf_t msg;
@@ -48,19 +104,19 @@ func (T *qServer) svSendClientDatagram(client *client_t) bool {
 	   for this client out to the message
 	   it is necessary for this to be after the WriteEntities
 	   so that entity references will be current */
-	// if (client->datagram.overflowed) {
-	// 	Com_Printf("WARNING: datagram overflowed for %s\n", client->name);
-	// } else {
-	// 	SZ_Write(&msg, client->datagram.data, client->datagram.cursize);
-	// }
+	if client.datagram.Overflowed {
+		T.common.Com_Printf("WARNING: datagram overflowed for %s\n", client.name)
+	} else {
+		msg.Write(client.datagram.Data())
+	}
 
-	// SZ_Clear(&client->datagram);
+	client.datagram.Clear()
 
-	// if (msg.overflowed) {
-	// 	/* must have room left for the packet header */
-	// 	Com_Printf("WARNING: msg overflowed for %s\n", client->name);
-	// 	SZ_Clear(&msg);
-	// }
+	if msg.Overflowed {
+		/* must have room left for the packet header */
+		T.common.Com_Printf("WARNING: msg overflowed for %s\n", client.name)
+		msg.Clear()
+	}
 
 	/* send the datagram */
 	client.netchan.Transmit(msg.Data())
@@ -105,55 +161,44 @@ func (T *qServer) svBroadcastCommand(format string, a ...interface{}) {
  * MULTICAST_PHS	send to clients potentially hearable from org
  */
 func (T *qServer) svMulticast(origin []float32, to shared.Multicast_t) {
-	//  client_t *client;
-	//  byte *mask;
-	//  int leafnum = 0, cluster;
-	//  int j;
-	//  qboolean reliable;
-	//  int area1, area2;
-
+	var mask []byte
 	reliable := false
+	area1 := 0
 
-	//  if ((to != shared.MULTICAST_ALL_R) && (to != shared.MULTICAST_ALL)) {
-	// 	 leafnum = CM_PointLeafnum(origin);
-	// 	 area1 = CM_LeafArea(leafnum);
-	//  }
-	//  else
-	//  {
-	// 	 area1 = 0;
-	//  }
+	if (to != shared.MULTICAST_ALL_R) && (to != shared.MULTICAST_ALL) {
+		leafnum := T.common.CMPointLeafnum(origin)
+		area1 = T.common.CMLeafArea(leafnum)
+	}
 
 	/* if doing a serverrecord, store everything */
-	//  if (svs.demofile) {
-	// 	 SZ_Write(&svs.demo_multicast, sv.multicast.data, sv.multicast.cursize);
-	//  }
+	if T.svs.demofile != nil {
+		T.svs.demo_multicast.Write(T.sv.multicast.Data())
+	}
 
 	switch to {
 	case shared.MULTICAST_ALL_R:
 		reliable = true /* intentional fallthrough */
 		fallthrough
 	case shared.MULTICAST_ALL:
-		//  mask = NULL;
-		break
-
-		//  case MULTICAST_PHS_R:
-		// 	 reliable = true; /* intentional fallthrough */
-		//  case MULTICAST_PHS:
-		// 	 leafnum = CM_PointLeafnum(origin);
-		// 	 cluster = CM_LeafCluster(leafnum);
-		// 	 mask = CM_ClusterPHS(cluster);
-		// 	 break;
-
-		//  case MULTICAST_PVS_R:
-		// 	 reliable = true; /* intentional fallthrough */
-		//  case MULTICAST_PVS:
-		// 	 leafnum = CM_PointLeafnum(origin);
-		// 	 cluster = CM_LeafCluster(leafnum);
-		// 	 mask = CM_ClusterPVS(cluster);
-		// 	 break;
+		mask = nil
+
+	case shared.MULTICAST_PHS_R:
+		reliable = true /* intentional fallthrough */
+		fallthrough
+	case shared.MULTICAST_PHS:
+		leafnum := T.common.CMPointLeafnum(origin)
+		cluster := T.common.CMLeafCluster(leafnum)
+		mask = T.common.CMClusterPHS(cluster)
+
+	case shared.MULTICAST_PVS_R:
+		reliable = true /* intentional fallthrough */
+		fallthrough
+	case shared.MULTICAST_PVS:
+		leafnum := T.common.CMPointLeafnum(origin)
+		cluster := T.common.CMLeafCluster(leafnum)
+		mask = T.common.CMClusterPVS(cluster)
 
 	default:
-		// mask = NULL
 		log.Fatalf("SV_Multicast: bad to:%v", to)
 	}
 
@@ -167,32 +212,158 @@ func (T *qServer) svMulticast(origin []float32, to shared.Multicast_t) {
 			continue
 		}
 
-		//  if (mask) {
-		// 	 leafnum = CM_PointLeafnum(client->edict->s.origin);
-		// 	 cluster = CM_LeafCluster(leafnum);
-		// 	 area2 = CM_LeafArea(leafnum);
+		if mask != nil {
+			leafnum := T.common.CMPointLeafnum(client.edict.S().Origin[:])
+			cluster := T.common.CMLeafCluster(leafnum)
+			area2 := T.common.CMLeafArea(leafnum)
 
-		// 	 if (!CM_AreasConnected(area1, area2))
-		// 	 {
-		// 		 continue;
-		// 	 }
+			if !T.common.CMAreasConnected(area1, area2) {
+				continue
+			}
 
-		// 	 if (!(mask[cluster >> 3] & (1 << (cluster & 7))))
-		// 	 {
-		// 		 continue;
-		// 	 }
-		//  }
+			if (mask[cluster>>3] & (1 << (cluster & 7))) == 0 {
+				continue
+			}
+		}
 
 		if reliable {
 			T.svs.clients[j].netchan.Message.Write(T.sv.multicast.Data())
 		} else {
-			// T.svs.clients[j].datagram.Write(T.sv.multicast.Data())
+			T.svs.clients[j].datagram.Write(T.sv.multicast.Data())
 		}
 	}
 
 	T.sv.multicast.Clear()
 }
 
+/*
+ * Each entity can have eight independant sound sources, like voice,
+ * weapon, feet, etc.
+ *
+ * If channel & 8, the sound will be sent to everyone, not just
+ * things in the PHS.
+ *
+ * Channel 0 is an auto-allocate channel, the others override anything
+ * already running on that entity/channel pair.
+ *
+ * An attenuation of 0 will play full volume everywhere in the level.
+ * Larger attenuations will drop off.  (max 4 attenuation)
+ *
+ * Timeofs can range from 0.0 to 0.1 to cause sounds to be started
+ * later in the frame than they normally would.
+ *
+ * If origin is NULL, the origin is determined from the entity origin
+ * or the midpoint of the entity box for bmodels.
+ */
+func (T *qServer) svStartSound(origin []float32, entity shared.Edict_s, channel, soundindex int,
+	volume, attenuation, timeofs float32) error {
+
+	if (volume < 0) || (volume > 1.0) {
+		return T.common.Com_Error(shared.ERR_FATAL, "SV_StartSound: volume = %v", volume)
+	}
+
+	if (attenuation < 0) || (attenuation > 4) {
+		return T.common.Com_Error(shared.ERR_FATAL, "SV_StartSound: attenuation = %v", attenuation)
+	}
+
+	if (timeofs < 0) || (timeofs > 0.255) {
+		return T.common.Com_Error(shared.ERR_FATAL, "SV_StartSound: timeofs = %v", timeofs)
+	}
+
+	ent := entity.S().Number
+
+	use_phs := true
+	if (channel & 8) != 0 { /* no PHS flag */
+		use_phs = false
+		channel &= 7
+	}
+
+	sendchan := (ent << 3) | (channel & 7)
+
+	flags := 0
+	if volume != shared.DEFAULT_SOUND_PACKET_VOLUME {
+		flags |= shared.SND_VOLUME
+	}
+
+	if attenuation != shared.DEFAULT_SOUND_PACKET_ATTENUATION {
+		flags |= shared.SND_ATTENUATION
+	}
+
+	/* the client doesn't know that bmodels have
+	   weird origins the origin can also be
+	   explicitly set */
+	if (entity.Svflags()&shared.SVF_NOCLIENT) != 0 ||
+		(entity.Solid() == shared.SOLID_BSP) || origin != nil {
+		flags |= shared.SND_POS
+	}
+
+	/* always send the entity number for channel overrides */
+	flags |= shared.SND_ENT
+
+	if timeofs != 0 {
+		flags |= shared.SND_OFFSET
+	}
+
+	/* use the entity origin unless it is a bmodel or explicitly specified */
+	if origin == nil {
+		origin = make([]float32, 3)
+
+		if entity.Solid() == shared.SOLID_BSP {
+			for i := 0; i < 3; i++ {
+				origin[i] = entity.S().Origin[i] + 0.5*(entity.Mins()[i]+entity.Maxs()[i])
+			}
+		} else {
+			copy(origin, entity.S().Origin[:])
+		}
+	}
+
+	T.sv.multicast.WriteByte(shared.SvcSound)
+	T.sv.multicast.WriteByte(flags)
+	T.sv.multicast.WriteByte(soundindex)
+
+	if (flags & shared.SND_VOLUME) != 0 {
+		T.sv.multicast.WriteByte(int(volume * 255))
+	}
+
+	if (flags & shared.SND_ATTENUATION) != 0 {
+		T.sv.multicast.WriteByte(int(attenuation * 64))
+	}
+
+	if (flags & shared.SND_OFFSET) != 0 {
+		T.sv.multicast.WriteByte(int(timeofs * 1000))
+	}
+
+	if (flags & shared.SND_ENT) != 0 {
+		T.sv.multicast.WriteShort(sendchan)
+	}
+
+	if (flags & shared.SND_POS) != 0 {
+		T.sv.multicast.WritePos(origin)
+	}
+
+	/* if the sound doesn't attenuate,send it to everyone
+	   (global radio chatter, voiceovers, etc) */
+	if attenuation == shared.ATTN_NONE {
+		use_phs = false
+	}
+
+	if (channel & shared.CHAN_RELIABLE) != 0 {
+		if use_phs {
+			T.svMulticast(origin, shared.MULTICAST_PHS_R)
+		} else {
+			T.svMulticast(origin, shared.MULTICAST_ALL_R)
+		}
+	} else {
+		if use_phs {
+			T.svMulticast(origin, shared.MULTICAST_PHS)
+		} else {
+			T.svMulticast(origin, shared.MULTICAST_ALL)
+		}
+	}
+
+	return nil
+}
+
 func (T *qServer) svSendClientMessages() {
 
 	var msgbuf []byte
@@ -237,10 +408,9 @@ func (T *qServer) svSendClientMessages() {
 		   client */
 		if c.netchan.Message.Overflowed {
 			T.svs.clients[i].netchan.Message.Clear()
-			// 		SZ_Clear(&c->netchan.message);
-			// 		SZ_Clear(&c->datagram);
-			// SV_BroadcastPrintf(PRINT_HIGH, "%s overflowed\n", c->name);
-			// 		SV_DropClient(c);
+			T.svs.clients[i].datagram.Clear()
+			T.svBroadcastPrintf(shared.PRINT_HIGH, "%s overflowed\n", c.name)
+			T.svDropClient(&T.svs.clients[i])
 		}
 
 		if (T.sv.state == ss_cinematic) ||
@@ -258,6 +258,14 @@ func sv_Nextserver_f(args []string, T *qServer) error {
 	return nil
 }
 
+/*
+ * The client is going to disconnect, so remove the connection immediately
+ */
+func sv_Disconnect_f(args []string, T *qServer) error {
+	T.svDropClient(T.sv_client)
+	return nil
+}
+
 var ucmds = map[string](func([]string, *qServer) error){
 	/* auto issued */
 	"new":           sv_New_f,
@@ -265,7 +273,7 @@ var ucmds = map[string](func([]string, *qServer) error){
 	"baselines":     sv_Baselines_f,
 	"begin":         sv_Begin_f,
 	"nextserver":    sv_Nextserver_f,
-	// {"disconnect", SV_Disconnect_f},
+	"disconnect":    sv_Disconnect_f,
 
 	// /* issued by hand at client consoles */
 	// {"info", SV_ShowServerinfo_f},
@@ -291,6 +299,7 @@ func (T *qServer) executeUserCommand(s string) error {
 
 	println("executeUserCommand", args[0])
 	if T.sv.state == ss_game {
+		T.game_args = args
 		T.ge.ClientCommand(T.sv_player, args)
 	}
 	return nil
@@ -333,7 +342,7 @@ func (T *qServer) executeClientMessage(cl *client_t, msg *shared.QReadbuf) error
 	for {
 		if msg.IsOver() {
 			T.common.Com_Printf("SV_ReadClientMessage: badread\n")
-			// SV_DropClient(cl)
+			T.svDropClient(cl)
 			return nil
 		}
 
@@ -348,10 +357,9 @@ func (T *qServer) executeClientMessage(cl *client_t, msg *shared.QReadbuf) error
 		case shared.ClcNop:
 			break
 
-			// 		 case clc_userinfo:
-			// 			 Q_strlcpy(cl->userinfo, MSG_ReadString(&net_message), sizeof(cl->userinfo));
-			// 			 SV_UserinfoChanged(cl);
-			// 			 break;
+		case shared.ClcUserinfo:
+			cl.userinfo = msg.ReadString()
+			T.userinfoChanged(cl)
 
 		case shared.ClcMove:
 
@@ -367,10 +375,10 @@ func (T *qServer) executeClientMessage(cl *client_t, msg *shared.QReadbuf) error
 			if lastframe != cl.lastframe {
 				cl.lastframe = lastframe
 
-				// if cl.lastframe > 0 {
-				// 	cl.frame_latency[cl.lastframe&(LATENCY_COUNTS-1)] =
-				// 		svs.realtime - cl.frames[cl.lastframe&UPDATE_MASK].senttime
-				// }
+				if cl.lastframe > 0 {
+					cl.frame_latency[cl.lastframe&(LATENCY_COUNTS-1)] =
+						T.svs.realtime - cl.frames[cl.lastframe&shared.UPDATE_MASK].senttime
+				}
 			}
 
 			// 			 memset(&nullcmd, 0, sizeof(nullcmd));
@@ -440,7 +448,7 @@ func (T *qServer) executeClientMessage(cl *client_t, msg *shared.QReadbuf) error
 
 		default:
 			T.common.Com_Printf("SV_ReadClientMessage: unknown command char\n")
-			// 			 SV_DropClient(cl);
+			T.svDropClient(cl)
 			return nil
 		}
 	}
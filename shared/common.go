@@ -73,7 +73,7 @@ const (
 	SvcInventory    = 5
 
 	/* the rest are private to the client and server */
-	SvcNop                 = 5
+	SvcNop                 = 6
 	SvcDisconnect          = 7
 	SvcReconnect           = 8
 	SvcSound               = 9  /* <see code> */
@@ -198,6 +198,7 @@ const (
 type Netadr_t struct {
 	Type Netadrtype_t
 	Ip   [16]byte
+	Zone string /* IPv6 scope of link local addresses */
 	Port uint16
 }
 
@@ -65,6 +65,7 @@ type Gclient_s interface {
 	// int ping;
 	Ps() *Player_state_t
 	Ping() int
+	SetPing(v int)
 	/* the game dll can add anything it wants
 	   after  this point in the structure */
 }
@@ -73,6 +74,7 @@ type Edict_s interface {
 	S() *Entity_state_t
 	Client() Gclient_s
 	Inuse() bool
+	SetInuse(v bool)
 	Linkcount() int
 	SetLinkcount(v int)
 
@@ -108,14 +110,14 @@ type Edict_s interface {
 /* functions provided by the main engine */
 type Game_import_t interface {
 	/* special messages */
-	// void (*bprintf)(int printlevel, char *fmt, ...);
+	Bprintf(printlevel int, format string, a ...interface{})
 	Dprintf(format string, a ...interface{})
-	// void (*cprintf)(edict_t *ent, int printlevel, char *fmt, ...);
-	// void (*centerprintf)(edict_t *ent, char *fmt, ...);
-	// void (*sound)(edict_t *ent, int channel, int soundindex, float volume,
-	// 		float attenuation, float timeofs);
-	// void (*positioned_sound)(vec3_t origin, edict_t *ent, int channel,
-	// 		int soundinedex, float volume, float attenuation, float timeofs);
+	Cprintf(ent Edict_s, printlevel int, format string, a ...interface{})
+	Centerprintf(ent Edict_s, format string, a ...interface{})
+	Sound(ent Edict_s, channel, soundindex int, volume,
+		attenuation, timeofs float32) error
+	PositionedSound(origin []float32, ent Edict_s, channel,
+		soundindex int, volume, attenuation, timeofs float32) error
 
 	/* config strings hold all the index strings, the lightstyles,
 	   and misc data like the sky definition and cdtrack.
@@ -138,8 +140,8 @@ type Game_import_t interface {
 	Pointcontents(point []float32) int
 	InPVS(p1, p2 []float32) bool
 	InPHS(p1, p2 []float32) bool
-	// void (*SetAreaPortalState)(int portalnum, qboolean open);
-	// qboolean (*AreasConnected)(int area1, int area2);
+	SetAreaPortalState(portalnum int, open bool) error
+	AreasConnected(area1, area2 int) bool
 
 	/* an entity will never be sent to a client or used for collision
 	   if it is not passed to linkentity. If the size, position, or
@@ -149,18 +151,18 @@ type Game_import_t interface {
 	BoxEdicts(mins, maxs []float32, edicts []Edict_s, maxcount, areatype int) int
 	Pmove(pmove *Pmove_t) /* player movement code common with client prediction */
 
-	// /* network messaging */
-	// void (*multicast)(vec3_t origin, multicast_t to);
-	// void (*unicast)(edict_t *ent, qboolean reliable);
-	// void (*WriteChar)(int c);
-	// void (*WriteByte)(int c);
-	// void (*WriteShort)(int c);
-	// void (*WriteLong)(int c);
-	// void (*WriteFloat)(float f);
-	// void (*WriteString)(char *s);
-	// void (*WritePosition)(vec3_t pos); /* some fractional bits */
-	// void (*WriteDir)(vec3_t pos); /* single byte encoded, very coarse */
-	// void (*WriteAngle)(float f);
+	/* network messaging */
+	Multicast(origin []float32, to Multicast_t)
+	Unicast(ent Edict_s, reliable bool)
+	WriteChar(c int)
+	WriteUByte(c int) /* MSG_WriteByte, named so it isn't taken for io.ByteWriter */
+	WriteShort(c int)
+	WriteLong(c int)
+	WriteFloat(f float32)
+	WriteString(s string)
+	WritePosition(pos []float32) /* some fractional bits */
+	WriteDir(pos []float32)      /* single byte encoded, very coarse */
+	WriteAngle(f float32)
 
 	// /* managed memory allocation */
 	// void *(*TagMalloc)(int size, int tag);
@@ -172,14 +174,14 @@ type Game_import_t interface {
 	// cvar_t *(*cvar_set)(char *var_name, char *value);
 	// cvar_t *(*cvar_forceset)(char *var_name, char *value);
 
-	// /* ClientCommand and ServerCommand parameter access */
-	// int (*argc)(void);
-	// char *(*argv)(int n);
-	// char *(*args)(void); /* concatenation of all argv >= 1 */
+	/* ClientCommand and ServerCommand parameter access */
+	Argc() int
+	Argv(n int) string
+	Args() string /* concatenation of all argv >= 1 */
 
-	// /* add commands to the server console as if
-	//    they were typed in for map changing, etc */
-	// void (*AddCommandString)(char *text);
+	/* add commands to the server console as if
+	   they were typed in for map changing, etc */
+	AddCommandString(text string)
 
 	// void (*DebugGraph)(float value, int color);
 }
@@ -197,33 +199,33 @@ type Game_export_t interface {
 	/* each new level entered will cause a call to SpawnEntities */
 	SpawnEntities(mapname, entstring, spawnpoint string) error
 
-	// /* Read/Write Game is for storing persistant cross level information
-	//    about the world state and the clients.
-	//    WriteGame is called every time a level is exited.
-	//    ReadGame is called on a loadgame. */
-	// void (*WriteGame)(char *filename, qboolean autosave);
-	// void (*ReadGame)(char *filename);
+	/* Read/Write Game is for storing persistant cross level information
+	   about the world state and the clients.
+	   WriteGame is called every time a level is exited.
+	   ReadGame is called on a loadgame. */
+	WriteGame(filename string, autosave bool) error
+	ReadGame(filename string) error
 
-	// /* ReadLevel is called after the default
-	//    map information has been loaded with
-	//    SpawnEntities */
-	// void (*WriteLevel)(char *filename);
-	// void (*ReadLevel)(char *filename);
+	/* ReadLevel is called after the default
+	   map information has been loaded with
+	   SpawnEntities */
+	WriteLevel(filename string) error
+	ReadLevel(filename string) error
 
-	ClientConnect(ent Edict_s, userinfo string) bool
+	ClientConnect(ent Edict_s, userinfo *string) bool
 	ClientBegin(ent Edict_s) error
-	// void (*ClientUserinfoChanged)(edict_t *ent, char *userinfo);
-	// void (*ClientDisconnect)(edict_t *ent);
+	ClientUserinfoChanged(ent Edict_s, userinfo *string)
+	ClientDisconnect(ent Edict_s)
 	ClientCommand(ent Edict_s, args []string)
 	ClientThink(ent Edict_s, cmd *Usercmd_t)
 
 	RunFrame() error
 
-	// /* ServerCommand will be called when an "sv <command>"
-	//    command is issued on the  server console. The game can
-	//    issue gi.argc() / gi.argv() commands to get the rest
-	//    of the parameters */
-	// void (*ServerCommand)(void);
+	/* ServerCommand will be called when an "sv <command>"
+	   command is issued on the  server console. The game can
+	   issue gi.argc() / gi.argv() commands to get the rest
+	   of the parameters */
+	ServerCommand()
 
 	/* global variables shared between game and server */
 
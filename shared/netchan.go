@@ -36,7 +36,7 @@ type Netchan_t struct {
 	last_received int /* for timeouts */
 	LastSent      int /* for retransmits */
 
-	remote_address Netadr_t
+	Remote_address Netadr_t
 	Qport          int /* qport value to write when transmitting */
 
 	/* sequencing variables */
@@ -70,7 +70,7 @@ func (ch *Netchan_t) Setup(common QCommon, sock Netsrc_t, adr Netadr_t, qport in
 	ch.Dropped = 0
 	ch.last_received = common.Curtime()
 	ch.LastSent = 0
-	ch.remote_address = adr
+	ch.Remote_address = adr
 	ch.Qport = qport
 	ch.incoming_sequence = 0
 	ch.Incoming_acknowledged = 0
@@ -125,8 +125,8 @@ func (ch *Netchan_t) Transmit(data []byte) {
 	/* check for message overflow */
 	if ch.Message.Overflowed {
 		ch.fatal_error = true
-		ch.common.Com_Printf("%v:Outgoing message overflow\n",
-			ch.remote_address)
+		ch.common.Com_Printf("%s:Outgoing message overflow\n",
+			NET_AdrToString(ch.Remote_address))
 		return
 	}
 
@@ -176,7 +176,7 @@ func (ch *Netchan_t) Transmit(data []byte) {
 	}
 
 	/* send the datagram */
-	ch.common.NET_SendPacket(ch.sock, send.Data(), ch.remote_address)
+	ch.common.NET_SendPacket(ch.sock, send.Data(), ch.Remote_address)
 
 	if ch.common.Showpackets() {
 		if send_reliable {
@@ -233,8 +233,7 @@ func (ch *Netchan_t) Process(msg *QReadbuf) bool {
 		// 	 if (showdrop->value)
 		// 	 {
 		ch.common.Com_Printf("%s:Out of order packet %v at %v\n",
-			//  NET_AdrToString(ch.remote_address),
-			ch.remote_address,
+			NET_AdrToString(ch.Remote_address),
 			sequence, ch.incoming_sequence)
 		// 	 }
 
@@ -248,8 +247,7 @@ func (ch *Netchan_t) Process(msg *QReadbuf) bool {
 		// 	 if (showdrop->value)
 		// 	 {
 		ch.common.Com_Printf("%s:Dropped %v packets at %v (%v)\n",
-			//  NET_AdrToString(chan->remote_address),
-			ch.remote_address,
+			NET_AdrToString(ch.Remote_address),
 			ch.Dropped, sequence, ch.incoming_sequence)
 		// 	 }
 	}
@@ -25,6 +25,135 @@
  */
 package shared
 
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+/* IPv6 multicast group the servers join for LAN discovery */
+const QUAKE2MCAST = "ff12::666"
+
+/*
+ * Compares two addresses, including the port.
+ */
+func NET_CompareAdr(a, b Netadr_t) bool {
+	return NET_CompareBaseAdr(a, b) && a.Port == b.Port
+}
+
+/*
+ * Compares without the port.
+ */
+func NET_CompareBaseAdr(a, b Netadr_t) bool {
+	if a.Type != b.Type {
+		return false
+	}
+
+	switch a.Type {
+	case NA_LOOPBACK:
+		return true
+	case NA_IP:
+		return a.Ip[0] == b.Ip[0] && a.Ip[1] == b.Ip[1] &&
+			a.Ip[2] == b.Ip[2] && a.Ip[3] == b.Ip[3]
+	case NA_IP6:
+		return a.Ip == b.Ip && a.Zone == b.Zone
+	}
+
+	return false
+}
+
+func NET_BaseAdrToString(a Netadr_t) string {
+	switch a.Type {
+	case NA_LOOPBACK:
+		return "loopback"
+	case NA_BROADCAST:
+		return "255.255.255.255"
+	case NA_IP:
+		return net.IPv4(a.Ip[0], a.Ip[1], a.Ip[2], a.Ip[3]).String()
+	case NA_IP6:
+		if len(a.Zone) > 0 {
+			return net.IP(a.Ip[:]).String() + "%" + a.Zone
+		}
+		return net.IP(a.Ip[:]).String()
+	case NA_MULTICAST6:
+		return QUAKE2MCAST
+	}
+	return "unknown"
+}
+
+func NET_AdrToString(a Netadr_t) string {
+	if a.Type == NA_LOOPBACK {
+		return "loopback"
+	}
+
+	if a.Type == NA_IP6 || a.Type == NA_MULTICAST6 {
+		return fmt.Sprintf("[%s]:%d", NET_BaseAdrToString(a), a.Port)
+	}
+
+	return fmt.Sprintf("%s:%d", NET_BaseAdrToString(a), a.Port)
+}
+
+func NET_IsLocalAddress(adr Netadr_t) bool {
+	return adr.Type == NA_LOOPBACK
+}
+
+/*
+ * Converts a resolved UDP address into a netadr_t.
+ * IPv4 mapped IPv6 addresses are turned into plain
+ * IPv4 addresses.
+ */
+func NET_UDPAddrToAdr(addr *net.UDPAddr) *Netadr_t {
+	a := &Netadr_t{}
+	a.Port = uint16(addr.Port)
+
+	if ip4 := addr.IP.To4(); ip4 != nil {
+		a.Type = NA_IP
+		copy(a.Ip[:], ip4)
+		return a
+	}
+
+	ip6 := addr.IP.To16()
+	if ip6 == nil {
+		return nil
+	}
+
+	if ip6.Equal(net.ParseIP(QUAKE2MCAST)) {
+		a.Type = NA_MULTICAST6
+	} else {
+		a.Type = NA_IP6
+		a.Zone = addr.Zone
+	}
+	copy(a.Ip[:], ip6)
+	return a
+}
+
+/*
+ * Converts a netadr_t into an UDP address
+ * usable with the sockets API.
+ */
+func NET_AdrToUDPAddr(a Netadr_t) *net.UDPAddr {
+	switch a.Type {
+	case NA_BROADCAST:
+		return &net.UDPAddr{IP: net.IPv4bcast, Port: int(a.Port)}
+	case NA_IP:
+		return &net.UDPAddr{IP: net.IPv4(a.Ip[0], a.Ip[1], a.Ip[2], a.Ip[3]), Port: int(a.Port)}
+	case NA_IP6:
+		ip := make(net.IP, net.IPv6len)
+		copy(ip, a.Ip[:])
+		return &net.UDPAddr{IP: ip, Port: int(a.Port), Zone: a.Zone}
+	case NA_MULTICAST6:
+		return &net.UDPAddr{IP: net.ParseIP(QUAKE2MCAST), Port: int(a.Port)}
+	}
+	return nil
+}
+
+/*
+ * Parses "localhost", "host", "host:port", a bare
+ * IPv6 address or "[address]:port". A missing port
+ * is returned as 0, the callers replace it with
+ * their default.
+ */
 func NET_StringToAdr(s string) *Netadr_t {
 
 	if s == "localhost" {
@@ -33,12 +162,23 @@ func NET_StringToAdr(s string) *Netadr_t {
 		return &a
 	}
 
-	//  if (!NET_StringToSockaddr(s, &sadr))
-	//  {
-	// 	 return false;
-	//  }
+	host := s
+	port := 0
+	if h, p, err := net.SplitHostPort(s); err == nil {
+		host = h
+		pi, err := strconv.ParseUint(p, 10, 16)
+		if err != nil {
+			return nil
+		}
+		port = int(pi)
+	} else if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		host = s[1 : len(s)-1]
+	}
 
-	//  SockadrToNetadr(&sadr, a);
+	addr, err := net.ResolveUDPAddr("udp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return nil
+	}
 
-	return nil
+	return NET_UDPAddrToAdr(addr)
 }
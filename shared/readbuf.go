@@ -43,6 +43,10 @@ func (msg *QReadbuf) Size() int {
 	return len(msg.data)
 }
 
+func (msg *QReadbuf) Data() []byte {
+	return msg.data
+}
+
 func (msg *QReadbuf) Count() int {
 	return msg.readcount
 }
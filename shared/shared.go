@@ -29,6 +29,7 @@
 package shared
 
 import (
+	"errors"
 	"math"
 	"math/rand"
 	"strconv"
@@ -38,6 +39,10 @@ import (
 const (
 	MAX_QPATH = 64 /* max length of a quake game pathname */
 
+	MAX_INFO_KEY    = 64
+	MAX_INFO_VALUE  = 64
+	MAX_INFO_STRING = 512
+
 	/* angle indexes */
 	PITCH = 0 /* up / down */
 	YAW   = 1 /* left / right */
@@ -1352,7 +1357,7 @@ func COM_Parse(data string, index int) (string, int) {
  */
 func Info_ValueForKey(s, key string) string {
 
-	split := strings.Split(s, "\\")
+	split := strings.Split(strings.TrimPrefix(s, "\\"), "\\")
 	index := 0
 	for index < len(split)-1 {
 		if split[index] == key {
@@ -1364,6 +1369,73 @@ func Info_ValueForKey(s, key string) string {
 	return ""
 }
 
+func Info_RemoveKey(s, key string) string {
+
+	if strings.Contains(key, "\\") {
+		return s
+	}
+
+	split := strings.Split(strings.TrimPrefix(s, "\\"), "\\")
+	var r strings.Builder
+	for index := 0; index < len(split)-1; index += 2 {
+		if split[index] == key {
+			continue
+		}
+		r.WriteString("\\")
+		r.WriteString(split[index])
+		r.WriteString("\\")
+		r.WriteString(split[index+1])
+	}
+
+	return r.String()
+}
+
+/*
+ * Some characters are illegal in info strings
+ * because they can mess up the server's parsing
+ */
+func Info_Validate(s string) bool {
+	return !strings.ContainsAny(s, "\";")
+}
+
+/*
+ * Sets key to value in the info string s. On error
+ * the unmodified string is returned together with
+ * the reason, printing it is up to the caller.
+ */
+func Info_SetValueForKey(s, key, value string) (string, error) {
+
+	if strings.ContainsAny(key, "\\;\"") || strings.ContainsAny(value, "\\;\"") {
+		return s, errors.New("Can't use keys or values with a \\")
+	}
+
+	if len(key) > MAX_INFO_KEY-1 || len(value) > MAX_INFO_VALUE-1 {
+		return s, errors.New("Keys and values must be < 64 characters.")
+	}
+
+	s = Info_RemoveKey(s, key)
+
+	if len(value) == 0 {
+		return s, nil
+	}
+
+	n := s + "\\" + key + "\\" + value
+	if len(n) > MAX_INFO_STRING {
+		return s, errors.New("Info string length exceeded")
+	}
+
+	/* only copy ascii values */
+	var r strings.Builder
+	for _, c := range []byte(n) {
+		c &= 127
+		if c >= 32 && c < 127 {
+			r.WriteByte(c)
+		}
+	}
+
+	return r.String(), nil
+}
+
 /*
  * Generate a pseudorandom
  * integer >0.
@@ -1403,6 +1475,9 @@ type QCommon interface {
 	Com_Printf(format string, a ...interface{})
 	Com_DPrintf(format string, a ...interface{})
 	Com_Error(code int, format string, a ...interface{}) error
+	Com_BeginRedirect(target, buffersize int, flush func(target int, buffer string))
+	Com_EndRedirect()
+	Info_Print(s string)
 	Com_Quit()
 
 	Cvar_Get(var_name, var_value string, flags int) *CvarT
@@ -1413,6 +1488,9 @@ type QCommon interface {
 	Cvar_VariableInt(var_name string) int
 	Cvar_VariableString(var_name string) string
 	Cvar_Userinfo() string
+	Cvar_Serverinfo() string
+	Cvar_LatchedVars() map[string]string
+	Cvar_UserinfoModified() bool
 	Cvar_ClearUserinfoModified()
 	Cvar_GetLatchedVars()
 
@@ -1420,6 +1498,7 @@ type QCommon interface {
 	Cbuf_Execute() error
 	Cmd_AddCommand(cmd_name string, function func([]string, interface{}) error, arg interface{})
 	Cmd_TokenizeString(text string, macroExpand bool) []string
+	Cmd_ExecuteString(text string) error
 
 	Netchan_OutOfBandPrint(net_socket Netsrc_t, adr Netadr_t, format string, a ...interface{}) error
 
@@ -1429,6 +1508,8 @@ type QCommon interface {
 
 	FS_FOpenFile(name string, gamedir_only bool) (QFileHandle, error)
 	LoadFile(path string) ([]byte, error)
+	FS_Gamedir() string
+	FS_CreatePath(path string)
 
 	Pmove(pmove *Pmove_t)
 
@@ -1443,7 +1524,10 @@ type QCommon interface {
 	CMClusterPVS(cluster int) []byte
 	CMClusterPHS(cluster int) []byte
 	CMBoxLeafnums(mins, maxs []float32, list []int, listsize int, topnode *int) int
+	CMSetAreaPortalState(portalnum int, open bool) error
 	CMAreasConnected(area1, area2 int) bool
+	CMWritePortalState() []byte
+	CMReadPortalState(data []byte)
 	CMHeadnodeVisible(nodenum int, visbits []byte) bool
 	CMBoxTrace(start, end, mins, maxs []float32, headnode, brushmask int) Trace_t
 	CMHeadnodeForBox(mins, maxs []float32) int
@@ -25,7 +25,10 @@
  */
 package shared
 
-import "log"
+import (
+	"log"
+	"math"
+)
 
 type QWritebuf struct {
 	Allowoverflow bool /* if false, do a Com_Error */
@@ -99,6 +102,11 @@ func (sb *QWritebuf) WriteShort(c int) {
 	buf[1] = byte(c >> 8)
 }
 
+func (sb *QWritebuf) WriteFloat(f float32) {
+
+	sb.WriteLong(int(int32(math.Float32bits(f))))
+}
+
 func (sb *QWritebuf) Write(data []byte) {
 	buf := sb.getSpace(len(data))
 	copy(buf, data)
@@ -132,6 +140,33 @@ func (sb *QWritebuf) WriteCoord(f float32) {
 	sb.WriteShort(int(f * 8))
 }
 
+func (sb *QWritebuf) WritePos(pos []float32) {
+	sb.WriteShort(int(pos[0] * 8))
+	sb.WriteShort(int(pos[1] * 8))
+	sb.WriteShort(int(pos[2] * 8))
+}
+
+func (sb *QWritebuf) WriteDir(dir []float32) {
+
+	if dir == nil {
+		sb.WriteByte(0)
+		return
+	}
+
+	var bestd float32
+	best := 0
+
+	for i := range bytedirs {
+		d := DotProduct(dir, bytedirs[i])
+		if d > bestd {
+			bestd = d
+			best = i
+		}
+	}
+
+	sb.WriteByte(best)
+}
+
 func (sb *QWritebuf) WriteAngle(f float32) {
 	sb.WriteByte(int(f*256/360) & 255)
 }